@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+)
+
+// Stream consumes `sf.substreams.rpc.v2.Stream/Blocks`, keeping track of the
+// cursor of the last processed block. When the server goes away (`Unavailable`
+// or `Internal` errors), the stream transparently reconnects from that cursor,
+// waiting an exponentially growing delay between attempts.
+//
+// Undo signals move the cursor back to their `last_valid_cursor`, so that a
+// reconnection replays the blocks from the new canonical chain.
+type Stream struct {
+	client   pbsubstreamsrpc.StreamClient
+	callOpts []grpc.CallOption
+	request  *pbsubstreamsrpc.Request
+	cursor   string
+
+	onResponse        func(ctx context.Context, resp *pbsubstreamsrpc.Response) error
+	onSessionInit     func(ctx context.Context, session *pbsubstreamsrpc.SessionInit) error
+	onProgress        func(ctx context.Context, progress *pbsubstreamsrpc.ModulesProgress) error
+	onBlockScopedData func(ctx context.Context, data *pbsubstreamsrpc.BlockScopedData) error
+	onBlockUndoSignal func(ctx context.Context, undo *pbsubstreamsrpc.BlockUndoSignal) error
+	onReconnect       func(attempt int, err error, delay time.Duration)
+	onConnected       func()
+
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	maxAttempts    int // 0 means retrying forever
+}
+
+type StreamOption func(s *Stream)
+
+// WithCallOptions sets the gRPC call options used on every connection attempt,
+// usually the ones returned by `NewSubstreamsClient`.
+func WithCallOptions(callOpts ...grpc.CallOption) StreamOption {
+	return func(s *Stream) {
+		s.callOpts = callOpts
+	}
+}
+
+// WithResponseHandler is called for every response received, before any of the
+// typed handlers.
+func WithResponseHandler(f func(ctx context.Context, resp *pbsubstreamsrpc.Response) error) StreamOption {
+	return func(s *Stream) {
+		s.onResponse = f
+	}
+}
+
+func WithSessionInitHandler(f func(ctx context.Context, session *pbsubstreamsrpc.SessionInit) error) StreamOption {
+	return func(s *Stream) {
+		s.onSessionInit = f
+	}
+}
+
+func WithProgressHandler(f func(ctx context.Context, progress *pbsubstreamsrpc.ModulesProgress) error) StreamOption {
+	return func(s *Stream) {
+		s.onProgress = f
+	}
+}
+
+// WithBlockScopedDataHandler is called for each block, the stream's cursor is
+// only moved to the block's cursor once the handler returns without error.
+func WithBlockScopedDataHandler(f func(ctx context.Context, data *pbsubstreamsrpc.BlockScopedData) error) StreamOption {
+	return func(s *Stream) {
+		s.onBlockScopedData = f
+	}
+}
+
+// WithBlockUndoSignalHandler is called when blocks are reverted, the stream's
+// cursor is moved to `last_valid_cursor` once the handler returns without error.
+func WithBlockUndoSignalHandler(f func(ctx context.Context, undo *pbsubstreamsrpc.BlockUndoSignal) error) StreamOption {
+	return func(s *Stream) {
+		s.onBlockUndoSignal = f
+	}
+}
+
+// WithReconnectHandler is called before waiting `delay` and reconnecting
+// because of `err`, `attempt` starting at 1.
+func WithReconnectHandler(f func(attempt int, err error, delay time.Duration)) StreamOption {
+	return func(s *Stream) {
+		s.onReconnect = f
+	}
+}
+
+// WithConnectedHandler is called each time the `Blocks` call is established.
+func WithConnectedHandler(f func()) StreamOption {
+	return func(s *Stream) {
+		s.onConnected = f
+	}
+}
+
+// WithBackoff configures the delay before the first reconnection attempt, doubled
+// on each subsequent one up to `max`. Receiving a response resets it.
+func WithBackoff(initial, max time.Duration) StreamOption {
+	return func(s *Stream) {
+		s.initialBackoff = initial
+		s.maxBackoff = max
+	}
+}
+
+// WithMaxAttempts bounds the number of consecutive reconnection attempts, 0 retries forever.
+func WithMaxAttempts(maxAttempts int) StreamOption {
+	return func(s *Stream) {
+		s.maxAttempts = maxAttempts
+	}
+}
+
+func NewStream(client pbsubstreamsrpc.StreamClient, request *pbsubstreamsrpc.Request, opts ...StreamOption) *Stream {
+	s := &Stream{
+		client:         client,
+		request:        request,
+		cursor:         request.StartCursor,
+		initialBackoff: 500 * time.Millisecond,
+		maxBackoff:     30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Cursor returns the cursor of the last block processed, or the `last_valid_cursor`
+// of the last undo signal. It is the cursor to resume from.
+func (s *Stream) Cursor() string {
+	return s.cursor
+}
+
+// Run streams until the request's stop block is reached, in which case it returns
+// nil, a handler returns an error, or a non-retryable error occurs.
+func (s *Stream) Run(ctx context.Context) error {
+	attempt := 0
+	delay := s.initialBackoff
+
+	for {
+		received, err := s.stream(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var handlerErr *handlerError
+		if errors.As(err, &handlerErr) || !isRetryable(err) {
+			return err
+		}
+
+		if received {
+			attempt = 0
+			delay = s.initialBackoff
+		}
+		attempt++
+		if s.maxAttempts != 0 && attempt > s.maxAttempts {
+			return fmt.Errorf("giving up after %d reconnection attempts: %w", s.maxAttempts, err)
+		}
+
+		zlog.Info("stream interrupted, reconnecting", zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.String("cursor", s.cursor), zap.Error(err))
+		if s.onReconnect != nil {
+			s.onReconnect(attempt, err, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > s.maxBackoff {
+			delay = s.maxBackoff
+		}
+	}
+}
+
+// stream runs a single `Blocks` call, `received` being true if at least one response came through.
+func (s *Stream) stream(ctx context.Context) (received bool, err error) {
+	request := proto.Clone(s.request).(*pbsubstreamsrpc.Request)
+	request.StartCursor = s.cursor
+
+	cli, err := s.client.Blocks(ctx, request, s.callOpts...)
+	if err != nil {
+		return false, fmt.Errorf("call sf.substreams.rpc.v2.Stream/Blocks: %w", err)
+	}
+	if s.onConnected != nil {
+		s.onConnected()
+	}
+
+	for {
+		resp, err := cli.Recv()
+		if err == io.EOF {
+			return received, nil
+		}
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		if err := s.handle(ctx, resp); err != nil {
+			return received, &handlerError{err}
+		}
+	}
+}
+
+func (s *Stream) handle(ctx context.Context, resp *pbsubstreamsrpc.Response) error {
+	if s.onResponse != nil {
+		if err := s.onResponse(ctx, resp); err != nil {
+			return err
+		}
+	}
+
+	switch msg := resp.Message.(type) {
+	case *pbsubstreamsrpc.Response_Session:
+		if s.onSessionInit != nil {
+			return s.onSessionInit(ctx, msg.Session)
+		}
+	case *pbsubstreamsrpc.Response_Progress:
+		if s.onProgress != nil {
+			return s.onProgress(ctx, msg.Progress)
+		}
+	case *pbsubstreamsrpc.Response_BlockScopedData:
+		if s.onBlockScopedData != nil {
+			if err := s.onBlockScopedData(ctx, msg.BlockScopedData); err != nil {
+				return err
+			}
+		}
+		s.cursor = msg.BlockScopedData.Cursor
+	case *pbsubstreamsrpc.Response_BlockUndoSignal:
+		if s.onBlockUndoSignal != nil {
+			if err := s.onBlockUndoSignal(ctx, msg.BlockUndoSignal); err != nil {
+				return err
+			}
+		}
+		s.cursor = msg.BlockUndoSignal.LastValidCursor
+	}
+	return nil
+}
+
+func isRetryable(err error) bool {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+
+	switch grpcErr.GRPCStatus().Code() {
+	case codes.Unavailable, codes.Internal:
+		return true
+	}
+	return false
+}
+
+// handlerError marks errors returned by the user's handlers, which are never retried.
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string { return e.err.Error() }
+func (e *handlerError) Unwrap() error { return e.err }
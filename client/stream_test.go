@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+)
+
+func TestStream_Run(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "server going away")
+
+	tests := []struct {
+		name          string
+		connections   [][]interface{} // responses or a final error, per connection
+		expectCursors []string        // start cursor of each connection
+		expectBlocks  []string
+		expectErr     string
+	}{
+		{
+			name:          "no interruption",
+			connections:   [][]interface{}{{block("a"), block("b")}},
+			expectCursors: []string{""},
+			expectBlocks:  []string{"a", "b"},
+		},
+		{
+			name: "reconnects from last cursor",
+			connections: [][]interface{}{
+				{block("a"), unavailable},
+				{block("b")},
+			},
+			expectCursors: []string{"", "a"},
+			expectBlocks:  []string{"a", "b"},
+		},
+		{
+			name: "reconnects from undo signal last valid cursor",
+			connections: [][]interface{}{
+				{block("a"), block("b"), undo("a"), status.Error(codes.Internal, "boom")},
+				{block("c")},
+			},
+			expectCursors: []string{"", "a"},
+			expectBlocks:  []string{"a", "b", "c"},
+		},
+		{
+			name: "non retryable error",
+			connections: [][]interface{}{
+				{block("a"), status.Error(codes.InvalidArgument, "bad request")},
+			},
+			expectCursors: []string{""},
+			expectBlocks:  []string{"a"},
+			expectErr:     "rpc error: code = InvalidArgument desc = bad request",
+		},
+		{
+			name: "gives up after max attempts",
+			connections: [][]interface{}{
+				{unavailable},
+				{unavailable},
+				{unavailable},
+			},
+			expectCursors: []string{"", "", ""},
+			expectErr:     "giving up after 2 reconnection attempts: rpc error: code = Unavailable desc = server going away",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cli := &testStreamClient{connections: test.connections}
+
+			var blocks []string
+			stream := NewStream(cli, &pbsubstreamsrpc.Request{},
+				WithBackoff(time.Millisecond, time.Millisecond),
+				WithMaxAttempts(2),
+				WithBlockScopedDataHandler(func(ctx context.Context, data *pbsubstreamsrpc.BlockScopedData) error {
+					blocks = append(blocks, data.Cursor)
+					return nil
+				}),
+			)
+
+			err := stream.Run(context.Background())
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectCursors, cli.cursors)
+			assert.Equal(t, test.expectBlocks, blocks)
+		})
+	}
+}
+
+func TestStream_Run_HandlerError(t *testing.T) {
+	cli := &testStreamClient{connections: [][]interface{}{{block("a"), block("b")}}}
+
+	stream := NewStream(cli, &pbsubstreamsrpc.Request{StartCursor: "start"},
+		WithBlockScopedDataHandler(func(ctx context.Context, data *pbsubstreamsrpc.BlockScopedData) error {
+			if data.Cursor == "b" {
+				return status.Error(codes.Unavailable, "sink unavailable")
+			}
+			return nil
+		}),
+	)
+
+	require.EqualError(t, stream.Run(context.Background()), "rpc error: code = Unavailable desc = sink unavailable")
+	assert.Equal(t, []string{"start"}, cli.cursors)
+	assert.Equal(t, "a", stream.Cursor())
+}
+
+func block(cursor string) *pbsubstreamsrpc.Response {
+	return &pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockScopedData{
+		BlockScopedData: &pbsubstreamsrpc.BlockScopedData{Cursor: cursor},
+	}}
+}
+
+func undo(lastValidCursor string) *pbsubstreamsrpc.Response {
+	return &pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockUndoSignal{
+		BlockUndoSignal: &pbsubstreamsrpc.BlockUndoSignal{LastValidCursor: lastValidCursor},
+	}}
+}
+
+type testStreamClient struct {
+	connections [][]interface{}
+	cursors     []string
+}
+
+func (c *testStreamClient) Blocks(ctx context.Context, in *pbsubstreamsrpc.Request, opts ...grpc.CallOption) (pbsubstreamsrpc.Stream_BlocksClient, error) {
+	if len(c.cursors) >= len(c.connections) {
+		return nil, fmt.Errorf("unexpected connection #%d", len(c.cursors)+1)
+	}
+	c.cursors = append(c.cursors, in.StartCursor)
+	return &testBlocksClient{items: c.connections[len(c.cursors)-1]}, nil
+}
+
+type testBlocksClient struct {
+	grpc.ClientStream
+	items []interface{}
+}
+
+func (c *testBlocksClient) Recv() (*pbsubstreamsrpc.Response, error) {
+	if len(c.items) == 0 {
+		return nil, io.EOF
+	}
+	item := c.items[0]
+	c.items = c.items[1:]
+
+	if err, ok := item.(error); ok {
+		return nil, err
+	}
+	return item.(*pbsubstreamsrpc.Response), nil
+}
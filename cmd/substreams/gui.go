@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli"
 	"github.com/streamingfast/substreams/client"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 	"github.com/streamingfast/substreams/tools"
 	"github.com/streamingfast/substreams/tui2"
 	"github.com/streamingfast/substreams/tui2/pages/request"
@@ -31,7 +32,9 @@ func init() {
 	guiCmd.Flags().StringSlice("debug-modules-initial-snapshot", nil, "List of 'store' modules from which to print the initial data snapshot (Unavailable in Production Mode")
 	guiCmd.Flags().StringSlice("debug-modules-output", nil, "List of extra modules from which to print outputs, deltas and logs (Unavailable in Production Mode)")
 	guiCmd.Flags().Bool("production-mode", false, "Enable Production Mode, with high-speed parallel processing")
-	guiCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY")
+	guiCmd.Flags().String("log-level", "debug", "Minimum level of the module logs shown, one of 'debug', 'info', 'warn' or 'error'. Logs of 'println' are of level 'info'")
+	guiCmd.Flags().String("network", "", "Network for which the manifest is read, selecting its initial blocks and params in the 'networks' section. Defaults to the manifest's 'network'")
+	guiCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY, or for typed params -p module3.field=value -p module4=@params.yaml")
 	guiCmd.Flags().Bool("replay", false, "Replay saved session into GUI from replay.bin")
 	rootCmd.AddCommand(guiCmd)
 }
@@ -85,7 +88,7 @@ func runGui(cmd *cobra.Command, args []string) error {
 		mustGetBool(cmd, "plaintext"),
 	)
 
-	manifestReader, err := manifest.NewReader(manifestPath)
+	manifestReader, err := manifest.NewReader(manifestPath, manifest.WithOverrideNetwork(mustGetString(cmd, "network")))
 	if err != nil {
 		return fmt.Errorf("manifest reader: %w", err)
 	}
@@ -120,6 +123,11 @@ func runGui(cmd *cobra.Command, args []string) error {
 
 	stopBlock := mustGetString(cmd, "stop-block")
 
+	logLevel, err := pbsubstreamsrpc.ParseLogLevel(mustGetString(cmd, "log-level"))
+	if err != nil {
+		return err
+	}
+
 	requestConfig := &request.RequestConfig{
 		ManifestPath:                manifestPath,
 		ReadFromModule:              readFromModule,
@@ -134,6 +142,7 @@ func runGui(cmd *cobra.Command, args []string) error {
 		StartBlock:                  startBlock,
 		StopBlock:                   stopBlock,
 		FinalBlocksOnly:             mustGetBool(cmd, "final-blocks-only"),
+		LogLevel:                    logLevel,
 	}
 
 	ui, err := tui2.New(requestConfig)
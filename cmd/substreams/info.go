@@ -30,6 +30,7 @@ var infoCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(infoCmd)
+	infoCmd.Flags().String("network", "", "Network for which the manifest is read, selecting its initial blocks and params in the 'networks' section. Defaults to the manifest's 'network'")
 }
 
 func runInfo(cmd *cobra.Command, args []string) error {
@@ -38,7 +39,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		manifestPath = args[0]
 	}
 
-	manifestReader, err := manifest.NewReader(manifestPath)
+	manifestReader, err := manifest.NewReader(manifestPath, manifest.WithOverrideNetwork(mustGetString(cmd, "network")))
 	if err != nil {
 		return fmt.Errorf("manifest reader: %w", err)
 	}
@@ -55,6 +56,17 @@ func runInfo(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("Package name:", pkg.PackageMeta[0].Name)
 	fmt.Println("Version:", pkg.PackageMeta[0].Version)
+	if pkg.Network != "" {
+		fmt.Println("Network:", pkg.Network)
+	}
+	digest, err := manifest.PackageDigest(pkg)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Digest:", digest)
+	for _, signature := range pkg.Signatures {
+		fmt.Printf("Signed by: %x\n", signature.PublicKey)
+	}
 	if doc := pkg.PackageMeta[0].Doc; doc != "" {
 		fmt.Println("Doc: " + strings.Replace(doc, "\n", "\n  ", -1))
 	}
@@ -75,10 +87,17 @@ func runInfo(cmd *cobra.Command, args []string) error {
 			fmt.Println("Kind: store")
 			fmt.Println("Value Type:", v.KindStore.ValueType)
 			fmt.Println("Update Policy:", v.KindStore.UpdatePolicy)
+		case *pbsubstreams.Module_KindBlockIndex_:
+			fmt.Println("Kind: blockIndex")
+			fmt.Println("Output Type:", v.KindBlockIndex.OutputType)
 		default:
 			fmt.Println("Kind: Unknown")
 		}
 
+		if filter := module.BlockFilter; filter != nil {
+			fmt.Printf("Block Filter: %s (%s)\n", filter.Query, filter.Module)
+		}
+
 		hashes.HashModule(pkg.Modules, module, graph)
 
 		fmt.Println("Hash:", hashes.Get(module.Name))
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+
+	"github.com/streamingfast/substreams/manifest"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [<manifest>]",
+	Short: "Check a manifest for unused modules, unread stores, unknown types and other mistakes",
+	Long: cli.Dedent(`
+		Check a manifest for likely mistakes: modules not used by the sink module, stores never read, unused block
+		indexes and protobuf files, output types missing from the protobuf definitions, 'deltas' inputs on
+		'set_if_not_exists' stores, modules consumed more than once by a module, and initial blocks lower than the
+		ones of the module's inputs. Imported modules are only checked through their use by the manifest's modules.
+
+		The manifest is optional as it will try to find a file named 'substreams.yaml' in current working directory
+		if nothing entered. You may enter a directory that contains a 'substreams.yaml' file in place of '<manifest>'.
+
+		Issues are printed as text, JSON ('-o json', one object per issue) or SARIF ('-o sarif', for code scanning
+		tools). The command exits with an error when issues of level 'error' are found, or any issue with '--strict'.
+	`),
+	Example: string(cli.ExamplePrefixed("substreams lint", `
+		./substreams.yaml
+		-o sarif > lint.sarif
+	`)),
+	RunE:         runLint,
+	Args:         cobra.RangeArgs(0, 1),
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringP("output", "o", "text", "Output format, one of 'text', 'json' or 'sarif'")
+	lintCmd.Flags().Bool("strict", false, "Exit with an error on warnings too")
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	manifestPath := ""
+	if len(args) == 1 {
+		manifestPath = args[0]
+	}
+
+	output := mustGetString(cmd, "output")
+	if output != "text" && output != "json" && output != "sarif" {
+		return fmt.Errorf("invalid output format %q, expecting 'text', 'json' or 'sarif'", output)
+	}
+
+	manifestReader, err := manifest.NewReader(manifestPath)
+	if err != nil {
+		return fmt.Errorf("manifest reader: %w", err)
+	}
+	manifestPath = manifestReader.ResolvedInput()
+
+	issues, err := manifest.Lint(manifestPath)
+	if err != nil {
+		return fmt.Errorf("linting manifest %q: %w", manifestPath, err)
+	}
+
+	switch output {
+	case "json":
+		err = printLintJSON(cmd.OutOrStdout(), manifestPath, issues)
+	case "sarif":
+		err = printLintSARIF(cmd.OutOrStdout(), manifestPath, issues)
+	default:
+		printLintText(cmd.OutOrStdout(), manifestPath, issues)
+	}
+	if err != nil {
+		return err
+	}
+
+	var errorCount int
+	for _, issue := range issues {
+		if issue.Rule.Level == manifest.LintLevelError {
+			errorCount++
+		}
+	}
+	if errorCount != 0 {
+		return fmt.Errorf("%d issues of level 'error' found", errorCount)
+	}
+	if mustGetBool(cmd, "strict") && len(issues) != 0 {
+		return fmt.Errorf("%d issues found", len(issues))
+	}
+	return nil
+}
+
+func printLintText(w io.Writer, manifestPath string, issues []*manifest.LintIssue) {
+	for _, issue := range issues {
+		location := manifestPath
+		if issue.Line != 0 {
+			location = fmt.Sprintf("%s:%d", manifestPath, issue.Line)
+		}
+		fmt.Fprintf(w, "%s: %s: %s (%s)\n", location, issue.Rule.Level, issue.Message, issue.Rule.ID)
+	}
+	fmt.Fprintf(w, "%d issues found\n", len(issues))
+}
+
+func printLintJSON(w io.Writer, manifestPath string, issues []*manifest.LintIssue) error {
+	encoder := json.NewEncoder(w)
+	for _, issue := range issues {
+		err := encoder.Encode(struct {
+			File    string             `json:"file"`
+			Line    int                `json:"line,omitempty"`
+			Rule    string             `json:"rule"`
+			Level   manifest.LintLevel `json:"level"`
+			Message string             `json:"message"`
+		}{manifestPath, issue.Line, issue.Rule.ID, issue.Rule.Level, issue.Message})
+		if err != nil {
+			return fmt.Errorf("encoding issue: %w", err)
+		}
+	}
+	return nil
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *struct {
+			StartLine int `json:"startLine"`
+		} `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	DefaultConfig    struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+// printLintSARIF writes `issues` as a SARIF 2.1.0 log, the format of code scanning tools.
+func printLintSARIF(w io.Writer, manifestPath string, issues []*manifest.LintIssue) error {
+	var rules []sarifRule
+	for _, rule := range manifest.LintRules {
+		r := sarifRule{ID: rule.ID, ShortDescription: sarifMessage{rule.Description}}
+		r.DefaultConfig.Level = string(rule.Level)
+		rules = append(rules, r)
+	}
+
+	results := []sarifResult{}
+	for _, issue := range issues {
+		var location sarifLocation
+		location.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(manifestPath)
+		if issue.Line != 0 {
+			location.PhysicalLocation.Region = &struct {
+				StartLine int `json:"startLine"`
+			}{issue.Line}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    issue.Rule.ID,
+			Level:     string(issue.Rule.Level),
+			Message:   sarifMessage{issue.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	log := map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []interface{}{
+			map[string]interface{}{
+				"tool": map[string]interface{}{
+					"driver": map[string]interface{}{
+						"name":           "substreams lint",
+						"informationUri": "https://substreams.streamingfast.io",
+						"rules":          rules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		return fmt.Errorf("encoding sarif log: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli"
 	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 )
@@ -21,6 +23,13 @@ var packCmd = &cobra.Command{
 		Build an .spkg out of a .yaml manifest. The manifest is optional as it will try to find a file named
 		'substreams.yaml' in current working directory if nothing entered. You may enter a directory that contains a
 		'substreams.yaml' file in place of '<manifest_file>', or a link to a remote .spkg file, using urls gs://, http(s)://, ipfs://, etc.'.
+
+		Packing the same sources always produces the same bytes, identified by the package digest printed once
+		written. With '--verify', the package is rebuilt and compared with the existing output file instead of
+		being written, failing if they differ.
+
+		The remote imports of the manifest are pinned to the hash of their content in a 'substreams.lock' file
+		written next to it, verified each time the manifest is read.
 	`),
 	RunE:         runPack,
 	Args:         cobra.RangeArgs(0, 1),
@@ -29,6 +38,8 @@ var packCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(packCmd)
+	packCmd.Flags().String("network", "", "Network for which the manifest is read, selecting its initial blocks and params in the 'networks' section. Defaults to the manifest's 'network'")
+	packCmd.Flags().Bool("verify", false, "Rebuild the package and compare it with the existing output file instead of writing it, failing if they differ")
 	packCmd.Flags().StringP("output-file", "o", "{manifestDir}/{spkgDefaultName}", cli.FlagDescription(`
 		Specifies output file where the generated "spkg" file will be written. You can use template directives when
 		specifying the value of the flag. You can use "{manifestDir}" which resolves to manifest's
@@ -45,7 +56,13 @@ func runPack(cmd *cobra.Command, args []string) error {
 		manifestPath = args[0]
 	}
 
-	manifestReader, err := manifest.NewReader(manifestPath)
+	verify := mustGetBool(cmd, "verify")
+	readerOptions := []manifest.Options{manifest.WithOverrideNetwork(mustGetString(cmd, "network"))}
+	if !verify {
+		readerOptions = append(readerOptions, manifest.WithUpdateLockfile())
+	}
+
+	manifestReader, err := manifest.NewReader(manifestPath, readerOptions...)
 	if err != nil {
 		return fmt.Errorf("manifest reader: %w", err)
 	}
@@ -72,26 +89,101 @@ func runPack(cmd *cobra.Command, args []string) error {
 
 	zlog.Debug("resolved output file", zap.String("original", originalOutputFile), zap.String("resolved", resolvedOutputFile))
 
+	cnt, err := manifest.MarshalPackage(pkg)
+	if err != nil {
+		return err
+	}
+	digest, err := manifest.PackageDigest(pkg)
+	if err != nil {
+		return err
+	}
+
+	if verify {
+		return verifyPack(resolvedOutputFile, cnt, pkg, digest)
+	}
+
 	outputDir := filepath.Dir(resolvedOutputFile)
 	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
 		return fmt.Errorf("create output directories: %w", err)
 	}
 
-	cnt, err := proto.Marshal(pkg)
-	if err != nil {
-		return fmt.Errorf("marshalling package: %w", err)
-	}
-
 	if err := ioutil.WriteFile(resolvedOutputFile, cnt, 0644); err != nil {
 		fmt.Println("")
 		return fmt.Errorf("writing file: %w", err)
 	}
 
 	fmt.Printf("Successfully wrote %q.\n", resolvedOutputFile)
+	fmt.Println("Package digest:", digest)
 
 	return nil
 }
 
+// verifyPack compares the package rebuilt from the manifest with the existing one at `spkgPath`.
+func verifyPack(spkgPath string, cnt []byte, pkg *pbsubstreams.Package, digest string) error {
+	existingCnt, err := os.ReadFile(spkgPath)
+	if err != nil {
+		return fmt.Errorf("reading package to verify: %w", err)
+	}
+	if bytes.Equal(cnt, existingCnt) {
+		fmt.Printf("Package %q is up to date, digest %s.\n", spkgPath, digest)
+		return nil
+	}
+
+	existing := &pbsubstreams.Package{}
+	if err := proto.Unmarshal(existingCnt, existing); err != nil {
+		return fmt.Errorf("unmarshalling package to verify: %w", err)
+	}
+	existingDigest, err := manifest.PackageDigest(existing)
+	if err != nil {
+		return err
+	}
+
+	// Signing with 'substreams sign' only adds signatures
+	if len(existing.Signatures) != 0 {
+		unsigned := proto.Clone(existing).(*pbsubstreams.Package)
+		unsigned.Signatures = nil
+		unsignedCnt, err := manifest.MarshalPackage(unsigned)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(cnt, unsignedCnt) {
+			fmt.Printf("Package %q is up to date and signed by %d keys, digest %s.\n", spkgPath, len(existing.Signatures), existingDigest)
+			return nil
+		}
+	}
+
+	var differences []string
+	if !equalProtoMessages(pkg.ProtoFiles, existing.ProtoFiles) {
+		differences = append(differences, "protobuf files")
+	}
+	if !proto.Equal(pkg.Modules, existing.Modules) {
+		differences = append(differences, "modules")
+	}
+	if !equalProtoMessages(pkg.ModuleMeta, existing.ModuleMeta) || !equalProtoMessages(pkg.PackageMeta, existing.PackageMeta) {
+		differences = append(differences, "metadata")
+	}
+	if pkg.Network != existing.Network || pkg.SinkModule != existing.SinkModule || !proto.Equal(pkg.SinkConfig, existing.SinkConfig) {
+		differences = append(differences, "network or sink")
+	}
+	if len(differences) == 0 {
+		differences = append(differences, "encoding only")
+	}
+
+	return fmt.Errorf("package %q differs from the one built from the manifest (%s): digest %s, expected %s", spkgPath, strings.Join(differences, ", "), existingDigest, digest)
+}
+
+func equalProtoMessages[T proto.Message](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func resolveOutputFile(input string, bindings map[string]string) string {
 	for k, v := range bindings {
 		input = strings.ReplaceAll(input, `{`+k+`}`, v)
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"crypto/ed25519"
+	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -13,13 +15,44 @@ var rootCmd = &cobra.Command{
 	Short:        "A tool to manipulate Substreams, and process them locally and remotely",
 	Long:         "Any place where <package> is specified, a 'substreams.yaml', a local '.spkg' file or an https://...spkg file can be specified",
 	SilenceUsage: true,
-	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 		manifest.IPFSURL = mustGetString(cmd, "ipfs-url")
+		manifest.PackageCacheDir = mustGetString(cmd, "package-cache-dir")
+
+		trustedKeys, err := readTrustedKeys(mustGetStringArray(cmd, "trusted-key"), mustGetString(cmd, "trusted-keys-file"))
+		if err != nil {
+			return err
+		}
+		manifest.TrustedKeys = trustedKeys
+		return nil
 	},
 }
 
 func init() {
 	// From https://thegraph.com/docs/en/operating-graph-node/
 	rootCmd.PersistentFlags().String("ipfs-url", "https://ipfs.network.thegraph.com", "IPFS endpoint to resolve substreams-based subgraphs as manifest")
+	rootCmd.PersistentFlags().String("package-cache-dir", manifest.PackageCacheDir, "Directory where remote imports are cached by hash of their content, to read the imports pinned in 'substreams.lock' offline. Empty disables the cache")
+	rootCmd.PersistentFlags().StringArray("trusted-key", nil, "Hex-encoded ed25519 public key trusted to sign packages, can be repeated. When trusted keys are set, remote packages and imports must be signed by one of them, see 'substreams sign'")
+	rootCmd.PersistentFlags().String("trusted-keys-file", "", "File of hex-encoded ed25519 public keys trusted to sign packages, one per line, see '--trusted-key'")
 	rootCmd.PersistentFlags().Duration("ipfs-timeout", time.Second*10, "IPFS timeout when resolving substreams-based subgraphs as manifest")
 }
+
+func readTrustedKeys(keys []string, keysFile string) ([]ed25519.PublicKey, error) {
+	var trustedKeys []ed25519.PublicKey
+	if keysFile != "" {
+		fileKeys, err := manifest.ReadTrustedKeysFile(keysFile)
+		if err != nil {
+			return nil, err
+		}
+		trustedKeys = append(trustedKeys, fileKeys...)
+	}
+
+	for _, in := range keys {
+		key, err := manifest.ParsePublicKey(in)
+		if err != nil {
+			return nil, fmt.Errorf("trusted key: %w", err)
+		}
+		trustedKeys = append(trustedKeys, key)
+	}
+	return trustedKeys, nil
+}
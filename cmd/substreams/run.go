@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
-	"io"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
 	"github.com/streamingfast/cli"
 	"github.com/streamingfast/substreams/client"
 	"github.com/streamingfast/substreams/manifest"
@@ -23,6 +25,7 @@ func init() {
 	runCmd.Flags().String("substreams-api-token-envvar", "SUBSTREAMS_API_TOKEN", "name of variable containing Substreams Authentication token")
 	runCmd.Flags().StringP("start-block", "s", "", "Start block to stream from. If empty, will be replaced by initialBlock of the first module you are streaming. If negative, will be resolved by the server relative to the chain head")
 	runCmd.Flags().StringP("cursor", "c", "", "Cursor to stream from. Leave blank for no cursor")
+	runCmd.Flags().String("cursor-file", "", "File where the latest cursor is persisted after each block, the stream resumes from it when it exists (unless --cursor is set)")
 	runCmd.Flags().StringP("stop-block", "t", "0", "Stop block to end stream at, exclusively. If the start-block is positive, a '+' prefix can indicate 'relative to start-block'")
 	runCmd.Flags().Bool("final-blocks-only", false, "Only process blocks that have pass finality, to prevent any reorg and undo signal by staying further away from the chain HEAD")
 	runCmd.Flags().Bool("insecure", false, "Skip certificate validation on GRPC connection")
@@ -31,9 +34,18 @@ func init() {
 	runCmd.Flags().StringSlice("debug-modules-initial-snapshot", nil, "List of 'store' modules from which to print the initial data snapshot (Unavailable in Production Mode)")
 	runCmd.Flags().StringSlice("debug-modules-output", nil, "List of modules from which to print outputs, deltas and logs (Unavailable in Production Mode)")
 	runCmd.Flags().Bool("production-mode", false, "Enable Production Mode, with high-speed parallel processing")
-	runCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY")
+	runCmd.Flags().String("log-level", "debug", "Minimum level of the module logs printed, one of 'debug', 'info', 'warn' or 'error'. Logs of 'println' are of level 'info'")
+	runCmd.Flags().String("network", "", "Network for which the manifest is read, selecting its initial blocks and params in the 'networks' section. Defaults to the manifest's 'network'")
+	runCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY, or for typed params -p module3.field=value -p module4=@params.yaml")
 	runCmd.Flags().String("test-file", "", "runs a test file")
 	runCmd.Flags().Bool("test-verbose", false, "print out all the results")
+	runCmd.Flags().Bool("local", false, "Run the package in-process against merged blocks files instead of a remote endpoint, requires a stop block")
+	runCmd.Flags().String("local-blocks-store", "./merged-blocks", "When --local is set, store URL of the merged blocks files to read blocks from")
+	runCmd.Flags().String("local-state-store", "./localdata", "When --local is set, store URL where the stores snapshots and modules outputs are cached")
+	runCmd.Flags().String("local-block-type", "", "When --local is set, protobuf type of the blocks, defaults to the one consumed by the package's modules")
+	runCmd.Flags().Uint64("local-save-interval", 1000, "When --local is set, interval at which the stores snapshots and modules outputs are saved")
+	runCmd.Flags().Uint64("local-parallel-jobs", 2, "When --local is set, number of back-processing jobs executed in parallel")
+	runCmd.Flags().Uint64("local-job-size", 10000, "When --local is set, number of blocks processed by each back-processing job")
 	rootCmd.AddCommand(runCmd)
 }
 
@@ -45,6 +57,9 @@ var runCmd = &cobra.Command{
 		Stream module outputs from a given package on a remote endpoint. The manifest is optional as it will try to find a file named
 		'substreams.yaml' in current working directory if nothing entered. You may enter a directory that contains a 'substreams.yaml'
 		'substreams.yaml' file in place of '<manifest_file>', or a link to a remote .spkg file, using urls gs://, http(s)://, ipfs://, etc.'.
+
+		With '--local', no endpoint is involved: the package is executed in-process against the merged blocks files found in
+		'--local-blocks-store', caching stores and modules outputs in '--local-state-store'.
 	`),
 	RunE:         runRun,
 	Args:         cobra.RangeArgs(1, 2),
@@ -63,8 +78,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 	}
 
 	outputMode := mustGetString(cmd, "output")
+	logLevel, err := pbsubstreamsrpc.ParseLogLevel(mustGetString(cmd, "log-level"))
+	if err != nil {
+		return err
+	}
 
-	manifestReader, err := manifest.NewReader(manifestPath)
+	manifestReader, err := manifest.NewReader(manifestPath, manifest.WithOverrideNetwork(mustGetString(cmd, "network")))
 	if err != nil {
 		return fmt.Errorf("manifest reader: %w", err)
 	}
@@ -119,27 +138,30 @@ func runRun(cmd *cobra.Command, args []string) error {
 		startBlock = int64(sb)
 	}
 
-	substreamsClientConfig := client.NewSubstreamsClientConfig(
-		mustGetString(cmd, "substreams-endpoint"),
-		tools.ReadAPIToken(cmd, "substreams-api-token-envvar"),
-		mustGetBool(cmd, "insecure"),
-		mustGetBool(cmd, "plaintext"),
-	)
-
-	ssClient, connClose, callOpts, err := client.NewSubstreamsClient(substreamsClientConfig)
-	if err != nil {
-		return fmt.Errorf("substreams client setup: %w", err)
-	}
-	defer connClose()
-
 	stopBlock, err := readStopBlockFlag(cmd, startBlock, "stop-block")
 	if err != nil {
 		return fmt.Errorf("stop block: %w", err)
 	}
 
+	if mustGetBool(cmd, "local") && stopBlock == 0 {
+		return fmt.Errorf("a stop block is required with --local")
+	}
+
+	cursor := mustGetString(cmd, "cursor")
+	cursorFile := mustGetString(cmd, "cursor-file")
+	if cursorFile != "" && cursor == "" {
+		cursor, err = loadCursor(cursorFile)
+		if err != nil {
+			return fmt.Errorf("load cursor file: %w", err)
+		}
+		if cursor != "" {
+			fmt.Printf("Resuming from cursor file %q at %s\n", cursorFile, describeCursor(cursor))
+		}
+	}
+
 	req := &pbsubstreamsrpc.Request{
 		StartBlockNum:                       startBlock,
-		StartCursor:                         mustGetString(cmd, "cursor"),
+		StartCursor:                         cursor,
 		StopBlockNum:                        stopBlock,
 		FinalBlocksOnly:                     mustGetBool(cmd, "final-blocks-only"),
 		Modules:                             pkg.Modules,
@@ -147,6 +169,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 		ProductionMode:                      productionMode,
 		DebugInitialStoreSnapshotForModules: debugModulesInitialSnapshot,
 	}
+	if len(pkg.Signatures) != 0 {
+		req.PackageSignatures = pkg.Signatures
+		if req.PackageMetadataDigest, err = manifest.PackageMetadataDigest(pkg); err != nil {
+			return err
+		}
+	}
 
 	if err := req.Validate(); err != nil {
 		return fmt.Errorf("validate request: %w", err)
@@ -157,6 +185,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 	}
 
 	ui := tui.New(req, pkg, toPrint)
+	ui.SetLogLevel(logLevel)
 	if err := ui.Init(outputMode); err != nil {
 		return fmt.Errorf("TUI initialization: %w", err)
 	}
@@ -173,40 +202,114 @@ func runRun(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	ui.SetRequest(req)
-	ui.Connecting()
-	cli, err := ssClient.Blocks(streamCtx, req, callOpts...)
-	if err != nil && streamCtx.Err() != context.Canceled {
-		return fmt.Errorf("call sf.substreams.rpc.v2.Stream/Blocks: %w", err)
+	onResponse := func(ctx context.Context, resp *pbsubstreamsrpc.Response) error {
+		if err := ui.IncomingMessage(ctx, resp, testRunner); err != nil {
+			fmt.Printf("RETURN HANDLER ERROR: %s\n", err)
+		}
+		return nil
 	}
-	ui.Connected()
 
-	for {
-		resp, err := cli.Recv()
-		if resp != nil {
-			if err := ui.IncomingMessage(ctx, resp, testRunner); err != nil {
-				fmt.Printf("RETURN HANDLER ERROR: %s\n", err)
+	var streamErr error
+	if mustGetBool(cmd, "local") {
+		ui.Connected()
+		streamErr = runLocal(streamCtx, cmd, pkg, req, func(ctx context.Context, resp *pbsubstreamsrpc.Response) error {
+			onResponse(ctx, resp)
+			if data := resp.GetBlockScopedData(); data != nil && cursorFile != "" {
+				return saveCursor(cursorFile, data.Cursor)
 			}
+			return nil
+		})
+	} else {
+		streamErr = runRemote(streamCtx, cmd, req, ui, onResponse, cursorFile)
+	}
+
+	if streamErr != nil {
+		// Special handling if interrupted the context ourselves, no error
+		if streamCtx.Err() == context.Canceled {
+			ui.Cancel()
+			return nil
 		}
-		if err != nil {
-			if err == io.EOF {
-				ui.Cancel()
-				fmt.Println("all done")
-				if testRunner != nil {
-					testRunner.LogResults()
-				}
-
-				return nil
-			}
 
-			// Special handling if interrupted the context ourselves, no error
-			if streamCtx.Err() == context.Canceled {
-				ui.Cancel()
-				return nil
-			}
+		return streamErr
+	}
 
-			return err
+	ui.Cancel()
+	fmt.Println("all done")
+	if testRunner != nil {
+		testRunner.LogResults()
+	}
+
+	return nil
+}
+
+func runRemote(ctx context.Context, cmd *cobra.Command, req *pbsubstreamsrpc.Request, ui *tui.TUI, onResponse func(ctx context.Context, resp *pbsubstreamsrpc.Response) error, cursorFile string) error {
+	substreamsClientConfig := client.NewSubstreamsClientConfig(
+		mustGetString(cmd, "substreams-endpoint"),
+		tools.ReadAPIToken(cmd, "substreams-api-token-envvar"),
+		mustGetBool(cmd, "insecure"),
+		mustGetBool(cmd, "plaintext"),
+	)
+
+	ssClient, connClose, callOpts, err := client.NewSubstreamsClient(substreamsClientConfig)
+	if err != nil {
+		return fmt.Errorf("substreams client setup: %w", err)
+	}
+	defer connClose()
+
+	ui.Connecting()
+	streamOpts := []client.StreamOption{
+		client.WithCallOptions(callOpts...),
+		client.WithConnectedHandler(ui.Connected),
+		client.WithReconnectHandler(func(attempt int, err error, delay time.Duration) {
+			ui.Connecting()
+		}),
+		client.WithResponseHandler(onResponse),
+	}
+	if cursorFile != "" {
+		streamOpts = append(streamOpts,
+			client.WithBlockScopedDataHandler(func(ctx context.Context, data *pbsubstreamsrpc.BlockScopedData) error {
+				return saveCursor(cursorFile, data.Cursor)
+			}),
+			client.WithBlockUndoSignalHandler(func(ctx context.Context, undo *pbsubstreamsrpc.BlockUndoSignal) error {
+				return saveCursor(cursorFile, undo.LastValidCursor)
+			}),
+		)
+	}
+
+	return client.NewStream(ssClient, req, streamOpts...).Run(ctx)
+}
+
+// loadCursor returns the cursor persisted in `path`, or an empty cursor when the file doesn't exist yet.
+func loadCursor(path string) (string, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
 		}
+		return "", err
+	}
+	return strings.TrimSpace(string(cnt)), nil
+}
+
+// saveCursor atomically replaces the content of `path` with `cursor`, so that a crash
+// never leaves a truncated cursor behind.
+func saveCursor(path, cursor string) error {
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, []byte(cursor), 0644); err != nil {
+		return fmt.Errorf("write cursor file: %w", err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		return fmt.Errorf("rename cursor file: %w", err)
+	}
+	return nil
+}
+
+func describeCursor(cursor string) string {
+	c, err := bstream.CursorFromOpaque(cursor)
+	if err != nil {
+		return fmt.Sprintf("unreadable cursor %q", cursor)
 	}
+	return fmt.Sprintf("block #%d (%s)", c.Block.Num(), c.Block.ID())
 }
 
 func readStartBlockFlag(cmd *cobra.Command, flagName string) (int64, bool, error) {
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/dstore"
+
+	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/reqctx"
+	"github.com/streamingfast/substreams/service"
+)
+
+// runLocal executes `req` in-process against the merged blocks files of `--local-blocks-store`,
+// instead of streaming it from a remote endpoint.
+func runLocal(ctx context.Context, cmd *cobra.Command, pkg *pbsubstreams.Package, req *pbsubstreamsrpc.Request, onResponse func(ctx context.Context, resp *pbsubstreamsrpc.Response) error) error {
+	blockType := mustGetString(cmd, "local-block-type")
+	if blockType == "" {
+		var err error
+		if blockType, err = manifest.PackageBlockType(pkg); err != nil {
+			return fmt.Errorf("%w, set --local-block-type", err)
+		}
+	}
+
+	mergedBlocksStore, err := dstore.NewDBinStore(mustGetString(cmd, "local-blocks-store"))
+	if err != nil {
+		return fmt.Errorf("setting up merged blocks store: %w", err)
+	}
+
+	stateStore, err := dstore.NewStore(mustGetString(cmd, "local-state-store"), "zst", "zstd", true)
+	if err != nil {
+		return fmt.Errorf("setting up state store: %w", err)
+	}
+
+	svc := service.NewLocal(
+		stateStore,
+		mergedBlocksStore,
+		blockType,
+		mustGetUint64(cmd, "local-parallel-jobs"),
+		mustGetUint64(cmd, "local-job-size"),
+		service.WithCacheSaveInterval(mustGetUint64(cmd, "local-save-interval")),
+	)
+
+	ctx = reqctx.WithLogger(ctx, zlog)
+	return svc.Blocks(ctx, req, func(respAny substreams.ResponseFromAnyTier) error {
+		return onResponse(ctx, respAny.(*pbsubstreamsrpc.Response))
+	})
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_cursorFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursor.txt")
+
+	cursor, err := loadCursor(path)
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	require.NoError(t, saveCursor(path, "first"))
+	require.NoError(t, saveCursor(path, "second"))
+
+	cursor, err = loadCursor(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second", cursor)
+	assert.NoFileExists(t, path+".tmp")
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+var signCmd = &cobra.Command{
+	Use:   "sign [<package.spkg>]",
+	Short: "Sign an .spkg with an ed25519 key",
+	Long: cli.Dedent(`
+		Sign a local .spkg with the ed25519 key of the '--key' file, adding the signature to the package which is
+		rewritten in place, or to '--output-file'. Signing again with the same key replaces its signature.
+
+		Signatures cover the whole package except the values of the params, which are set at run time. Packages
+		are verified against the keys of '--trusted-key' and '--trusted-keys-file': remote packages and imports must
+		then be signed by one of them, and local packages must when they are signed.
+
+		With '--generate-key', a new key is written to the '--key' file instead, and its public key printed so that
+		it can be trusted.
+	`),
+	Example: string(cli.ExamplePrefixed("substreams sign", `
+		--generate-key --key ./signing.key
+		./my-package-v0.1.0.spkg --key ./signing.key
+	`)),
+	RunE:         runSign,
+	Args:         cobra.RangeArgs(0, 1),
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(signCmd)
+	signCmd.Flags().String("key", "", "File of the hex-encoded ed25519 private key seed to sign with")
+	signCmd.Flags().Bool("generate-key", false, "Generate a new key in the '--key' file and print its public key, instead of signing a package")
+	signCmd.Flags().StringP("output-file", "o", "", "File where the signed package is written, defaults to the input package")
+}
+
+func runSign(cmd *cobra.Command, args []string) error {
+	keyFile := mustGetString(cmd, "key")
+	if keyFile == "" {
+		return fmt.Errorf("missing '--key' flag")
+	}
+
+	if mustGetBool(cmd, "generate-key") {
+		if len(args) != 0 {
+			return fmt.Errorf("no package is expected with '--generate-key'")
+		}
+		return generateSigningKey(keyFile)
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("missing package to sign")
+	}
+	spkgPath := args[0]
+
+	key, err := manifest.ReadSigningKeyFile(keyFile)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := os.ReadFile(spkgPath)
+	if err != nil {
+		return fmt.Errorf("reading package: %w", err)
+	}
+	pkg := &pbsubstreams.Package{}
+	if err := proto.Unmarshal(cnt, pkg); err != nil {
+		return fmt.Errorf("unmarshalling package %q: %w", spkgPath, err)
+	}
+
+	if err := manifest.SignPackage(pkg, key); err != nil {
+		return fmt.Errorf("signing package: %w", err)
+	}
+
+	cnt, err = manifest.MarshalPackage(pkg)
+	if err != nil {
+		return err
+	}
+	digest, err := manifest.PackageDigest(pkg)
+	if err != nil {
+		return err
+	}
+
+	outputFile := mustGetString(cmd, "output-file")
+	if outputFile == "" {
+		outputFile = spkgPath
+	}
+	if err := os.WriteFile(outputFile, cnt, 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	fmt.Printf("Successfully signed %q with key %x.\n", outputFile, key.Public())
+	fmt.Println("Package digest:", digest)
+	return nil
+}
+
+func generateSigningKey(keyFile string) error {
+	if _, err := os.Stat(keyFile); err == nil {
+		return fmt.Errorf("key file %q already exists", keyFile)
+	}
+
+	publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("generating key: %w", err)
+	}
+	if err := os.WriteFile(keyFile, []byte(hex.EncodeToString(key.Seed())+"\n"), 0600); err != nil {
+		return fmt.Errorf("writing key file: %w", err)
+	}
+
+	fmt.Printf("Successfully wrote key %q.\n", keyFile)
+	fmt.Printf("Public key: %x\n", publicKey)
+	return nil
+}
@@ -271,7 +271,7 @@ func (e *Engine) moduleOutputForName(moduleName string) (string, error) {
 
 func (e *Engine) FunctionSignature(module *manifest.Module) (*FunctionSignature, error) {
 	switch module.Kind {
-	case manifest.ModuleKindMap:
+	case manifest.ModuleKindMap, manifest.ModuleKindBlockIndex:
 		return e.mapFunctionSignature(module)
 	case manifest.ModuleKindStore:
 		return e.storeFunctionSignature(module)
@@ -336,6 +336,9 @@ func (e *Engine) ModuleArgument(inputs []*manifest.Input) (Arguments, error) {
 			out = append(out, NewArgument(name, inputType, input))
 		case input.IsParams():
 			inputType := strings.Trim(input.Params, " ")
+			if input.IsTypedParams() {
+				inputType = mustTransformProtoType(inputType, e.Manifest)
+			}
 			out = append(out, NewArgument("params", inputType, input))
 		default:
 			return nil, fmt.Errorf("unknown MustModule kind: %T", input)
@@ -0,0 +1,26 @@
+package index
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	pbindex "github.com/streamingfast/substreams/pb/sf/substreams/index/v1"
+)
+
+// KeysOutputType is the only output type accepted for `blockIndex` modules.
+const KeysOutputType = "proto:sf.substreams.index.v1.Keys"
+
+// DecodeKeys decodes the output of a `blockIndex` module into a set of keys.
+func DecodeKeys(data []byte) (map[string]bool, error) {
+	keys := &pbindex.Keys{}
+	if err := proto.Unmarshal(data, keys); err != nil {
+		return nil, fmt.Errorf("unmarshal index keys: %w", err)
+	}
+
+	out := make(map[string]bool, len(keys.Keys))
+	for _, key := range keys.Keys {
+		out[key] = true
+	}
+	return out, nil
+}
@@ -0,0 +1,194 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Query is a parsed block filter expression, evaluated against the keys
+// produced by a `blockIndex` module for a given block.
+//
+// The syntax supports keys, the `&&`, `||` and `!` operators and
+// parentheses, with the usual precedence (`!` binds tighter than `&&`,
+// which binds tighter than `||`):
+//
+//	transfer && (erc20 || erc721) && !0xdeadbeef
+//
+// Keys are made of letters, digits and any of `_`, `-`, `.`, `:` and `/`.
+type Query struct {
+	raw  string
+	root node
+}
+
+func ParseQuery(query string) (*Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].value, p.tokens[p.pos].offset)
+	}
+
+	return &Query{raw: query, root: root}, nil
+}
+
+func (q *Query) String() string { return q.raw }
+
+// Matches returns true when the keys satisfy the query.
+func (q *Query) Matches(keys map[string]bool) bool {
+	return q.root.eval(keys)
+}
+
+type node interface {
+	eval(keys map[string]bool) bool
+}
+
+type keyNode string
+type notNode struct{ operand node }
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+
+func (n keyNode) eval(keys map[string]bool) bool { return keys[string(n)] }
+func (n notNode) eval(keys map[string]bool) bool { return !n.operand.eval(keys) }
+func (n andNode) eval(keys map[string]bool) bool { return n.left.eval(keys) && n.right.eval(keys) }
+func (n orNode) eval(keys map[string]bool) bool  { return n.left.eval(keys) || n.right.eval(keys) }
+
+type tokenKind int
+
+const (
+	tokenKey tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	offset int
+}
+
+func tokenize(query string) (out []token, err error) {
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(query[i:], "&&"):
+			out = append(out, token{tokenAnd, "&&", i})
+			i += 2
+		case strings.HasPrefix(query[i:], "||"):
+			out = append(out, token{tokenOr, "||", i})
+			i += 2
+		case c == '!':
+			out = append(out, token{tokenNot, "!", i})
+			i++
+		case c == '(':
+			out = append(out, token{tokenOpen, "(", i})
+			i++
+		case c == ')':
+			out = append(out, token{tokenClose, ")", i})
+			i++
+		case isKeyChar(c):
+			start := i
+			for i < len(query) && isKeyChar(query[i]) {
+				i++
+			}
+			out = append(out, token{tokenKey, query[start:i], start})
+		default:
+			return nil, fmt.Errorf("invalid character %q at position %d", c, i)
+		}
+	}
+	return out, nil
+}
+
+func isKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '_' || c == '-' || c == '.' || c == ':' || c == '/'
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind == tokenOr; tok = p.peek() {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind == tokenAnd; tok = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	p.pos++
+
+	switch tok.kind {
+	case tokenKey:
+		return keyNode(tok.value), nil
+	case tokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case tokenOpen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing == nil || closing.kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis for %q at position %d", "(", tok.offset)
+		}
+		p.pos++
+		return inner, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.value, tok.offset)
+	}
+}
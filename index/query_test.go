@@ -0,0 +1,62 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_Matches(t *testing.T) {
+	tests := []struct {
+		query  string
+		keys   []string
+		expect bool
+	}{
+		{"transfer", []string{"transfer"}, true},
+		{"transfer", []string{"approval"}, false},
+		{"transfer && erc20", []string{"transfer", "erc20"}, true},
+		{"transfer && erc20", []string{"transfer"}, false},
+		{"transfer || approval", []string{"approval"}, true},
+		{"!transfer", nil, true},
+		{"!transfer", []string{"transfer"}, false},
+		{"a || b && c", []string{"a"}, true},
+		{"(a || b) && c", []string{"a"}, false},
+		{"(a || b) && !c", []string{"b"}, true},
+		{"contract:0xdeadbeef && !event/Transfer", []string{"contract:0xdeadbeef"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			q, err := ParseQuery(test.query)
+			require.NoError(t, err)
+
+			keys := map[string]bool{}
+			for _, k := range test.keys {
+				keys[k] = true
+			}
+			assert.Equal(t, test.expect, q.Matches(keys))
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query     string
+		expectErr string
+	}{
+		{"", "empty query"},
+		{"a &&", "unexpected end of query"},
+		{"(a || b", `missing closing parenthesis for "(" at position 0`},
+		{"a b", `unexpected "b" at position 2`},
+		{"a & b", `invalid character '&' at position 2`},
+		{"&& a", `unexpected "&&" at position 0`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := ParseQuery(test.query)
+			require.EqualError(t, err, test.expectErr)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// canonicalizePackage orders the protobuf files and binaries of `pkg` independently of
+// the way they were collected, so that packing the same sources always produces the same
+// bytes: system protobuf files first, then the others after their dependencies and by
+// name, and binaries by first use by the modules, unused ones being dropped.
+//
+// Modules are left in the order of the manifest and its imports, which module hashes
+// depend on through the order of their ancestors.
+func canonicalizePackage(pkg *pbsubstreams.Package) error {
+	systemFiles, err := readSystemProtobufs()
+	if err != nil {
+		return fmt.Errorf("reading system protobufs: %w", err)
+	}
+	pkg.ProtoFiles = sortProtoFiles(pkg.ProtoFiles, systemFiles.File)
+
+	var binaries []*pbsubstreams.Binary
+	newIndexes := map[uint32]uint32{}
+	for _, mod := range pkg.Modules.Modules {
+		newIndex, found := newIndexes[mod.BinaryIndex]
+		if !found {
+			newIndex = uint32(len(binaries))
+			newIndexes[mod.BinaryIndex] = newIndex
+			binaries = append(binaries, pkg.Modules.Binaries[mod.BinaryIndex])
+		}
+		mod.BinaryIndex = newIndex
+	}
+	pkg.Modules.Binaries = binaries
+
+	return nil
+}
+
+// sortProtoFiles orders `files` with the ones of `systemFiles` first, in their order,
+// then the others after their dependencies, by name otherwise.
+func sortProtoFiles(files []*descriptorpb.FileDescriptorProto, systemFiles []*descriptorpb.FileDescriptorProto) []*descriptorpb.FileDescriptorProto {
+	byName := map[string]*descriptorpb.FileDescriptorProto{}
+	var names []string
+	for _, file := range files {
+		if _, found := byName[file.GetName()]; !found {
+			names = append(names, file.GetName())
+			byName[file.GetName()] = file
+		}
+	}
+	sort.Strings(names)
+
+	out := make([]*descriptorpb.FileDescriptorProto, 0, len(names))
+	added := map[string]bool{}
+	var add func(name string)
+	add = func(name string) {
+		file := byName[name]
+		if added[name] || file == nil {
+			return
+		}
+		added[name] = true
+
+		dependencies := append([]string{}, file.Dependency...)
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			add(dependency)
+		}
+		out = append(out, file)
+	}
+	for _, file := range systemFiles {
+		add(file.GetName())
+	}
+	for _, name := range names {
+		add(name)
+	}
+	return out
+}
+
+// MarshalPackage encodes `pkg` deterministically, the same package always giving the
+// same bytes.
+func MarshalPackage(pkg *pbsubstreams.Package) ([]byte, error) {
+	cnt, err := proto.MarshalOptions{Deterministic: true}.Marshal(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling package: %w", err)
+	}
+	return cnt, nil
+}
+
+// PackageDigest returns the hash of the encoding of `pkg` by MarshalPackage, which is
+// the hash of the content of the '.spkg' files written by `substreams pack`.
+func PackageDigest(pkg *pbsubstreams.Package) (string, error) {
+	cnt, err := MarshalPackage(pkg)
+	if err != nil {
+		return "", err
+	}
+	return packageHash(cnt), nil
+}
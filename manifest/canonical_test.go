@@ -0,0 +1,60 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSortProtoFiles(t *testing.T) {
+	file := func(name string, dependencies ...string) *descriptorpb.FileDescriptorProto {
+		return &descriptorpb.FileDescriptorProto{Name: proto.String(name), Dependency: dependencies}
+	}
+	names := func(files []*descriptorpb.FileDescriptorProto) (out []string) {
+		for _, f := range files {
+			out = append(out, f.GetName())
+		}
+		return
+	}
+
+	system := []*descriptorpb.FileDescriptorProto{file("system/z.proto"), file("system/a.proto", "system/z.proto")}
+	files := []*descriptorpb.FileDescriptorProto{
+		file("c.proto", "system/a.proto", "e.proto"),
+		file("system/a.proto", "system/z.proto"),
+		file("b.proto"),
+		file("e.proto", "d.proto"),
+		file("system/z.proto"),
+		file("d.proto"),
+		file("b.proto"),
+	}
+
+	assert.Equal(t, []string{"system/z.proto", "system/a.proto", "b.proto", "d.proto", "e.proto", "c.proto"}, names(sortProtoFiles(files, system)))
+}
+
+func TestReader_CanonicalPackage(t *testing.T) {
+	pkg, err := MustNewReader("testdata/overrides.yaml").Read()
+	require.NoError(t, err)
+
+	// Binaries are ordered by first use
+	require.Len(t, pkg.Modules.Binaries, 2)
+	assert.Equal(t, uint32(0), findModule(t, pkg, "filtered").BinaryIndex)
+	assert.Equal(t, uint32(1), findModule(t, pkg, "dep:mod1").BinaryIndex)
+	assert.Equal(t, uint32(0), findModule(t, pkg, "dep:mod3").BinaryIndex)
+
+	digest, err := PackageDigest(pkg)
+	require.NoError(t, err)
+	cnt, err := MarshalPackage(pkg)
+	require.NoError(t, err)
+	assert.Equal(t, packageHash(cnt), digest)
+
+	for i := 0; i < 3; i++ {
+		other, err := MustNewReader("testdata/overrides.yaml").Read()
+		require.NoError(t, err)
+		otherDigest, err := PackageDigest(other)
+		require.NoError(t, err)
+		assert.Equal(t, digest, otherDigest)
+	}
+}
@@ -61,6 +61,13 @@ func NewModuleGraph(modules []*pbsubstreams.Module) (*ModuleGraph, error) {
 
 			g.inputOrderIndex[module.Name][moduleName] = j
 		}
+
+		if filter := module.BlockFilter; filter != nil {
+			if j, found := g.moduleIndex[filter.Module]; found {
+				g.AddCost(i, j, 1)
+			}
+			g.inputOrderIndex[module.Name][filter.Module] = len(module.Inputs)
+		}
 	}
 
 	if !graph.Acyclic(g) {
@@ -254,6 +261,9 @@ func (g *ModuleGraph) Context(moduleName string) (parents []string, children []s
 		parents = append(parents, input.Pretty())
 		inputSeen[input.Pretty()] = true
 	}
+	if filter := g.modules[mod].BlockFilter; filter != nil && !inputSeen[filter.Module] {
+		parents = append(parents, filter.Module)
+	}
 
 	for _, m := range g.MustChildrenOf(moduleName) {
 		children = append(children, m.Name)
@@ -0,0 +1,305 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"gopkg.in/yaml.v3"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+type LintLevel string
+
+const (
+	LintLevelError   LintLevel = "error"
+	LintLevelWarning LintLevel = "warning"
+)
+
+type LintRule struct {
+	ID          string
+	Level       LintLevel
+	Description string
+}
+
+var (
+	LintRuleUnusedModule = &LintRule{"unused-module", LintLevelWarning, "Module not used to compute the sink module, or block index not used by any module"}
+	LintRuleUnreadStore  = &LintRule{"unread-store", LintLevelWarning, "Store module never read by any module"}
+	LintRuleUnknownType  = &LintRule{"unknown-output-type", LintLevelError, "Output or value type not found in the protobuf definitions of the package"}
+	LintRuleDeltasOnSet  = &LintRule{"deltas-on-set-if-not-exists", LintLevelWarning, "Store input in 'deltas' mode on a store with the 'set_if_not_exists' update policy"}
+	LintRuleDuplicate    = &LintRule{"duplicate-input", LintLevelError, "Module consumed more than once in the inputs of a module"}
+	LintRuleInitialBlock = &LintRule{"initial-block-before-input", LintLevelWarning, "Module initial block lower than the one of its inputs"}
+	LintRuleUnusedProto  = &LintRule{"unused-proto-file", LintLevelWarning, "Protobuf file defining no type used by the modules or the sink"}
+)
+
+// LintRules lists the rules checked by Lint.
+var LintRules = []*LintRule{
+	LintRuleUnusedModule,
+	LintRuleUnreadStore,
+	LintRuleUnknownType,
+	LintRuleDeltasOnSet,
+	LintRuleDuplicate,
+	LintRuleInitialBlock,
+	LintRuleUnusedProto,
+}
+
+type LintIssue struct {
+	Rule    *LintRule
+	Message string
+	// Line of the manifest where the issue is located, 0 when unknown.
+	Line int
+}
+
+// Lint reads the local manifest `input` and returns the issues found in its modules and
+// protobuf files, in the order of the manifest. Imported modules are only checked
+// through their use by the manifest's modules.
+func Lint(input string) ([]*LintIssue, error) {
+	r, err := NewReader(input, SkipModuleOutputTypeValidationReader())
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsLocalManifest() {
+		return nil, fmt.Errorf("%q is not a local manifest", input)
+	}
+
+	m, err := LoadManifestFile(r.resolvedInput)
+	if err != nil {
+		return nil, err
+	}
+	// Not validated, so that invalid inputs are reported as issues
+	pkg, _, err := r.manifestToPkg(m)
+	if err != nil {
+		return nil, err
+	}
+
+	cnt, err := os.ReadFile(r.resolvedInput)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", r.resolvedInput, err)
+	}
+	l, err := newLinter(m, pkg, cnt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mod := range m.Modules {
+		if err := l.lintModule(mod); err != nil {
+			return nil, fmt.Errorf("module %q: %w", mod.Name, err)
+		}
+	}
+	l.lintProtoFiles()
+
+	return l.issues, nil
+}
+
+type linter struct {
+	manif    *Manifest
+	pkg      *pbsubstreams.Package
+	graph    *ModuleGraph
+	files    map[string]*desc.FileDescriptor
+	modules  map[string]*pbsubstreams.Module
+	sinkUsed map[string]bool
+
+	moduleLines    map[string]int
+	protoFileLines map[string]int
+
+	issues []*LintIssue
+}
+
+func newLinter(m *Manifest, pkg *pbsubstreams.Package, manifestContent []byte) (*linter, error) {
+	l := &linter{
+		manif:   m,
+		pkg:     pkg,
+		modules: map[string]*pbsubstreams.Module{},
+	}
+
+	for _, mod := range pkg.Modules.Modules {
+		l.modules[mod.Name] = mod
+	}
+
+	var err error
+	if l.graph, err = NewModuleGraph(pkg.Modules.Modules); err != nil {
+		return nil, fmt.Errorf("module graph: %w", err)
+	}
+	if l.files, err = desc.CreateFileDescriptors(pkg.ProtoFiles); err != nil {
+		return nil, fmt.Errorf("protobuf definitions: %w", err)
+	}
+
+	if pkg.SinkModule != "" {
+		ancestors, err := l.graph.AncestorsOf(pkg.SinkModule)
+		if err != nil {
+			return nil, fmt.Errorf("sink module: %w", err)
+		}
+		l.sinkUsed = map[string]bool{pkg.SinkModule: true}
+		for _, ancestor := range ancestors {
+			l.sinkUsed[ancestor.Name] = true
+		}
+	}
+
+	l.moduleLines, l.protoFileLines = manifestLines(manifestContent)
+	return l, nil
+}
+
+func (l *linter) report(rule *LintRule, line int, format string, args ...interface{}) {
+	l.issues = append(l.issues, &LintIssue{Rule: rule, Message: fmt.Sprintf(format, args...), Line: line})
+}
+
+func (l *linter) lintModule(manifMod *Module) error {
+	mod := l.modules[manifMod.Name]
+	line := l.moduleLines[mod.Name]
+
+	children, err := l.graph.ChildrenOf(mod.Name)
+	if err != nil {
+		return err
+	}
+
+	unused := len(children) == 0
+	switch kind := mod.Kind.(type) {
+	case *pbsubstreams.Module_KindMap_:
+		l.lintOutputType(mod, line, "output type", kind.KindMap.OutputType)
+	case *pbsubstreams.Module_KindStore_:
+		l.lintOutputType(mod, line, "value type", kind.KindStore.ValueType)
+		if unused {
+			l.report(LintRuleUnreadStore, line, "store %q is not read by any module", mod.Name)
+		}
+	case *pbsubstreams.Module_KindBlockIndex_:
+		if unused {
+			l.report(LintRuleUnusedModule, line, "block index %q is not used by any module", mod.Name)
+		}
+	}
+
+	// Unread stores and indexes are already reported
+	if l.sinkUsed != nil && !l.sinkUsed[mod.Name] && (mod.GetKindMap() != nil || !unused) {
+		l.report(LintRuleUnusedModule, line, "module %q is not used by the sink module %q", mod.Name, l.pkg.SinkModule)
+	}
+
+	consumed := map[string]bool{}
+	for _, input := range mod.Inputs {
+		var name string
+		switch in := input.Input.(type) {
+		case *pbsubstreams.Module_Input_Source_:
+			name = "source " + in.Source.Type
+		case *pbsubstreams.Module_Input_Map_:
+			name = "module " + in.Map.ModuleName
+		case *pbsubstreams.Module_Input_Store_:
+			name = "module " + in.Store.ModuleName
+			if store := l.modules[in.Store.ModuleName].GetKindStore(); store != nil && in.Store.Mode == pbsubstreams.Module_Input_Store_DELTAS && store.UpdatePolicy == pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_IF_NOT_EXISTS {
+				l.report(LintRuleDeltasOnSet, line, "module %q reads the deltas of store %q, which only change when a key is first set", mod.Name, in.Store.ModuleName)
+			}
+		case *pbsubstreams.Module_Input_Params_:
+			name = "params"
+		}
+
+		if consumed[name] {
+			l.report(LintRuleDuplicate, line, "module %q consumes %s more than once", mod.Name, name)
+		}
+		consumed[name] = true
+	}
+
+	// Inherited initial blocks are never lower than the ones of the inputs
+	if manifMod.InitialBlock != nil {
+		parents, err := l.graph.ParentsOf(mod.Name)
+		if err != nil {
+			return err
+		}
+		for _, parent := range parents {
+			if parent.InitialBlock > mod.InitialBlock {
+				l.report(LintRuleInitialBlock, line, "module %q starts at block %d, before its input %q which starts at block %d", mod.Name, mod.InitialBlock, parent.Name, parent.InitialBlock)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *linter) lintOutputType(mod *pbsubstreams.Module, line int, what string, outputType string) {
+	if !strings.HasPrefix(outputType, "proto:") {
+		return
+	}
+	if FindMessageDescriptor(l.files, strings.TrimPrefix(outputType, "proto:")) == nil {
+		l.report(LintRuleUnknownType, line, "%s %q of module %q is not defined in the protobuf files of the package", what, outputType, mod.Name)
+	}
+}
+
+// lintProtoFiles reports the manifest's protobuf files defining none of the types used
+// by the modules and the sink, directly or through the fields of these types.
+func (l *linter) lintProtoFiles() {
+	usedFiles := map[string]bool{}
+	seen := map[string]bool{}
+	var use func(msgDesc *desc.MessageDescriptor)
+	use = func(msgDesc *desc.MessageDescriptor) {
+		if msgDesc == nil || seen[msgDesc.GetFullyQualifiedName()] {
+			return
+		}
+		seen[msgDesc.GetFullyQualifiedName()] = true
+		usedFiles[msgDesc.GetFile().GetName()] = true
+
+		for _, field := range msgDesc.GetFields() {
+			if enumDesc := field.GetEnumType(); enumDesc != nil {
+				usedFiles[enumDesc.GetFile().GetName()] = true
+			}
+			use(field.GetMessageType())
+		}
+	}
+
+	var usedTypes []string
+	for _, mod := range l.pkg.Modules.Modules {
+		usedTypes = append(usedTypes, ModuleOutputType(mod))
+		if len(mod.Inputs) != 0 && mod.Inputs[0].GetParams() != nil {
+			usedTypes = append(usedTypes, mod.Inputs[0].GetParams().Type)
+		}
+	}
+	for _, usedType := range usedTypes {
+		if strings.HasPrefix(usedType, "proto:") {
+			use(FindMessageDescriptor(l.files, strings.TrimPrefix(usedType, "proto:")))
+		}
+	}
+	if sinkConfig := l.pkg.SinkConfig; sinkConfig != nil {
+		use(FindMessageDescriptor(l.files, strings.TrimPrefix(sinkConfig.TypeUrl, "type.googleapis.com/")))
+	}
+
+	for _, file := range l.manif.Protobuf.Files {
+		if !usedFiles[file] {
+			l.report(LintRuleUnusedProto, l.protoFileLines[file], "protobuf file %q defines no type used by the modules or the sink", file)
+		}
+	}
+}
+
+// manifestLines returns the lines of the manifest's modules and protobuf files, errors
+// only leaving issues without a line.
+func manifestLines(manifestContent []byte) (moduleLines map[string]int, protoFileLines map[string]int) {
+	moduleLines, protoFileLines = map[string]int{}, map[string]int{}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(manifestContent, &doc); err != nil || len(doc.Content) == 0 {
+		return
+	}
+
+	value := func(mapping *yaml.Node, key string) *yaml.Node {
+		if mapping == nil || mapping.Kind != yaml.MappingNode {
+			return nil
+		}
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			if mapping.Content[i].Value == key {
+				return mapping.Content[i+1]
+			}
+		}
+		return nil
+	}
+
+	root := doc.Content[0]
+	if modules := value(root, "modules"); modules != nil {
+		for _, mod := range modules.Content {
+			if name := value(mod, "name"); name != nil {
+				moduleLines[name.Value] = mod.Line
+			}
+		}
+	}
+	if files := value(value(root, "protobuf"), "files"); files != nil {
+		for _, file := range files.Content {
+			protoFileLines[file.Value] = file.Line
+		}
+	}
+	return
+}
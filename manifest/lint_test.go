@@ -0,0 +1,41 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	issues, err := Lint("testdata/lint.yaml")
+	require.NoError(t, err)
+
+	type issue struct {
+		rule    string
+		line    int
+		message string
+	}
+	var actual []issue
+	for _, i := range issues {
+		actual = append(actual, issue{i.Rule.ID, i.Line, i.Message})
+	}
+
+	assert.Equal(t, []issue{
+		{"unknown-output-type", 35, `output type "proto:sf.substreams.test.Unknown" of module "map_b" is not defined in the protobuf files of the package`},
+		{"deltas-on-set-if-not-exists", 35, `module "map_b" reads the deltas of store "store_a", which only change when a key is first set`},
+		{"duplicate-input", 35, `module "map_b" consumes module store_a more than once`},
+		{"initial-block-before-input", 35, `module "map_b" starts at block 50, before its input "map_a" which starts at block 100`},
+		{"initial-block-before-input", 35, `module "map_b" starts at block 50, before its input "store_a" which starts at block 100`},
+		{"unread-store", 46, `store "store_unread" is not read by any module`},
+		{"unused-module", 53, `block index "index_unused" is not used by any module`},
+		{"unused-module", 60, `module "map_unused" is not used by the sink module "map_b"`},
+		{"unused-proto-file", 9, `protobuf file "unused.proto" defines no type used by the modules or the sink`},
+	}, actual)
+
+	// Imported modules are not linted
+	issues, err = Lint("testdata/overrides.yaml")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, `output type "proto:test" of module "filtered" is not defined in the protobuf files of the package`, issues[0].Message)
+}
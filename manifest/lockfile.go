@@ -0,0 +1,153 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const LockfileName = "substreams.lock"
+const LockfileVersion = 1
+
+const packageHashPrefix = "sha256:"
+
+// PackageCacheDir is the content-addressed directory where the remote imports
+// are cached, by hash of their content. An empty value disables the cache.
+var PackageCacheDir = defaultPackageCacheDir()
+
+func defaultPackageCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "substreams", "packages")
+}
+
+// Lockfile pins the remote imports of a manifest, by import name, to the hash
+// of their content. It is written next to the manifest by `substreams pack`.
+type Lockfile struct {
+	Version int                      `yaml:"version"`
+	Imports map[string]*LockedImport `yaml:"imports"`
+}
+
+type LockedImport struct {
+	URL  string `yaml:"url"`
+	Hash string `yaml:"hash"`
+}
+
+func readLockfile(dir string) (*Lockfile, error) {
+	path := filepath.Join(dir, LockfileName)
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Lockfile{}, nil
+		}
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	lock := &Lockfile{}
+	if err := yaml.Unmarshal(cnt, lock); err != nil {
+		return nil, fmt.Errorf("decoding %q: %w", path, err)
+	}
+	if lock.Version != LockfileVersion {
+		return nil, fmt.Errorf("%q: unsupported version %d, expected %d", path, lock.Version, LockfileVersion)
+	}
+	return lock, nil
+}
+
+func writeLockfile(dir string, lock *Lockfile) error {
+	cnt, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("encoding lockfile: %w", err)
+	}
+	cnt = append([]byte("# Generated by 'substreams pack', do not edit.\n"), cnt...)
+
+	path := filepath.Join(dir, LockfileName)
+	if err := os.WriteFile(path, cnt, 0644); err != nil {
+		return fmt.Errorf("writing %q: %w", path, err)
+	}
+	return nil
+}
+
+// readLockedImport returns the content of the remote import `name` at `importURL`,
+// read from the package cache when `locked` pins it, and fetched otherwise. Content
+// not matching the pinned hash is refused. A pinned URL that changed is refused too,
+// unless the lockfile is being updated.
+func (r *Reader) readLockedImport(name string, importURL string, locked *LockedImport) ([]byte, *LockedImport, error) {
+	if locked != nil && locked.URL != importURL && !r.updateLockfile {
+		return nil, nil, fmt.Errorf("import %q: url %q differs from %q pinned in %s, run 'substreams pack' to update it", name, importURL, locked.URL, LockfileName)
+	}
+
+	if locked != nil && locked.URL == importURL {
+		if cnt := readCachedPackage(locked.Hash); cnt != nil {
+			return cnt, locked, nil
+		}
+	}
+
+	cnt, err := readRemoteContent(importURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("importing %q: %w", importURL, err)
+	}
+
+	hash := packageHash(cnt)
+	if locked != nil && locked.URL == importURL && hash != locked.Hash {
+		return nil, nil, fmt.Errorf("import %q: content of %q has hash %s but %s is pinned in %s, remove its entry to accept the new content", name, importURL, hash, locked.Hash, LockfileName)
+	}
+
+	writeCachedPackage(hash, cnt)
+	return cnt, &LockedImport{URL: importURL, Hash: hash}, nil
+}
+
+func packageHash(cnt []byte) string {
+	sum := sha256.Sum256(cnt)
+	return packageHashPrefix + hex.EncodeToString(sum[:])
+}
+
+func cachedPackagePath(hash string) string {
+	return filepath.Join(PackageCacheDir, strings.TrimPrefix(hash, packageHashPrefix)+".spkg")
+}
+
+// readCachedPackage returns the cached content of hash `hash`, or nil when it's
+// not cached or its content is corrupted.
+func readCachedPackage(hash string) []byte {
+	if PackageCacheDir == "" || !strings.HasPrefix(hash, packageHashPrefix) {
+		return nil
+	}
+
+	cnt, err := os.ReadFile(cachedPackagePath(hash))
+	if err != nil || packageHash(cnt) != hash {
+		return nil
+	}
+	return cnt
+}
+
+// writeCachedPackage caches `cnt`, failures only making the next reads fetch it again.
+func writeCachedPackage(hash string, cnt []byte) {
+	if PackageCacheDir == "" {
+		return
+	}
+
+	if err := os.MkdirAll(PackageCacheDir, os.ModePerm); err != nil {
+		zlog.Warn("unable to create package cache directory", zap.String("dir", PackageCacheDir), zap.Error(err))
+		return
+	}
+
+	// Written aside then renamed, so concurrent reads never see partial content.
+	path := cachedPackagePath(hash)
+	tmpPath := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmpPath, cnt, 0644); err != nil {
+		zlog.Warn("unable to write cached package", zap.String("path", path), zap.Error(err))
+		return
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		zlog.Warn("unable to write cached package", zap.String("path", path), zap.Error(err))
+		os.Remove(tmpPath)
+	}
+}
@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReader_Lockfile(t *testing.T) {
+	previousCacheDir := PackageCacheDir
+	PackageCacheDir = t.TempDir()
+	defer func() { PackageCacheDir = previousCacheDir }()
+
+	spkg1Content, err := os.ReadFile("testdata/spkg1/spkg1-v0.0.0.spkg")
+	require.NoError(t, err)
+	dep1Content, err := proto.Marshal(MustNewReader("testdata/dep1.yaml").MustRead())
+	require.NoError(t, err)
+
+	served := spkg1Content
+	var requests int
+	remoteServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Write(served)
+	}))
+	defer remoteServer.Close()
+
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	writeManifest := func(spkgName string) {
+		require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+imports:
+  bare: "`+remoteServer.URL+`/`+spkgName+`"
+`), 0644))
+	}
+	writeManifest("spkg1-v0.0.0.spkg")
+
+	// Without a lockfile, imports are fetched and not pinned.
+	_, err = MustNewReader(manifestPath).Read()
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, LockfileName))
+
+	_, err = MustNewReader(manifestPath, WithUpdateLockfile()).Read()
+	require.NoError(t, err)
+	lock, err := readLockfile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, &Lockfile{
+		Version: LockfileVersion,
+		Imports: map[string]*LockedImport{
+			"bare": {URL: remoteServer.URL + "/spkg1-v0.0.0.spkg", Hash: packageHash(spkg1Content)},
+		},
+	}, lock)
+
+	// Pinned imports are read from the cache, whatever the server now serves.
+	served = dep1Content
+	requests = 0
+	pkg, err := MustNewReader(manifestPath).Read()
+	require.NoError(t, err)
+	assert.Equal(t, 0, requests)
+	assert.Equal(t, "spkg1", pkg.PackageMeta[1].Name)
+
+	// Without the cache, the fetched content must match the pinned hash.
+	require.NoError(t, os.RemoveAll(PackageCacheDir))
+	_, err = MustNewReader(manifestPath).Read()
+	assert.ErrorContains(t, err, `import "bare": content of "`+remoteServer.URL+`/spkg1-v0.0.0.spkg" has hash `+packageHash(dep1Content)+` but `+packageHash(spkg1Content)+` is pinned in substreams.lock, remove its entry to accept the new content`)
+
+	// A changed URL must be pinned again.
+	writeManifest("dep1.spkg")
+	_, err = MustNewReader(manifestPath).Read()
+	assert.ErrorContains(t, err, `import "bare": url "`+remoteServer.URL+`/dep1.spkg" differs from "`+remoteServer.URL+`/spkg1-v0.0.0.spkg" pinned in substreams.lock, run 'substreams pack' to update it`)
+
+	_, err = MustNewReader(manifestPath, WithUpdateLockfile()).Read()
+	require.NoError(t, err)
+	_, err = MustNewReader(manifestPath).Read()
+	require.NoError(t, err)
+	lock, err = readLockfile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, packageHash(dep1Content), lock.Imports["bare"].Hash)
+}
@@ -12,6 +12,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/streamingfast/substreams/index"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
@@ -24,28 +25,38 @@ func init() {
 }
 
 const (
-	ModuleKindStore = "store"
-	ModuleKindMap   = "map"
+	ModuleKindStore      = "store"
+	ModuleKindMap        = "map"
+	ModuleKindBlockIndex = "blockIndex"
 )
 
 // Manifest is a YAML structure used to create a Package and its list
 // of Modules. The notion of a manifest does not live in protobuf definitions.
 type Manifest struct {
-	SpecVersion string            `yaml:"specVersion"` // check that it equals v0.1.0
-	Package     PackageMeta       `yaml:"package"`
-	Protobuf    Protobuf          `yaml:"protobuf"`
-	Imports     mapSlice          `yaml:"imports"`
-	Binaries    map[string]Binary `yaml:"binaries"`
-	Modules     []*Module         `yaml:"modules"`
-	Params      map[string]string `yaml:"params"`
-
-	Network string `yaml:"network"`
-	Sink    *Sink  `yaml:"sink"`
+	SpecVersion string                 `yaml:"specVersion"` // check that it equals v0.1.0
+	Package     PackageMeta            `yaml:"package"`
+	Protobuf    Protobuf               `yaml:"protobuf"`
+	Imports     mapSlice               `yaml:"imports"`
+	Binaries    map[string]Binary      `yaml:"binaries"`
+	Modules     []*Module              `yaml:"modules"`
+	Params      map[string]ParamsValue `yaml:"params"`
+
+	Network   string                   `yaml:"network"`
+	Networks  map[string]NetworkParams `yaml:"networks"`
+	Overrides []*ModuleOverride        `yaml:"overrides"`
+	Sink      *Sink                    `yaml:"sink"`
 
 	Graph   *ModuleGraph `yaml:"-"`
 	Workdir string       `yaml:"-"`
 }
 
+// NetworkParams overrides the initial block and the params of modules, local or
+// imported, when the package is read for a given network.
+type NetworkParams struct {
+	InitialBlocks map[string]uint64      `yaml:"initialBlocks"`
+	Params        map[string]ParamsValue `yaml:"params"`
+}
+
 type Sink struct {
 	Type   string      `yaml:"type"`
 	Module string      `yaml:"module"`
@@ -84,8 +95,26 @@ type Module struct {
 	ValueType    string `yaml:"valueType"`
 	Binary       string `yaml:"binary"`
 
-	Inputs []*Input     `yaml:"inputs"`
-	Output StreamOutput `yaml:"output"`
+	Inputs      []*Input     `yaml:"inputs"`
+	Output      StreamOutput `yaml:"output"`
+	BlockFilter *BlockFilter `yaml:"blockFilter"`
+	Limits      *Limits      `yaml:"limits"`
+}
+
+// Limits are the resource limits of a module, capped by the maximums of the
+// server which replace the unset ones.
+type Limits struct {
+	MaxFuelPerBlock  uint64 `yaml:"maxFuelPerBlock"`
+	MaxLogBytes      uint64 `yaml:"maxLogBytes"`
+	MaxStoreSize     uint64 `yaml:"maxStoreSize"`
+	MaxStoreItemSize uint64 `yaml:"maxStoreItemSize"`
+}
+
+// BlockFilter restricts the execution of a module to the blocks for which
+// the keys produced by the `blockIndex` module named `Module` match `Query`.
+type BlockFilter struct {
+	Module string `yaml:"module"`
+	Query  string `yaml:"query"`
 }
 
 type Input struct {
@@ -107,7 +136,7 @@ type Binary struct {
 }
 
 type StreamOutput struct {
-	// For 'map'
+	// For 'map' and 'blockIndex'
 	Type string `yaml:"type"`
 }
 
@@ -141,6 +170,11 @@ func (i *Input) IsParams() bool {
 	return i.Params != "" && i.Source == "" && i.Map == "" && i.Store == ""
 }
 
+// IsTypedParams returns whether the params are a protobuf message, declared as 'proto:<type>'.
+func (i *Input) IsTypedParams() bool {
+	return i.IsParams() && strings.HasPrefix(i.Params, "proto:")
+}
+
 func (i *Input) parse() error {
 	if i.IsMap() {
 		//i.Name = fmt.Sprintf("map:%s", i.Map)
@@ -159,8 +193,8 @@ func (i *Input) parse() error {
 		return nil
 	}
 	if i.IsParams() {
-		if i.Params != "string" {
-			return fmt.Errorf("input 'params': 'string' is the only acceptable value here; specify the parameter's value under the top-level 'params' mapping")
+		if i.Params != "string" && !i.IsTypedParams() {
+			return fmt.Errorf("input 'params': 'string' or a 'proto:' message type are the only acceptable values here; specify the parameter's value under the top-level 'params' mapping")
 		}
 		return nil
 	}
@@ -231,6 +265,26 @@ func validateStoreBuilder(module *Module) error {
 	return nil
 }
 
+func validateBlockIndex(module *Module) error {
+	if module.Output.Type != index.KeysOutputType {
+		return fmt.Errorf("'output.type' for kind 'blockIndex' must be %q", index.KeysOutputType)
+	}
+	if module.BlockFilter != nil {
+		return errors.New("'blockFilter' cannot be used on kind 'blockIndex'")
+	}
+	return nil
+}
+
+func validateBlockFilter(filter *BlockFilter) error {
+	if filter.Module == "" {
+		return errors.New("missing 'blockFilter.module'")
+	}
+	if _, err := index.ParseQuery(filter.Query); err != nil {
+		return fmt.Errorf("invalid 'blockFilter.query' %q: %w", filter.Query, err)
+	}
+	return nil
+}
+
 func (m *Module) String() string {
 	return m.Name
 }
@@ -249,6 +303,8 @@ func (m *Module) ToProtoWASM(codeIndex uint32) (*pbsubstreams.Module, error) {
 
 	m.setOutputToProto(out)
 	m.setKindToProto(out)
+	m.setBlockFilterToProto(out)
+	m.setLimitsToProto(out)
 	err := m.setInputsToProto(out)
 	if err != nil {
 		return nil, fmt.Errorf("setting input for module, %s: %w", m.Name, err)
@@ -318,6 +374,9 @@ func (m *Module) setInputsToProto(pbModule *pbsubstreams.Module) error {
 					},
 				},
 			}
+			if input.IsTypedParams() {
+				pbInput.GetParams().Type = input.Params
+			}
 			pbModule.Inputs = append(pbModule.Inputs, pbInput)
 			continue
 		}
@@ -356,6 +415,12 @@ func (m *Module) setKindToProto(pbModule *pbsubstreams.Module) {
 				OutputType: m.Output.Type,
 			},
 		}
+	case ModuleKindBlockIndex:
+		pbModule.Kind = &pbsubstreams.Module_KindBlockIndex_{
+			KindBlockIndex: &pbsubstreams.Module_KindBlockIndex{
+				OutputType: m.Output.Type,
+			},
+		}
 	case ModuleKindStore:
 		var updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy
 		switch m.UpdatePolicy {
@@ -390,3 +455,23 @@ func (m *Module) setOutputToProto(pbModule *pbsubstreams.Module) {
 		}
 	}
 }
+
+func (m *Module) setLimitsToProto(pbModule *pbsubstreams.Module) {
+	if m.Limits != nil {
+		pbModule.Limits = &pbsubstreams.Module_Limits{
+			MaxFuelPerBlock:  m.Limits.MaxFuelPerBlock,
+			MaxLogBytes:      m.Limits.MaxLogBytes,
+			MaxStoreSize:     m.Limits.MaxStoreSize,
+			MaxStoreItemSize: m.Limits.MaxStoreItemSize,
+		}
+	}
+}
+
+func (m *Module) setBlockFilterToProto(pbModule *pbsubstreams.Module) {
+	if m.BlockFilter != nil {
+		pbModule.BlockFilter = &pbsubstreams.Module_BlockFilter{
+			Module: m.BlockFilter.Module,
+			Query:  m.BlockFilter.Query,
+		}
+	}
+}
@@ -61,6 +61,30 @@ inputs:
 				Inputs:       []*Input{{Source: "proto:sf.ethereum.type.v1.Block"}, {Store: "pairs"}},
 			},
 		},
+		{
+			name: "store with limits",
+			rawYamlInput: `---
+name: prices
+kind: store
+updatePolicy: set
+valueType: bytes
+inputs:
+  - source: proto:sf.ethereum.type.v1.Block
+limits:
+  maxFuelPerBlock: 1000000
+  maxLogBytes: 4096
+  maxStoreSize: 104857600
+  maxStoreItemSize: 1024
+`,
+			expectedOutput: Module{
+				Name:         "prices",
+				Kind:         "store",
+				UpdatePolicy: "set",
+				ValueType:    "bytes",
+				Inputs:       []*Input{{Source: "proto:sf.ethereum.type.v1.Block"}},
+				Limits:       &Limits{MaxFuelPerBlock: 1000000, MaxLogBytes: 4096, MaxStoreSize: 104857600, MaxStoreItemSize: 1024},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -51,6 +51,8 @@ func generateMermaidGraph(mods *pbsubstreams.Modules) string {
 			str.WriteString(fmt.Sprintf("  %s[map: %s];\n", s.Name, s.Name))
 		case *pbsubstreams.Module_KindStore_:
 			str.WriteString(fmt.Sprintf("  %s[store: %s];\n", s.Name, s.Name))
+		case *pbsubstreams.Module_KindBlockIndex_:
+			str.WriteString(fmt.Sprintf("  %s[blockIndex: %s];\n", s.Name, s.Name))
 		}
 
 		for _, in := range s.Inputs {
@@ -74,6 +76,10 @@ func generateMermaidGraph(mods *pbsubstreams.Modules) string {
 				str.WriteString(fmt.Sprintf("  %s[params] --> %s;\n", name, s.Name))
 			}
 		}
+
+		if s.BlockFilter != nil {
+			str.WriteString(fmt.Sprintf("  %s -. filter .-> %s;\n", s.BlockFilter.Module, s.Name))
+		}
 	}
 
 	return str.String()
@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jhump/protoreflect/desc"
@@ -35,6 +36,9 @@ func BuildMessageDescriptors(pkg *pbsubstreams.Package) (out map[string]*ModuleD
 		case *pbsubstreams.Module_KindMap_:
 			msgType = modKind.KindMap.OutputType
 			desc.MapOutputType = msgType
+		case *pbsubstreams.Module_KindBlockIndex_:
+			msgType = modKind.KindBlockIndex.OutputType
+			desc.MapOutputType = msgType
 		}
 		if strings.HasPrefix(msgType, "proto:") {
 			msgType = strings.TrimPrefix(msgType, "proto:")
@@ -52,3 +56,35 @@ func BuildMessageDescriptors(pkg *pbsubstreams.Package) (out map[string]*ModuleD
 	}
 	return
 }
+
+// ModuleOutputType returns the output type of a map or blockIndex module, the value
+// type of a store module.
+func ModuleOutputType(mod *pbsubstreams.Module) string {
+	switch kind := mod.Kind.(type) {
+	case *pbsubstreams.Module_KindMap_:
+		return kind.KindMap.OutputType
+	case *pbsubstreams.Module_KindStore_:
+		return kind.KindStore.ValueType
+	case *pbsubstreams.Module_KindBlockIndex_:
+		return kind.KindBlockIndex.OutputType
+	}
+	return ""
+}
+
+// FindMessageDescriptor returns the descriptor of the fully qualified `messageType`
+// defined in `files`, nil if none does. Files are searched in name order so that the
+// same definition is found when it is duplicated in multiple files.
+func FindMessageDescriptor(files map[string]*desc.FileDescriptor, messageType string) *desc.MessageDescriptor {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if msgDesc := files[name].FindMessage(messageType); msgDesc != nil {
+			return msgDesc
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// applyNetwork resolves the 'networks' section of `m` for `network`, or the
+// manifest's 'network' when empty: the network's initial blocks are set on the
+// modules of `pkg`, and its params override the ones of the 'params' section.
+func applyNetwork(pkg *pbsubstreams.Package, m *Manifest, network string) error {
+	if network == "" {
+		network = m.Network
+	}
+	pkg.Network = network
+
+	if len(m.Networks) == 0 {
+		return nil
+	}
+
+	if err := validateNetworks(pkg, m); err != nil {
+		return fmt.Errorf("networks: %w", err)
+	}
+
+	if network == "" {
+		return fmt.Errorf("networks: no network selected, set the manifest's 'network' or use '--network', one of %s", strings.Join(networkNames(m), ", "))
+	}
+	networkParams, found := m.Networks[network]
+	if !found {
+		return fmt.Errorf("networks: network %q is not defined, must be one of %s", network, strings.Join(networkNames(m), ", "))
+	}
+
+	for _, mod := range pkg.Modules.Modules {
+		if initialBlock, found := networkParams.InitialBlocks[mod.Name]; found {
+			mod.InitialBlock = initialBlock
+		}
+	}
+
+	if len(networkParams.Params) != 0 {
+		params := make(map[string]ParamsValue, len(m.Params)+len(networkParams.Params))
+		for modName, value := range m.Params {
+			params[modName] = value
+		}
+		for modName, value := range networkParams.Params {
+			params[modName] = value
+		}
+		m.Params = params
+	}
+
+	return nil
+}
+
+// validateNetworks checks that the modules of the 'networks' section exist, and
+// that every network defines the initial blocks and params of the same modules,
+// so that none falls back on values meant for another network.
+func validateNetworks(pkg *pbsubstreams.Package, m *Manifest) error {
+	modules := map[string]bool{}
+	for _, mod := range pkg.Modules.Modules {
+		modules[mod.Name] = true
+	}
+
+	names := networkNames(m)
+	reference := m.Networks[names[0]]
+	for _, name := range names {
+		networkParams := m.Networks[name]
+		for modName := range networkParams.InitialBlocks {
+			if !modules[modName] {
+				return fmt.Errorf("network %q: initial block defined for module %q, but such module is not defined", name, modName)
+			}
+		}
+		for modName := range networkParams.Params {
+			if !modules[modName] {
+				return fmt.Errorf("network %q: params defined for module %q, but such module is not defined", name, modName)
+			}
+		}
+
+		if missing := firstMissingKey(networkParams.InitialBlocks, reference.InitialBlocks); missing != "" {
+			return fmt.Errorf("network %q: initial block of module %q is defined by network %q but not by this one", name, missing, names[0])
+		}
+		if missing := firstMissingKey(reference.InitialBlocks, networkParams.InitialBlocks); missing != "" {
+			return fmt.Errorf("network %q: initial block of module %q is defined by this network but not by network %q", name, missing, names[0])
+		}
+		if missing := firstMissingKey(networkParams.Params, reference.Params); missing != "" {
+			return fmt.Errorf("network %q: params of module %q are defined by network %q but not by this one", name, missing, names[0])
+		}
+		if missing := firstMissingKey(reference.Params, networkParams.Params); missing != "" {
+			return fmt.Errorf("network %q: params of module %q are defined by this network but not by network %q", name, missing, names[0])
+		}
+	}
+	return nil
+}
+
+func networkNames(m *Manifest) []string {
+	names := make([]string, 0, len(m.Networks))
+	for name := range m.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// firstMissingKey returns the first key, in sorted order, of `reference` not in `keys`.
+func firstMissingKey[V any](keys, reference map[string]V) string {
+	var missing []string
+	for key := range reference {
+		if _, found := keys[key]; !found {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	sort.Strings(missing)
+	return missing[0]
+}
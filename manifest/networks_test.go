@@ -0,0 +1,151 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReader_Networks(t *testing.T) {
+	tests := []struct {
+		name               string
+		file               string
+		network            string
+		expectNetwork      string
+		expectInitialBlock uint64
+		expectParams       string
+		expectErrMsg       string
+	}{
+		{
+			name:               "manifest network",
+			file:               "testdata/networks.yaml",
+			expectNetwork:      "mainnet",
+			expectInitialBlock: 12369621,
+			expectParams:       "mainnet",
+		},
+		{
+			name:               "overridden network",
+			file:               "testdata/networks.yaml",
+			network:            "polygon",
+			expectNetwork:      "polygon",
+			expectInitialBlock: 4000000,
+			expectParams:       "polygon",
+		},
+		{
+			name:         "unknown network",
+			file:         "testdata/networks.yaml",
+			network:      "solana",
+			expectErrMsg: `networks: network "solana" is not defined, must be one of mainnet, polygon`,
+		},
+		{
+			name:         "network missing a module's params",
+			file:         "testdata/networks_incomplete.yaml",
+			expectErrMsg: `networks: network "polygon": params of module "mod2" are defined by network "mainnet" but not by this one`,
+		},
+		{
+			name:               "without networks",
+			file:               "testdata/with-params.yaml",
+			network:            "polygon",
+			expectNetwork:      "polygon",
+			expectInitialBlock: UNSET,
+			expectParams:       "my param",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkg, err := MustNewReader(test.file, WithOverrideNetwork(test.network)).Read()
+			if test.expectErrMsg != "" {
+				assert.EqualError(t, err, test.expectErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectNetwork, pkg.Network)
+			assert.Equal(t, test.expectInitialBlock, pkg.Modules.Modules[0].InitialBlock)
+			assert.Equal(t, test.expectParams, pkg.Modules.Modules[1].Inputs[0].GetParams().Value)
+		})
+	}
+}
+
+func TestReader_PackageNetwork(t *testing.T) {
+	pkg, err := MustNewReader("testdata/networks.yaml").Read()
+	require.NoError(t, err)
+
+	cnt, err := proto.Marshal(pkg)
+	require.NoError(t, err)
+	spkgPath := filepath.Join(t.TempDir(), "networks.spkg")
+	require.NoError(t, os.WriteFile(spkgPath, cnt, 0644))
+
+	_, err = MustNewReader(spkgPath, WithOverrideNetwork("mainnet")).Read()
+	require.NoError(t, err)
+
+	_, err = MustNewReader(spkgPath, WithOverrideNetwork("polygon")).Read()
+	assert.EqualError(t, err, `package was built for network "mainnet", it cannot be read for network "polygon", pack it from its manifest with '--network polygon'`)
+}
+
+func TestReader_ImportsNetwork(t *testing.T) {
+	tests := []struct {
+		name               string
+		network            string
+		expectNetwork      string
+		expectInitialBlock uint64
+	}{
+		{
+			name:               "manifest network",
+			expectNetwork:      "polygon",
+			expectInitialBlock: 4000000,
+		},
+		{
+			name:               "overridden network",
+			network:            "mainnet",
+			expectNetwork:      "mainnet",
+			expectInitialBlock: 12369621,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkg, err := MustNewReader("testdata/networks_import.yaml", WithOverrideNetwork(test.network)).Read()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectNetwork, pkg.Network)
+			for _, mod := range pkg.Modules.Modules {
+				if mod.Name == "imp:mod1" {
+					assert.Equal(t, test.expectInitialBlock, mod.InitialBlock)
+					return
+				}
+			}
+			t.Fatal("imported module imp:mod1 not found")
+		})
+	}
+}
+
+func TestReader_ImportedPackageNetwork(t *testing.T) {
+	pkg, err := MustNewReader("testdata/networks.yaml").Read()
+	require.NoError(t, err)
+
+	cnt, err := proto.Marshal(pkg)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "networks.spkg"), cnt, 0644))
+
+	manifest := `specVersion: v0.1.0
+package:
+  name: testnetworksimport
+  version: v0.1.0
+
+network: polygon
+
+imports:
+  imp: ./networks.spkg
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "substreams.yaml"), []byte(manifest), 0644))
+
+	_, err = MustNewReader(filepath.Join(dir, "substreams.yaml")).Read()
+	assert.ErrorContains(t, err, `package was built for network "mainnet", it cannot be read for network "polygon"`)
+}
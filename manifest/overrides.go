@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// ModuleOverride replaces parts of a module of an imported package, referenced
+// as '<import>:<module>'. Inputs replace the module's inputs as a whole, and can
+// refer to the modules of the manifest as well as to imported ones.
+type ModuleOverride struct {
+	Module       string       `yaml:"module"`
+	InitialBlock *uint64      `yaml:"initialBlock"`
+	Binary       string       `yaml:"binary"`
+	Inputs       []*Input     `yaml:"inputs"`
+	Params       *ParamsValue `yaml:"params"`
+}
+
+func (o *ModuleOverride) parse() error {
+	if o.Module == "" {
+		return fmt.Errorf("missing 'module'")
+	}
+	for idx, input := range o.Inputs {
+		if err := input.parse(); err != nil {
+			return fmt.Errorf("module %q: invalid input [%d]: %w", o.Module, idx, err)
+		}
+	}
+	return nil
+}
+
+// applyOverrides applies the 'overrides' section of `m` to the imported modules of `pkg`.
+// The params of overrides are merged in the 'params' section, applied later on.
+func (r *Reader) applyOverrides(pkg *pbsubstreams.Package, m *Manifest, codeIndexes map[string]int) error {
+	overridden := map[string]bool{}
+	for _, override := range m.Overrides {
+		if overridden[override.Module] {
+			return fmt.Errorf("overrides: module %q is overridden more than once", override.Module)
+		}
+		overridden[override.Module] = true
+
+		if err := r.applyOverride(pkg, m, override, codeIndexes); err != nil {
+			return fmt.Errorf("overrides: module %q: %w", override.Module, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reader) applyOverride(pkg *pbsubstreams.Package, m *Manifest, override *ModuleOverride, codeIndexes map[string]int) error {
+	var mod *pbsubstreams.Module
+	for _, candidate := range pkg.Modules.Modules {
+		if candidate.Name == override.Module {
+			mod = candidate
+		}
+	}
+	if mod == nil {
+		return fmt.Errorf("module not found, imported modules are referenced as '<import>%s<module>'", PrefixSeparator)
+	}
+	if !strings.Contains(mod.Name, PrefixSeparator) {
+		return fmt.Errorf("module is not imported, change it in the 'modules' section instead")
+	}
+
+	if override.InitialBlock != nil {
+		mod.InitialBlock = *override.InitialBlock
+	}
+
+	if override.Binary != "" {
+		binaryDef, found := m.Binaries[override.Binary]
+		if !found {
+			return fmt.Errorf("binary %q is not defined in the 'binaries' section of the manifest", override.Binary)
+		}
+		codeIndex, err := r.binaryIndex(pkg, m, override.Binary, binaryDef, codeIndexes)
+		if err != nil {
+			return err
+		}
+		mod.BinaryIndex = codeIndex
+	}
+
+	if override.Inputs != nil {
+		var previousParams *pbsubstreams.Module_Input_Params
+		if len(mod.Inputs) != 0 {
+			previousParams = mod.Inputs[0].GetParams()
+		}
+
+		replacement := &pbsubstreams.Module{}
+		if err := (&Module{Name: mod.Name, Inputs: override.Inputs}).setInputsToProto(replacement); err != nil {
+			return fmt.Errorf("inputs: %w", err)
+		}
+		mod.Inputs = replacement.Inputs
+
+		// The value of the params set by the imported package are kept, unless their type changes.
+		if len(mod.Inputs) != 0 && previousParams != nil {
+			if params := mod.Inputs[0].GetParams(); params != nil && params.Type == previousParams.Type {
+				mod.Inputs[0].Input = &pbsubstreams.Module_Input_Params_{Params: previousParams}
+			}
+		}
+	}
+
+	if override.Params != nil {
+		if _, found := m.Params[override.Module]; found {
+			return fmt.Errorf("params are defined both in the 'params' and 'overrides' sections")
+		}
+		if m.Params == nil {
+			m.Params = map[string]ParamsValue{}
+		}
+		m.Params[override.Module] = *override.Params
+	}
+
+	return nil
+}
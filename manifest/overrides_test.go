@@ -0,0 +1,168 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestReader_Overrides(t *testing.T) {
+	pkg, err := MustNewReader("testdata/overrides.yaml").Read()
+	require.NoError(t, err)
+
+	depPkg, err := MustNewReader("testdata/overrides_dep.yaml").Read()
+	require.NoError(t, err)
+
+	mod2 := findModule(t, pkg, "dep:mod2")
+	assert.Equal(t, uint64(20), mod2.InitialBlock)
+	require.Len(t, mod2.Inputs, 2)
+	assert.Equal(t, "overridden", mod2.Inputs[0].GetParams().Value)
+	assert.Equal(t, "filtered", mod2.Inputs[1].GetMap().ModuleName)
+
+	localBinary, err := os.ReadFile("testdata/binaries/dummy02.wasm")
+	require.NoError(t, err)
+	mod3 := findModule(t, pkg, "dep:mod3")
+	assert.Equal(t, localBinary, pkg.Modules.Binaries[mod3.BinaryIndex].Content)
+	assert.Equal(t, findModule(t, pkg, "filtered").BinaryIndex, mod3.BinaryIndex)
+
+	// Overridden modules and their dependents get new hashes, the others keep theirs.
+	assert.Equal(t, moduleHash(t, depPkg, "mod1"), moduleHash(t, pkg, "dep:mod1"))
+	assert.NotEqual(t, moduleHash(t, depPkg, "mod2"), moduleHash(t, pkg, "dep:mod2"))
+	assert.NotEqual(t, moduleHash(t, depPkg, "mod3"), moduleHash(t, pkg, "dep:mod3"))
+}
+
+func TestReader_OverridesErrors(t *testing.T) {
+	depPath, err := filepath.Abs("testdata/overrides_dep.yaml")
+	require.NoError(t, err)
+	binaryPath, err := filepath.Abs("testdata/binaries/dummy02.wasm")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		overrides    string
+		expectParams string
+		expectErrMsg string
+	}{
+		{
+			name: "params are kept when inputs are replaced",
+			overrides: `
+  - module: dep:mod2
+    inputs:
+      - params: string
+      - source: sf.test.Block`,
+			expectParams: "dep",
+		},
+		{
+			name: "missing module",
+			overrides: `
+  - initialBlock: 20`,
+			expectErrMsg: "overrides [0]: missing 'module'",
+		},
+		{
+			name: "unknown module",
+			overrides: `
+  - module: mod2
+    initialBlock: 20`,
+			expectErrMsg: `overrides: module "mod2": module not found, imported modules are referenced as '<import>:<module>'`,
+		},
+		{
+			name: "local module",
+			overrides: `
+  - module: local
+    initialBlock: 20`,
+			expectErrMsg: `overrides: module "local": module is not imported, change it in the 'modules' section instead`,
+		},
+		{
+			name: "module overridden twice",
+			overrides: `
+  - module: dep:mod2
+    initialBlock: 20
+  - module: dep:mod2
+    initialBlock: 30`,
+			expectErrMsg: `overrides: module "dep:mod2" is overridden more than once`,
+		},
+		{
+			name: "unknown binary",
+			overrides: `
+  - module: dep:mod2
+    binary: other`,
+			expectErrMsg: `overrides: module "dep:mod2": binary "other" is not defined in the 'binaries' section of the manifest`,
+		},
+		{
+			name: "unknown input module",
+			overrides: `
+  - module: dep:mod2
+    inputs:
+      - map: unknown`,
+			expectErrMsg: `module validation failed: module "dep:mod2": map input named "unknown" not found`,
+		},
+		{
+			name: "params defined twice",
+			overrides: `
+  - module: dep:mod2
+    params: "other"
+params:
+  dep:mod2: "params"`,
+			expectErrMsg: `overrides: module "dep:mod2": params are defined both in the 'params' and 'overrides' sections`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifestPath := filepath.Join(t.TempDir(), "substreams.yaml")
+			require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: testoverrides
+  version: v0.1.0
+imports:
+  dep: `+depPath+`
+binaries:
+  default:
+    type: wasm/rust-v1
+    file: `+binaryPath+`
+modules:
+  - name: local
+    kind: map
+    inputs:
+      - source: sf.test.Block
+    output:
+      type: proto:test
+overrides:`+test.overrides+"\n"), 0644))
+
+			pkg, err := MustNewReader(manifestPath).Read()
+			if test.expectErrMsg != "" {
+				assert.ErrorContains(t, err, test.expectErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectParams, findModule(t, pkg, "dep:mod2").Inputs[0].GetParams().Value)
+		})
+	}
+}
+
+func findModule(t *testing.T, pkg *pbsubstreams.Package, name string) *pbsubstreams.Module {
+	t.Helper()
+
+	for _, mod := range pkg.Modules.Modules {
+		if mod.Name == name {
+			return mod
+		}
+	}
+	require.Failf(t, "module not found", "module %q", name)
+	return nil
+}
+
+func moduleHash(t *testing.T, pkg *pbsubstreams.Package, name string) ModuleHash {
+	t.Helper()
+
+	graph, err := NewModuleGraph(pkg.Modules.Modules)
+	require.NoError(t, err)
+	hash, err := NewModuleHashes().HashModule(pkg.Modules, findModule(t, pkg, name), graph)
+	require.NoError(t, err)
+	return hash
+}
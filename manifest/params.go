@@ -1,25 +1,56 @@
 package manifest
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
 	"github.com/schollz/closestmatch"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gopkg.in/yaml.v3"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
+// ParamsValue is the value of a module's params in the manifest: a string for
+// 'string' params, or any YAML value for typed params, which is converted to
+// the module's protobuf message like the sink's config (a string value being
+// parsed as a YAML or JSON document, '@<file>' reading it from a file).
+type ParamsValue struct {
+	String string
+	Value  interface{}
+
+	isScalar bool
+}
+
+func (p *ParamsValue) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.String = node.Value
+		p.isScalar = true
+	}
+	return node.Decode(&p.Value)
+}
+
+// ApplyParams sets the params of the modules of `pkg` from `module=value` definitions.
+// Typed params are set as a whole from a YAML or JSON document (or a '@<file>' holding
+// it), or one field at a time with `module.field=value`, nested fields being separated
+// by dots.
 func ApplyParams(paramsString []string, pkg *pbsubstreams.Package) error {
 	for _, param := range paramsString {
 		parts := strings.SplitN(param, "=", 2)
 		if len(parts) != 2 {
-			return fmt.Errorf(`param %q invalid, must be of the format: "module=value" or "imported:module=value"`, param)
+			return fmt.Errorf(`param %q invalid, must be of the format: "module=value", "imported:module=value" or "module.field=value"`, param)
 		}
+		moduleName, fieldPath, _ := strings.Cut(parts[0], ".")
+
 		var found bool
 		var closest []string
 		for _, mod := range pkg.Modules.Modules {
 			closest = append(closest, mod.Name)
-			if mod.Name == parts[0] {
+			if mod.Name == moduleName {
 				if len(mod.Inputs) == 0 {
 					return fmt.Errorf("param for module %q: missing 'params' module input", mod.Name)
 				}
@@ -27,14 +58,261 @@ func ApplyParams(paramsString []string, pkg *pbsubstreams.Package) error {
 				if p == nil {
 					return fmt.Errorf("param for module %q: first module input is not 'params'", mod.Name)
 				}
-				p.Value = parts[1]
+				if err := setParams(p, fieldPath, parts[1], pkg.ProtoFiles); err != nil {
+					return fmt.Errorf("param for module %q: %w", mod.Name, err)
+				}
 				found = true
 			}
 		}
 		if !found {
-			closeEnough := closestmatch.New(closest, []int{2}).Closest(parts[0])
-			return fmt.Errorf("param for module %q: module not found, did you mean %q ?", parts[0], closeEnough)
+			closeEnough := closestmatch.New(closest, []int{2}).Closest(moduleName)
+			return fmt.Errorf("param for module %q: module not found, did you mean %q ?", moduleName, closeEnough)
+		}
+	}
+	return nil
+}
+
+func setParams(p *pbsubstreams.Module_Input_Params, fieldPath string, value string, protoFiles []*descriptorpb.FileDescriptorProto) error {
+	if p.Type == "" {
+		if fieldPath != "" {
+			return fmt.Errorf("field %q cannot be set, the module's params are a string", fieldPath)
+		}
+		p.Value = value
+		return nil
+	}
+
+	msg, err := newParamsMessage(p.Type, protoFiles)
+	if err != nil {
+		return err
+	}
+
+	if fieldPath == "" {
+		if err := decodeParamsDocument(msg, value, func(in string) string { return in }); err != nil {
+			return err
+		}
+	} else {
+		if err := msg.Unmarshal(p.TypedValue); err != nil {
+			return fmt.Errorf("decoding current params: %w", err)
+		}
+		if err := setParamsField(msg, strings.Split(fieldPath, "."), value); err != nil {
+			return err
+		}
+	}
+
+	p.TypedValue, err = msg.MarshalDeterministic()
+	if err != nil {
+		return fmt.Errorf("encoding params: %w", err)
+	}
+	return nil
+}
+
+// applyManifestParams sets the params of the modules of `pkg` from the manifest's
+// 'params' section, and checks that the types of typed params are all defined in
+// the package's protobuf definitions.
+func applyManifestParams(pkg *pbsubstreams.Package, m *Manifest) error {
+	for _, mod := range pkg.Modules.Modules {
+		if len(mod.Inputs) == 0 {
+			continue
+		}
+		if p := mod.Inputs[0].GetParams(); p != nil && p.Type != "" {
+			if _, err := newParamsMessage(p.Type, pkg.ProtoFiles); err != nil {
+				return fmt.Errorf("module %q: %w", mod.Name, err)
+			}
+		}
+	}
+
+	for modName, paramValue := range m.Params {
+		var modFound bool
+		for _, mod := range pkg.Modules.Modules {
+			if mod.Name == modName {
+				if len(mod.Inputs) == 0 {
+					return fmt.Errorf("params value defined for module %q but module has no inputs defined, add 'params: string' to 'inputs' for module", modName)
+				}
+				p := mod.Inputs[0].GetParams()
+				if p == nil {
+					return fmt.Errorf("params value defined for module %q: module %q does not have 'params' as its first input type", modName, modName)
+				}
+
+				if p.Type == "" {
+					if !paramValue.isScalar {
+						return fmt.Errorf("params value defined for module %q: module's params are a string, the value must be a string too", modName)
+					}
+					p.Value = paramValue.String
+				} else {
+					msg, err := newParamsMessage(p.Type, pkg.ProtoFiles)
+					if err != nil {
+						return fmt.Errorf("params value defined for module %q: %w", modName, err)
+					}
+					if err := decodeParamsDocument(msg, paramValue.Value, m.resolvePath); err != nil {
+						return fmt.Errorf("params value defined for module %q: %w", modName, err)
+					}
+					if p.TypedValue, err = msg.MarshalDeterministic(); err != nil {
+						return fmt.Errorf("params value defined for module %q: encoding params: %w", modName, err)
+					}
+				}
+				modFound = true
+			}
+		}
+		if !modFound {
+			return fmt.Errorf("params value defined for module %q, but such module is not defined", modName)
+		}
+	}
+	return nil
+}
+
+// newParamsMessage returns an empty message of `paramsType` ('proto:<type>'), found in `protoFiles`.
+func newParamsMessage(paramsType string, protoFiles []*descriptorpb.FileDescriptorProto) (*dynamic.Message, error) {
+	messageType := strings.TrimPrefix(paramsType, "proto:")
+
+	files, err := desc.CreateFileDescriptors(protoFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file descriptor: %w", err)
+	}
+	for _, file := range files {
+		if msgDesc := file.FindMessage(messageType); msgDesc != nil {
+			return dynamic.NewMessageFactoryWithDefaults().NewDynamicMessage(msgDesc), nil
+		}
+	}
+	return nil, fmt.Errorf("params type: could not find protobuf message type %q in bundled protobuf descriptors", messageType)
+}
+
+// decodeParamsDocument sets `msg` from `value`, a YAML value which, when it's a string,
+// is itself parsed as a YAML or JSON document.
+func decodeParamsDocument(msg *dynamic.Message, value interface{}, resolvePath func(in string) string) error {
+	doc, err := convertYAMLtoJSONCompat(value, resolvePath)
+	if err != nil {
+		return fmt.Errorf("converting to json: %w", err)
+	}
+
+	if s, ok := doc.(string); ok {
+		var parsed interface{}
+		if err := yaml.Unmarshal([]byte(s), &parsed); err != nil {
+			return fmt.Errorf("parsing params document: %w", err)
+		}
+		if doc, err = convertYAMLtoJSONCompat(parsed, resolvePath); err != nil {
+			return fmt.Errorf("converting to json: %w", err)
+		}
+	}
+
+	msg.Reset()
+	if doc == nil {
+		return nil
+	}
+	if _, ok := doc.(map[string]interface{}); !ok {
+		return fmt.Errorf("params of type %q must be a mapping of its fields", msg.GetMessageDescriptor().GetFullyQualifiedName())
+	}
+
+	jsonBytes, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("error marshalling to json: %w", err)
+	}
+
+	if err := msg.UnmarshalJSON(jsonBytes); err != nil {
+		return fmt.Errorf("encoding json into protobuf message: %w", err)
+	}
+	return nil
+}
+
+// setParamsField replaces the field at `path` of `msg` with `value`, converted
+// according to the field's type: repeated fields take comma-separated values,
+// message and map fields take a YAML or JSON document.
+func setParamsField(msg *dynamic.Message, path []string, value string) error {
+	current, err := msg.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("decoding current params: %w", err)
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(current, &doc); err != nil {
+		return fmt.Errorf("decoding current params: %w", err)
+	}
+
+	obj := doc
+	msgDesc := msg.GetMessageDescriptor()
+	for i, name := range path {
+		fd := msgDesc.FindFieldByName(name)
+		if fd == nil {
+			fd = msgDesc.FindFieldByJSONName(name)
+		}
+		if fd == nil {
+			return fmt.Errorf("field %q not found in message %q", strings.Join(path[:i+1], "."), msgDesc.GetFullyQualifiedName())
 		}
+
+		if i == len(path)-1 {
+			fieldValue, err := paramsFieldValue(fd, value)
+			if err != nil {
+				return fmt.Errorf("field %q: %w", strings.Join(path, "."), err)
+			}
+			obj[fd.GetJSONName()] = fieldValue
+			break
+		}
+
+		if fd.GetMessageType() == nil || fd.IsRepeated() {
+			return fmt.Errorf("field %q is not a message, it has no fields", strings.Join(path[:i+1], "."))
+		}
+		sub, _ := obj[fd.GetJSONName()].(map[string]interface{})
+		if sub == nil {
+			sub = map[string]interface{}{}
+			obj[fd.GetJSONName()] = sub
+		}
+		obj = sub
+		msgDesc = fd.GetMessageType()
+	}
+
+	jsonBytes, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("error marshalling to json: %w", err)
+	}
+
+	msg.Reset()
+	if err := msg.UnmarshalJSON(jsonBytes); err != nil {
+		return fmt.Errorf("field %q: encoding json into protobuf message: %w", strings.Join(path, "."), err)
 	}
 	return nil
 }
+
+func paramsFieldValue(fd *desc.FieldDescriptor, value string) (interface{}, error) {
+	if fd.IsMap() {
+		return parseParamsDocument(value)
+	}
+	if fd.IsRepeated() {
+		out := []interface{}{}
+		if value == "" {
+			return out, nil
+		}
+		for _, element := range strings.Split(value, ",") {
+			v, err := paramsScalarValue(fd, element)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	return paramsScalarValue(fd, value)
+}
+
+func paramsScalarValue(fd *desc.FieldDescriptor, value string) (interface{}, error) {
+	switch fd.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return parseParamsDocument(value)
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", value)
+		}
+		return v, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		return value, nil
+	default:
+		// Numbers are validated against the field's type when decoding the JSON document.
+		return json.Number(value), nil
+	}
+}
+
+func parseParamsDocument(value string) (interface{}, error) {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, fmt.Errorf("parsing %q as a YAML or JSON document: %w", value, err)
+	}
+	return convertYAMLtoJSONCompat(parsed, func(in string) string { return in })
+}
@@ -0,0 +1,128 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestReader_TypedParams(t *testing.T) {
+	pkg, err := MustNewReader("testdata/typed-params.yaml").Read()
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"minAmount":"1000","tokens":["a","b"],"filter":{"owner":"alice"}}`, typedParamsJSON(t, pkg, "mod1"))
+	assert.JSONEq(t, `{"enabled":true,"filter":{"limit":3}}`, typedParamsJSON(t, pkg, "mod2"))
+
+	_, err = MustNewReader("testdata/typed-params-unknown-type.yaml").Read()
+	assert.ErrorContains(t, err, `could not find protobuf message type "sf.substreams.test.Params"`)
+}
+
+func TestApplyParams(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       []string
+		expectMod1   string
+		expectErrMsg string
+	}{
+		{
+			name:       "whole message as json",
+			params:     []string{`mod1={"tokens": ["c"]}`},
+			expectMod1: `{"tokens":["c"]}`,
+		},
+		{
+			name:       "whole message from file",
+			params:     []string{"mod1=@testdata/typed-params.json"},
+			expectMod1: `{"enabled":true,"filter":{"limit":3}}`,
+		},
+		{
+			name:       "scalar fields",
+			params:     []string{"mod1.min_amount=42", "mod1.enabled=true"},
+			expectMod1: `{"minAmount":"42","tokens":["a","b"],"enabled":true,"filter":{"owner":"alice"}}`,
+		},
+		{
+			name:       "repeated field is replaced",
+			params:     []string{"mod1.tokens=x,y,z"},
+			expectMod1: `{"minAmount":"1000","tokens":["x","y","z"],"filter":{"owner":"alice"}}`,
+		},
+		{
+			name:       "nested field by json name",
+			params:     []string{"mod1.filter.limit=5", "mod1.minAmount=7"},
+			expectMod1: `{"minAmount":"7","tokens":["a","b"],"filter":{"owner":"alice","limit":5}}`,
+		},
+		{
+			name:       "message field as document",
+			params:     []string{"mod1.filter={owner: bob}"},
+			expectMod1: `{"minAmount":"1000","tokens":["a","b"],"filter":{"owner":"bob"}}`,
+		},
+		{
+			name:         "unknown field",
+			params:       []string{"mod1.unknown=1"},
+			expectErrMsg: `param for module "mod1": field "unknown" not found in message "sf.substreams.test.Params"`,
+		},
+		{
+			name:         "invalid value",
+			params:       []string{"mod1.enabled=maybe"},
+			expectErrMsg: `param for module "mod1": field "enabled": invalid boolean "maybe"`,
+		},
+		{
+			name:         "invalid number",
+			params:       []string{"mod1.filter.limit=99999999999"},
+			expectErrMsg: `param for module "mod1": field "filter.limit": encoding json into protobuf message`,
+		},
+		{
+			name:         "field of a scalar",
+			params:       []string{"mod1.enabled.value=true"},
+			expectErrMsg: `param for module "mod1": field "enabled" is not a message, it has no fields`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkg, err := MustNewReader("testdata/typed-params.yaml").Read()
+			require.NoError(t, err)
+
+			err = ApplyParams(test.params, pkg)
+			if test.expectErrMsg != "" {
+				assert.ErrorContains(t, err, test.expectErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expectMod1, typedParamsJSON(t, pkg, "mod1"))
+		})
+	}
+}
+
+func TestApplyParams_String(t *testing.T) {
+	pkg, err := MustNewReader("testdata/with-params.yaml").Read()
+	require.NoError(t, err)
+
+	require.NoError(t, ApplyParams([]string{"mod2=other param"}, pkg))
+	assert.Equal(t, "other param", pkg.Modules.Modules[1].Inputs[0].GetParams().Value)
+
+	assert.EqualError(t, ApplyParams([]string{"mod2.field=value"}, pkg), `param for module "mod2": field "field" cannot be set, the module's params are a string`)
+}
+
+func typedParamsJSON(t *testing.T, pkg *pbsubstreams.Package, moduleName string) string {
+	t.Helper()
+
+	for _, mod := range pkg.Modules.Modules {
+		if mod.Name != moduleName {
+			continue
+		}
+
+		params := mod.Inputs[0].GetParams()
+		msg, err := newParamsMessage(params.Type, pkg.ProtoFiles)
+		require.NoError(t, err)
+		require.NoError(t, msg.Unmarshal(params.TypedValue))
+
+		out, err := msg.MarshalJSON()
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	require.Failf(t, "module not found", "module %q", moduleName)
+	return ""
+}
@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,6 +18,7 @@ import (
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/dynamic"
 	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/index"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"go.uber.org/zap"
 	"golang.org/x/mod/semver"
@@ -46,6 +48,24 @@ func SkipModuleOutputTypeValidationReader() Options {
 	}
 }
 
+// WithOverrideNetwork selects the network resolved from the manifest's 'networks'
+// section instead of its 'network' field. Packages must have been built for it.
+func WithOverrideNetwork(network string) Options {
+	return func(r *Reader) *Reader {
+		r.overrideNetwork = network
+		return r
+	}
+}
+
+// WithUpdateLockfile writes the 'substreams.lock' file of the manifest, pinning
+// its remote imports to the hash of their content, instead of only verifying it.
+func WithUpdateLockfile() Options {
+	return func(r *Reader) *Reader {
+		r.updateLockfile = true
+		return r
+	}
+}
+
 func WithCollectProtoDefinitions(f func(protoDefinitions []*desc.FileDescriptor)) Options {
 	return func(r *Reader) *Reader {
 		r.collectProtoDefinitionsFunc = f
@@ -65,6 +85,9 @@ type Reader struct {
 	//options
 	skipSourceCodeImportValidation bool
 	skipModuleOutputTypeValidation bool
+	overrideNetwork                string
+	updateLockfile                 bool
+	trustedKeys                    []ed25519.PublicKey
 
 	constructorErr error
 }
@@ -88,7 +111,7 @@ func MustNewReader(input string, opts ...Options) *Reader {
 }
 
 func newReader(input string, workingDir string, opts ...Options) (*Reader, error) {
-	r := &Reader{resolvedInput: input}
+	r := &Reader{resolvedInput: input, trustedKeys: TrustedKeys}
 	for _, opt := range opts {
 		r = opt(r)
 	}
@@ -155,7 +178,7 @@ func (r *Reader) Read() (*pbsubstreams.Package, error) {
 
 func (r *Reader) read(workingDir string) (*pbsubstreams.Package, error) {
 	if r.IsRemotePackage() {
-		return r.newPkgFromURL(r.resolvedInput)
+		return r.checkPackageNetwork(r.newPkgFromURL(r.resolvedInput))
 	}
 
 	input := r.resolvedInput
@@ -171,7 +194,19 @@ func (r *Reader) read(workingDir string) (*pbsubstreams.Package, error) {
 		return pkg, nil
 	}
 
-	return r.newPkgFromFile(input)
+	return r.checkPackageNetwork(r.newPkgFromFile(input))
+}
+
+// checkPackageNetwork fails if `pkg` was built for another network than the
+// overridden one, the 'networks' section of manifests not being packaged.
+func (r *Reader) checkPackageNetwork(pkg *pbsubstreams.Package, err error) (*pbsubstreams.Package, error) {
+	if err != nil {
+		return nil, err
+	}
+	if r.overrideNetwork != "" && pkg.Network != "" && pkg.Network != r.overrideNetwork {
+		return nil, fmt.Errorf("package was built for network %q, it cannot be read for network %q, pack it from its manifest with '--network %s'", pkg.Network, r.overrideNetwork, r.overrideNetwork)
+	}
+	return pkg, nil
 }
 
 // IsRemotePackage determines if reader's input to read the manifest is a remote file accessible over
@@ -205,37 +240,63 @@ func (r *Reader) newPkgFromFile(inputFilePath string) (pkg *pbsubstreams.Package
 		return nil, fmt.Errorf("error reading %q: %w", inputFilePath, err)
 	}
 
-	return r.fromContents(cnt)
+	pkg, err = r.fromContents(cnt)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.verifySignatures(pkg, inputFilePath, false); err != nil {
+		return nil, err
+	}
+	return pkg, nil
 }
 
 func (r *Reader) newPkgFromURL(fileURL string) (pkg *pbsubstreams.Package, err error) {
+	cnt, err := readRemoteContent(fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err = r.fromContents(cnt)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.verifySignatures(pkg, fileURL, true); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// readRemoteContent returns the content of the package at `fileURL`, over HTTP(S),
+// from a cloud storage or from IPFS.
+func readRemoteContent(fileURL string) ([]byte, error) {
 	u, err := url.Parse(fileURL)
 	if err != nil {
 		panic(fmt.Errorf("fileURL %q should have been valid by that execution point but it seems it was not: %w", fileURL, err))
 	}
 
 	if u.Scheme == "gs" || u.Scheme == "s3" || u.Scheme == "az" {
-		return r.newPkgFromStore(fileURL)
+		return readStoreContent(fileURL)
 	}
 
 	if u.Scheme == "ipfs" {
-		return r.newPkgFromIPFS(u.Host)
+		return readIPFSPackageContent(u.Host)
 	}
 
 	resp, err := httpClient.Get(fileURL)
 	if err != nil {
 		return nil, fmt.Errorf("error downloading %q: %w", fileURL, err)
 	}
+	defer resp.Body.Close()
 
 	cnt, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %q: %w", fileURL, err)
 	}
 
-	return r.fromContents(cnt)
+	return cnt, nil
 }
 
-func (r *Reader) newPkgFromStore(fileURL string) (pkg *pbsubstreams.Package, err error) {
+func readStoreContent(fileURL string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -244,7 +305,7 @@ func (r *Reader) newPkgFromStore(fileURL string) (pkg *pbsubstreams.Package, err
 		return nil, fmt.Errorf("error reading %q: %w", fileURL, err)
 	}
 
-	return r.fromContents(cnt)
+	return cnt, nil
 }
 
 type subgraphManifest struct {
@@ -267,7 +328,7 @@ func readIPFSContent(hash string, sh *ipfs.Shell) ([]byte, error) {
 	return ioutil.ReadAll(readCloser)
 }
 
-func (r *Reader) newPkgFromIPFS(hash string) (pkg *pbsubstreams.Package, err error) {
+func readIPFSPackageContent(hash string) ([]byte, error) {
 	sh := ipfs.NewShell(IPFSURL)
 	sh.SetTimeout(IPFSTimeout)
 
@@ -280,7 +341,7 @@ func (r *Reader) newPkgFromIPFS(hash string) (pkg *pbsubstreams.Package, err err
 	err = yaml.Unmarshal(cnt, manifest)
 	if err != nil || len(manifest.DataSources) == 0 {
 		// not a valid manifest, maybe it's the spkg itself
-		return r.fromContents(cnt)
+		return cnt, nil
 	}
 
 	if manifest.DataSources[0].Kind != "substreams" {
@@ -289,12 +350,7 @@ func (r *Reader) newPkgFromIPFS(hash string) (pkg *pbsubstreams.Package, err err
 
 	spkgHash := manifest.DataSources[0].Source.Package.File["/"]
 
-	cnt, err = readIPFSContent(spkgHash, sh)
-	if err != nil {
-		return nil, err
-	}
-
-	return r.fromContents(cnt)
+	return readIPFSContent(spkgHash, sh)
 }
 
 func (r *Reader) newPkgFromManifest(inputPath string) (pkg *pbsubstreams.Package, protoDefinitions []*desc.FileDescriptor, err error) {
@@ -385,6 +441,10 @@ func (r *Reader) validatePackage(pkg *pbsubstreams.Package) error {
 					return fmt.Errorf("module %q: invalid valueType %q", mod.Name, valueType)
 				}
 			}
+		case *pbsubstreams.Module_KindBlockIndex_:
+			if outputType := i.KindBlockIndex.OutputType; outputType != index.KeysOutputType {
+				return fmt.Errorf("module %q: invalid outputType %q for a blockIndex module, must be %q", mod.Name, outputType, index.KeysOutputType)
+			}
 		}
 
 		inputSeen := map[string]bool{}
@@ -509,6 +569,31 @@ func ValidateModules(mods *pbsubstreams.Modules) error {
 				}
 			}
 		}
+
+		if filter := mod.BlockFilter; filter != nil {
+			if mod.GetKindBlockIndex() != nil {
+				return fmt.Errorf("module %q: block filter cannot be used on a blockIndex module", mod.Name)
+			}
+			var found bool
+			for _, mod2 := range mods.Modules {
+				if mod2.Name == filter.Module {
+					found = true
+					if mod2.GetKindBlockIndex() == nil {
+						return fmt.Errorf("module %q: block filter: referenced module %q not of 'blockIndex' kind", mod.Name, filter.Module)
+					}
+				}
+			}
+			if !found {
+				return fmt.Errorf("module %q: block filter module named %q not found", mod.Name, filter.Module)
+			}
+			if _, err := index.ParseQuery(filter.Query); err != nil {
+				return fmt.Errorf("module %q: block filter: invalid query %q: %w", mod.Name, filter.Query, err)
+			}
+		}
+
+		if limits := mod.Limits; limits != nil && mod.GetKindStore() == nil && (limits.MaxStoreSize != 0 || limits.MaxStoreItemSize != 0) {
+			return fmt.Errorf("module %q: store limits cannot be used on a module of kind other than store", mod.Name)
+		}
 	}
 
 	return nil
@@ -556,6 +641,10 @@ func LoadManifestFile(inputPath string) (*Manifest, error) {
 			if err := validateStoreBuilder(s); err != nil {
 				return nil, fmt.Errorf("stream %q: %w", s.Name, err)
 			}
+		case ModuleKindBlockIndex:
+			if err := validateBlockIndex(s); err != nil {
+				return nil, fmt.Errorf("stream %q: %w", s.Name, err)
+			}
 
 		default:
 			return nil, fmt.Errorf("stream %q: invalid kind %q", s.Name, s.Kind)
@@ -565,20 +654,60 @@ func LoadManifestFile(inputPath string) (*Manifest, error) {
 				return nil, fmt.Errorf("module %q: invalid input [%d]: %w", s.Name, idx, err)
 			}
 		}
+		if s.BlockFilter != nil {
+			if err := validateBlockFilter(s.BlockFilter); err != nil {
+				return nil, fmt.Errorf("module %q: %w", s.Name, err)
+			}
+		}
+		if s.Limits != nil && s.Kind != ModuleKindStore && (s.Limits.MaxStoreSize != 0 || s.Limits.MaxStoreItemSize != 0) {
+			return nil, fmt.Errorf("module %q: 'limits.maxStoreSize' and 'limits.maxStoreItemSize' only apply to kind 'store'", s.Name)
+		}
+	}
+
+	for idx, override := range m.Overrides {
+		if err := override.parse(); err != nil {
+			return nil, fmt.Errorf("overrides [%d]: %w", idx, err)
+		}
 	}
 
 	return m, nil
 }
 
-func loadImports(pkg *pbsubstreams.Package, manif *Manifest) error {
+// loadImports merges the packages imported by `manif` into `pkg`, reading them
+// for `network`.
+func (r *Reader) loadImports(pkg *pbsubstreams.Package, manif *Manifest, network string) error {
+	lock, err := readLockfile(manif.Workdir)
+	if err != nil {
+		return err
+	}
+	updatedLock := &Lockfile{Version: LockfileVersion, Imports: map[string]*LockedImport{}}
+
 	for _, kv := range manif.Imports {
 		importName := kv[0]
 		importPath := manif.resolvePath(kv[1])
 
-		subpkgReader := MustNewReader(importPath)
-		subpkg, err := subpkgReader.Read()
-		if err != nil {
-			return fmt.Errorf("importing %q: %w", importPath, err)
+		subpkgReader := MustNewReader(importPath, WithOverrideNetwork(network), WithTrustedKeys(r.trustedKeys))
+
+		var subpkg *pbsubstreams.Package
+		if subpkgReader.IsRemotePackage() {
+			cnt, locked, err := r.readLockedImport(importName, importPath, lock.Imports[importName])
+			if err != nil {
+				return err
+			}
+			updatedLock.Imports[importName] = locked
+
+			subpkg, err = subpkgReader.checkPackageNetwork(subpkgReader.fromContents(cnt))
+			if err != nil {
+				return fmt.Errorf("importing %q: %w", importPath, err)
+			}
+			if err := r.verifySignatures(subpkg, importPath, true); err != nil {
+				return fmt.Errorf("import %q: %w", importName, err)
+			}
+		} else {
+			subpkg, err = subpkgReader.Read()
+			if err != nil {
+				return fmt.Errorf("importing %q: %w", importPath, err)
+			}
 		}
 
 		prefixModules(subpkg.Modules.Modules, importName)
@@ -587,6 +716,12 @@ func loadImports(pkg *pbsubstreams.Package, manif *Manifest) error {
 	}
 	// loop through the Manifest, and get the `imports` statements,
 	// pull the Package files from Disk, and merge them into this one
+
+	if r.updateLockfile && (len(updatedLock.Imports) != 0 || len(lock.Imports) != 0) {
+		if err := writeLockfile(manif.Workdir, updatedLock); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -607,6 +742,9 @@ func prefixModules(mods []*pbsubstreams.Module, prefix string) {
 				panic(fmt.Sprintf("module %q: input index %d: unsupported module input type %s", mod.Name, idx, inputIface.Input))
 			}
 		}
+		if mod.BlockFilter != nil {
+			mod.BlockFilter.Module = prefix + PrefixSeparator + mod.BlockFilter.Module
+		}
 	}
 }
 
@@ -657,7 +795,9 @@ func mergeProtoFiles(src, dest *pbsubstreams.Package) {
 // in some cases we do not want to validate the package and ensure that all the code and dependencies are there fro example
 // when we are using the generated package transitively
 func (r *Reader) manifestToPkg(m *Manifest) (*pbsubstreams.Package, []*desc.FileDescriptor, error) {
-	pkg, err := r.convertToPkg(m)
+	// Binaries already in the package, reused by the overrides of imported modules.
+	codeIndexes := map[string]int{}
+	pkg, err := r.convertToPkg(m, codeIndexes)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to convert manifest to pkg: %w", err)
 	}
@@ -667,18 +807,40 @@ func (r *Reader) manifestToPkg(m *Manifest) (*pbsubstreams.Package, []*desc.File
 		return nil, nil, fmt.Errorf("error loading protobuf: %w", err)
 	}
 
-	if err := loadImports(pkg, m); err != nil {
+	// Imports are read for the same network, the manifest's one unless overridden.
+	network := r.overrideNetwork
+	if network == "" {
+		network = m.Network
+	}
+
+	if err := r.loadImports(pkg, m, network); err != nil {
 		return nil, nil, fmt.Errorf("error loading imports: %w", err)
 	}
 
+	if err := r.applyOverrides(pkg, m, codeIndexes); err != nil {
+		return nil, nil, err
+	}
+
+	if err := applyNetwork(pkg, m, network); err != nil {
+		return nil, nil, err
+	}
+
+	if err := applyManifestParams(pkg, m); err != nil {
+		return nil, nil, err
+	}
+
 	if err := r.loadSinkConfig(pkg, m); err != nil {
 		return nil, nil, fmt.Errorf("error parsing sink configuration: %w", err)
 	}
 
+	if err := canonicalizePackage(pkg); err != nil {
+		return nil, nil, err
+	}
+
 	return pkg, protoDefinitions, nil
 }
 
-func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error) {
+func (r *Reader) convertToPkg(m *Manifest, codeIndexes map[string]int) (pkg *pbsubstreams.Package, err error) {
 	pkgMeta := &pbsubstreams.PackageMetadata{
 		Version: m.Package.Version,
 		Url:     m.Package.URL,
@@ -692,7 +854,6 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 		Network:     m.Network,
 	}
 
-	moduleCodeIndexes := map[string]int{}
 	for _, mod := range m.Modules {
 		pbmeta := &pbsubstreams.ModuleMetadata{
 			Doc: mod.Doc,
@@ -710,28 +871,11 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 			return nil, fmt.Errorf("module %q refers to %sbinary %q, which is not defined in the 'binaries' section of the manifest", mod.Name, implicit, binaryName)
 		}
 
-		switch binaryDef.Type {
-		case "wasm/rust-v1":
-			// OPTIM(abourget): also check if it's not already in
-			// `Binaries`, by comparing its, length + hash or value.
-			codeIndex, found := moduleCodeIndexes[binaryDef.File]
-			if !found {
-				codePath := m.resolvePath(binaryDef.File)
-				var byteCode []byte
-				if !r.skipSourceCodeImportValidation {
-					byteCode, err = os.ReadFile(codePath)
-					if err != nil {
-						return nil, fmt.Errorf("failed to read source code %q: %w", codePath, err)
-					}
-				}
-				pkg.Modules.Binaries = append(pkg.Modules.Binaries, &pbsubstreams.Binary{Type: binaryDef.Type, Content: byteCode})
-				codeIndex = len(pkg.Modules.Binaries) - 1
-				moduleCodeIndexes[binaryDef.File] = codeIndex
-			}
-			pbmod, err = mod.ToProtoWASM(uint32(codeIndex))
-		default:
-			return nil, fmt.Errorf("module %q: invalid code type %q", mod.Name, binaryDef.Type)
+		codeIndex, err := r.binaryIndex(pkg, m, binaryName, binaryDef, codeIndexes)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", mod.Name, err)
 		}
+		pbmod, err = mod.ToProtoWASM(codeIndex)
 		if err != nil {
 			return nil, err
 		}
@@ -740,27 +884,53 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 		pkg.Modules.Modules = append(pkg.Modules.Modules, pbmod)
 	}
 
-	for modName, paramValue := range m.Params {
-		var modFound bool
-		for _, mod := range pkg.Modules.Modules {
-			if mod.Name == modName {
-				if len(mod.Inputs) == 0 {
-					return nil, fmt.Errorf("params value defined for module %q but module has no inputs defined, add 'params: string' to 'inputs' for module", modName)
-				}
-				p := mod.Inputs[0].GetParams()
-				if p == nil {
-					return nil, fmt.Errorf("params value defined for module %q: module %q does not have 'params' as its first input type", modName, modName)
+	return
+}
+
+// binaryIndex returns the index of the binary `binaryName` in `pkg`, adding it
+// unless `codeIndexes`, tracking the binaries already added, has it.
+func (r *Reader) binaryIndex(pkg *pbsubstreams.Package, m *Manifest, binaryName string, binaryDef Binary, codeIndexes map[string]int) (uint32, error) {
+	switch binaryDef.Type {
+	case "wasm/rust-v1":
+		// OPTIM(abourget): also check if it's not already in
+		// `Binaries`, by comparing its, length + hash or value.
+		codeIndex, found := codeIndexes[binaryDef.File]
+		if !found {
+			codePath := m.resolvePath(binaryDef.File)
+			var byteCode []byte
+			if !r.skipSourceCodeImportValidation {
+				var err error
+				byteCode, err = os.ReadFile(codePath)
+				if err != nil {
+					return 0, fmt.Errorf("failed to read source code %q: %w", codePath, err)
 				}
-				p.Value = paramValue
-				modFound = true
 			}
+			pkg.Modules.Binaries = append(pkg.Modules.Binaries, &pbsubstreams.Binary{Type: binaryDef.Type, Content: byteCode})
+			codeIndex = len(pkg.Modules.Binaries) - 1
+			codeIndexes[binaryDef.File] = codeIndex
 		}
-		if !modFound {
-			return nil, fmt.Errorf("params value defined for module %q, but such module is not defined", modName)
+		return uint32(codeIndex), nil
+	case "native/go":
+		// The code of native modules is compiled in the server, the binary
+		// only carries the name and version under which it was registered,
+		// the version changing the module hashes when the code changes.
+		if binaryDef.Native == "" {
+			return 0, fmt.Errorf("binary %q of type %q requires a 'native' field", binaryName, binaryDef.Type)
 		}
+		if name, version, _ := strings.Cut(binaryDef.Native, "@"); name == "" || version == "" {
+			return 0, fmt.Errorf("binary %q of type %q: 'native' field %q must be '<name>@<version>'", binaryName, binaryDef.Type, binaryDef.Native)
+		}
+		codeKey := "native:" + binaryDef.Native
+		codeIndex, found := codeIndexes[codeKey]
+		if !found {
+			pkg.Modules.Binaries = append(pkg.Modules.Binaries, &pbsubstreams.Binary{Type: binaryDef.Type, Content: []byte(binaryDef.Native)})
+			codeIndex = len(pkg.Modules.Binaries) - 1
+			codeIndexes[codeKey] = codeIndex
+		}
+		return uint32(codeIndex), nil
+	default:
+		return 0, fmt.Errorf("invalid code type %q", binaryDef.Type)
 	}
-
-	return
 }
 
 var storeValidTypes = map[string]bool{
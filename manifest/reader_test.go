@@ -147,6 +147,47 @@ func TestReader_Read(t *testing.T) {
 			require.NoError,
 			require.NoError,
 		},
+		{
+			"binaries_native.yaml",
+			args{validateBinary: true},
+			&pbsubstreams.Package{
+				Version:    1,
+				ProtoFiles: readSystemProtoDescriptors(t),
+				PackageMeta: []*pbsubstreams.PackageMetadata{
+					{
+						Name:    "test",
+						Version: "v0.0.0",
+					},
+				},
+				ModuleMeta: []*pbsubstreams.ModuleMetadata{
+					{},
+					{},
+				},
+				Modules: &pbsubstreams.Modules{
+					Binaries: []*pbsubstreams.Binary{{Type: "native/go", Content: []byte("test_native@v1")}},
+					Modules: []*pbsubstreams.Module{
+						newTestModuleModel("test_mapper", UNSET, "sf.test.Block", "proto:sf.test.Output"),
+						newTestModuleModel("test_other_mapper", UNSET, "sf.test.Block", "proto:sf.test.Output"),
+					},
+				},
+			},
+			require.NoError,
+			require.NoError,
+		},
+		{
+			"binaries_native_missing_name.yaml",
+			args{},
+			nil,
+			require.NoError,
+			require.Error,
+		},
+		{
+			"binaries_native_missing_version.yaml",
+			args{},
+			nil,
+			require.NoError,
+			require.Error,
+		},
 		{
 			"imports_http_url.yaml",
 			args{
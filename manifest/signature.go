@@ -66,6 +66,8 @@ func (m *ModuleHashes) HashModule(modules *pbsubstreams.Modules, module *pbsubst
 		buf.WriteString("map")
 	case *pbsubstreams.Module_KindStore_:
 		buf.WriteString("store")
+	case *pbsubstreams.Module_KindBlockIndex_:
+		buf.WriteString("block_index")
 	default:
 		return nil, fmt.Errorf("invalid module file %T", module.Kind)
 	}
@@ -89,6 +91,13 @@ func (m *ModuleHashes) HashModule(modules *pbsubstreams.Modules, module *pbsubst
 		buf.WriteString(value)
 	}
 
+	// Only written when set, so hashes of modules without a filter are unchanged. The
+	// filter's index module is accounted for in the `AncestorOf()` tree.
+	if filter := module.BlockFilter; filter != nil {
+		buf.WriteString("block_filter")
+		buf.WriteString(filter.Query)
+	}
+
 	buf.WriteString("ancestors")
 	ancestors, _ := graph.AncestorsOf(module.Name)
 	for _, ancestor := range ancestors {
@@ -132,6 +141,9 @@ func inputValue(input *pbsubstreams.Module_Input) (string, error) {
 	case *pbsubstreams.Module_Input_Source_:
 		return input.GetSource().Type, nil
 	case *pbsubstreams.Module_Input_Params_:
+		if params := input.GetParams(); params.Type != "" {
+			return params.Type + "=" + string(params.TypedValue), nil
+		}
 		return input.GetParams().Value, nil
 	case *pbsubstreams.Module_Input_Store_:
 		return "", nil // this is accounted for in the `AncestorOf()` tree
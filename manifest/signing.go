@@ -0,0 +1,217 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+const packageSignaturePrefix = "sf.substreams.v1.Package:"
+
+// TrustedKeys are the public keys packages are verified against by readers not
+// configured with WithTrustedKeys. When empty, signatures are not verified.
+var TrustedKeys []ed25519.PublicKey
+
+// WithTrustedKeys makes the reader verify the signatures of the packages it reads
+// against `keys`: remote packages, imports included, must be signed by one of them,
+// and local packages must be when they are signed.
+func WithTrustedKeys(keys []ed25519.PublicKey) Options {
+	return func(r *Reader) *Reader {
+		r.trustedKeys = keys
+		return r
+	}
+}
+
+// SignPackage signs `pkg` with `key`, replacing a previous signature with the same key.
+func SignPackage(pkg *pbsubstreams.Package, key ed25519.PrivateKey) error {
+	metadataDigest, err := PackageMetadataDigest(pkg)
+	if err != nil {
+		return err
+	}
+	message, err := signedMessage(pkg.Modules, metadataDigest)
+	if err != nil {
+		return err
+	}
+
+	publicKey := key.Public().(ed25519.PublicKey)
+	signature := &pbsubstreams.PackageSignature{
+		PublicKey: publicKey,
+		Signature: ed25519.Sign(key, message),
+	}
+
+	for i, existing := range pkg.Signatures {
+		if bytes.Equal(existing.PublicKey, publicKey) {
+			pkg.Signatures[i] = signature
+			return nil
+		}
+	}
+	pkg.Signatures = append(pkg.Signatures, signature)
+	return nil
+}
+
+// VerifyPackage fails unless `pkg` carries a valid signature by one of `trustedKeys`.
+func VerifyPackage(pkg *pbsubstreams.Package, trustedKeys []ed25519.PublicKey) error {
+	metadataDigest, err := PackageMetadataDigest(pkg)
+	if err != nil {
+		return err
+	}
+	return VerifyModules(pkg.Modules, metadataDigest, pkg.Signatures, trustedKeys)
+}
+
+// VerifyModules fails unless `signatures` holds a valid signature by one of
+// `trustedKeys` of the package made of `modules` and of the rest of the package
+// digested by `metadataDigest`, which is how servers receiving only the modules
+// verify packages.
+func VerifyModules(modules *pbsubstreams.Modules, metadataDigest []byte, signatures []*pbsubstreams.PackageSignature, trustedKeys []ed25519.PublicKey) error {
+	if len(signatures) == 0 {
+		return fmt.Errorf("package is not signed")
+	}
+
+	message, err := signedMessage(modules, metadataDigest)
+	if err != nil {
+		return err
+	}
+
+	var invalidKey []byte
+	for _, signature := range signatures {
+		if !isTrustedKey(signature.PublicKey, trustedKeys) {
+			continue
+		}
+		if ed25519.Verify(signature.PublicKey, message, signature.Signature) {
+			return nil
+		}
+		invalidKey = signature.PublicKey
+	}
+	if invalidKey != nil {
+		return fmt.Errorf("invalid signature by key %x, the package was modified after being signed", invalidKey)
+	}
+	return fmt.Errorf("package is not signed by a trusted key")
+}
+
+func isTrustedKey(key []byte, trustedKeys []ed25519.PublicKey) bool {
+	for _, trustedKey := range trustedKeys {
+		if trustedKey.Equal(ed25519.PublicKey(key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageMetadataDigest returns the SHA-256 digest of `pkg` without its modules and
+// signatures, which servers need along the modules to verify the signatures.
+func PackageMetadataDigest(pkg *pbsubstreams.Package) ([]byte, error) {
+	metadata := proto.Clone(pkg).(*pbsubstreams.Package)
+	metadata.Modules = nil
+	metadata.Signatures = nil
+
+	cnt, err := MarshalPackage(metadata)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(cnt)
+	return digest[:], nil
+}
+
+// signedMessage returns the message signed for the package made of `modules` and of
+// the rest of the package digested by `metadataDigest`. Params values are not signed,
+// being set at run time.
+func signedMessage(modules *pbsubstreams.Modules, metadataDigest []byte) ([]byte, error) {
+	if len(metadataDigest) != sha256.Size {
+		return nil, fmt.Errorf("invalid package metadata digest of %d bytes", len(metadataDigest))
+	}
+
+	unparameterized := &pbsubstreams.Modules{}
+	if modules != nil {
+		unparameterized = proto.Clone(modules).(*pbsubstreams.Modules)
+	}
+	for _, mod := range unparameterized.Modules {
+		for _, input := range mod.Inputs {
+			if params := input.GetParams(); params != nil {
+				params.Value = ""
+			}
+		}
+	}
+
+	cnt, err := proto.MarshalOptions{Deterministic: true}.Marshal(unparameterized)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling modules: %w", err)
+	}
+	modulesDigest := sha256.Sum256(cnt)
+
+	message := append([]byte(packageSignaturePrefix), modulesDigest[:]...)
+	return append(message, metadataDigest...), nil
+}
+
+// verifySignatures verifies the signatures of `pkg` read from `input` against the
+// trusted keys of the reader, if any. Unsigned local packages are accepted.
+func (r *Reader) verifySignatures(pkg *pbsubstreams.Package, input string, remote bool) error {
+	if len(r.trustedKeys) == 0 || (!remote && len(pkg.Signatures) == 0) {
+		return nil
+	}
+	if err := VerifyPackage(pkg, r.trustedKeys); err != nil {
+		return fmt.Errorf("verifying signatures of %q: %w", input, err)
+	}
+	return nil
+}
+
+// ParsePublicKey decodes the hex-encoded ed25519 public key `in`.
+func ParsePublicKey(in string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(in))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key %q, expecting %d hex-encoded bytes", in, ed25519.PublicKeySize)
+	}
+	return key, nil
+}
+
+// ReadTrustedKeysFile reads the hex-encoded public keys of `path`, one per line.
+// Empty lines and the ones starting with '#' are ignored.
+func ReadTrustedKeysFile(path string) ([]ed25519.PublicKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening trusted keys file: %w", err)
+	}
+	defer f.Close()
+
+	var keys []ed25519.PublicKey
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, err := ParsePublicKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		keys = append(keys, key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading trusted keys file: %w", err)
+	}
+	return keys, nil
+}
+
+// ReadSigningKeyFile reads the hex-encoded ed25519 private key of `path`, its 32 bytes
+// seed as written by `substreams sign --generate-key`.
+func ReadSigningKeyFile(path string) (ed25519.PrivateKey, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+
+	seed, err := hex.DecodeString(strings.TrimSpace(string(cnt)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key in %q, expecting %d hex-encoded bytes", path, ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
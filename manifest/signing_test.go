@@ -0,0 +1,160 @@
+package manifest
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestSignPackage(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	publicKey := key.Public().(ed25519.PublicKey)
+	otherKey := ed25519.NewKeyFromSeed(append(make([]byte, ed25519.SeedSize-1), 1))
+	otherPublicKey := otherKey.Public().(ed25519.PublicKey)
+
+	signed := func(t *testing.T) *pbsubstreams.Package {
+		pkg := MustNewReader("testdata/with-params.yaml").MustRead()
+		require.NoError(t, SignPackage(pkg, key))
+		return pkg
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		pkg := signed(t)
+		require.Len(t, pkg.Signatures, 1)
+		assert.NoError(t, VerifyPackage(pkg, []ed25519.PublicKey{otherPublicKey, publicKey}))
+
+		require.NoError(t, SignPackage(pkg, key))
+		require.NoError(t, SignPackage(pkg, otherKey))
+		assert.Len(t, pkg.Signatures, 2)
+		assert.NoError(t, VerifyPackage(pkg, []ed25519.PublicKey{otherPublicKey}))
+	})
+
+	t.Run("params set at run time", func(t *testing.T) {
+		pkg := signed(t)
+		require.NoError(t, ApplyParams([]string{"mod2=changed"}, pkg))
+		assert.NoError(t, VerifyPackage(pkg, []ed25519.PublicKey{publicKey}))
+	})
+
+	t.Run("modified modules", func(t *testing.T) {
+		pkg := signed(t)
+		pkg.Modules.Modules[0].InitialBlock++
+		assert.EqualError(t, VerifyPackage(pkg, []ed25519.PublicKey{publicKey}), fmt.Sprintf("invalid signature by key %x, the package was modified after being signed", publicKey))
+	})
+
+	t.Run("modified metadata", func(t *testing.T) {
+		pkg := signed(t)
+		pkg.PackageMeta[0].Version = "v9.9.9"
+		assert.Error(t, VerifyPackage(pkg, []ed25519.PublicKey{publicKey}))
+	})
+
+	t.Run("untrusted", func(t *testing.T) {
+		pkg := signed(t)
+		assert.EqualError(t, VerifyPackage(pkg, []ed25519.PublicKey{otherPublicKey}), "package is not signed by a trusted key")
+
+		pkg.Signatures = nil
+		assert.EqualError(t, VerifyPackage(pkg, []ed25519.PublicKey{publicKey}), "package is not signed")
+	})
+
+	t.Run("modules and metadata digest", func(t *testing.T) {
+		pkg := signed(t)
+		metadataDigest, err := PackageMetadataDigest(pkg)
+		require.NoError(t, err)
+		assert.NoError(t, VerifyModules(pkg.Modules, metadataDigest, pkg.Signatures, []ed25519.PublicKey{publicKey}))
+		assert.EqualError(t, VerifyModules(pkg.Modules, nil, pkg.Signatures, []ed25519.PublicKey{publicKey}), "invalid package metadata digest of 0 bytes")
+	})
+}
+
+func TestReader_TrustedKeys(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	trustedKeys := []ed25519.PublicKey{key.Public().(ed25519.PublicKey)}
+
+	unsigned := MustNewReader("testdata/dep1.yaml").MustRead()
+	unsignedContent, err := MarshalPackage(unsigned)
+	require.NoError(t, err)
+
+	signed := proto.Clone(unsigned).(*pbsubstreams.Package)
+	require.NoError(t, SignPackage(signed, key))
+	signedContent, err := MarshalPackage(signed)
+	require.NoError(t, err)
+
+	tampered := proto.Clone(signed).(*pbsubstreams.Package)
+	tampered.Network = "other"
+	tamperedContent, err := MarshalPackage(tampered)
+	require.NoError(t, err)
+
+	remoteServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signed.spkg":
+			w.Write(signedContent)
+		case "/tampered.spkg":
+			w.Write(tamperedContent)
+		default:
+			w.Write(unsignedContent)
+		}
+	}))
+	defer remoteServer.Close()
+
+	_, err = MustNewReader(remoteServer.URL + "/unsigned.spkg").Read()
+	assert.NoError(t, err)
+	_, err = MustNewReader(remoteServer.URL+"/signed.spkg", WithTrustedKeys(trustedKeys)).Read()
+	assert.NoError(t, err)
+	_, err = MustNewReader(remoteServer.URL+"/unsigned.spkg", WithTrustedKeys(trustedKeys)).Read()
+	assert.EqualError(t, err, `verifying signatures of "`+remoteServer.URL+`/unsigned.spkg": package is not signed`)
+	_, err = MustNewReader(remoteServer.URL+"/tampered.spkg", WithTrustedKeys(trustedKeys)).Read()
+	assert.ErrorContains(t, err, "the package was modified after being signed")
+
+	dir := t.TempDir()
+	unsignedPath := filepath.Join(dir, "unsigned.spkg")
+	require.NoError(t, os.WriteFile(unsignedPath, unsignedContent, 0644))
+	tamperedPath := filepath.Join(dir, "tampered.spkg")
+	require.NoError(t, os.WriteFile(tamperedPath, tamperedContent, 0644))
+
+	// Local packages are only verified when signed
+	_, err = MustNewReader(unsignedPath, WithTrustedKeys(trustedKeys)).Read()
+	assert.NoError(t, err)
+	_, err = MustNewReader(tamperedPath, WithTrustedKeys(trustedKeys)).Read()
+	assert.ErrorContains(t, err, "the package was modified after being signed")
+
+	// Remote imports must be signed
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+imports:
+  signed: "`+remoteServer.URL+`/signed.spkg"
+  unsigned: "`+remoteServer.URL+`/unsigned.spkg"
+`), 0644))
+	_, err = MustNewReader(manifestPath).Read()
+	assert.NoError(t, err)
+	_, err = MustNewReader(manifestPath, WithTrustedKeys(trustedKeys)).Read()
+	assert.EqualError(t, err, `error loading imports: import "unsigned": verifying signatures of "`+remoteServer.URL+`/unsigned.spkg": package is not signed`)
+}
+
+func TestReadTrustedKeysFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trusted-keys")
+	require.NoError(t, os.WriteFile(path, []byte(`# Release keys
+3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29
+
+  8a88e3dd7409f195fd52db2d3cba5d72ca6709bf1d94121bf3748801b40f6f5c
+`), 0644))
+
+	keys, err := ReadTrustedKeysFile(path)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public(), keys[0])
+
+	require.NoError(t, os.WriteFile(path, []byte("# Release keys\n3b6a27bc\n"), 0644))
+	_, err = ReadTrustedKeysFile(path)
+	assert.EqualError(t, err, path+`:2: invalid public key "3b6a27bc", expecting 32 hex-encoded bytes`)
+}
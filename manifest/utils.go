@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"gopkg.in/yaml.v3"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
 // mapSlice represents a map in the form of a list of key/value pairs (key/value
@@ -27,3 +29,24 @@ func (s *mapSlice) UnmarshalYAML(n *yaml.Node) error {
 
 	return nil
 }
+
+// PackageBlockType returns the type of the blocks consumed by the modules of `pkg`.
+func PackageBlockType(pkg *pbsubstreams.Package) (string, error) {
+	blockType := ""
+	for _, mod := range pkg.Modules.Modules {
+		for _, input := range mod.Inputs {
+			src := input.GetSource()
+			if src == nil || src.Type == "sf.substreams.v1.Clock" {
+				continue
+			}
+			if blockType != "" && blockType != src.Type {
+				return "", fmt.Errorf("modules consume different block types %q and %q", blockType, src.Type)
+			}
+			blockType = src.Type
+		}
+	}
+	if blockType == "" {
+		return "", fmt.Errorf("no module consumes blocks")
+	}
+	return blockType, nil
+}
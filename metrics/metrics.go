@@ -12,3 +12,8 @@ var BlockEndProcess = MetricSet.NewCounter("substreams_block_process_end_counter
 var SquashesLaunched = MetricSet.NewCounter("substreams_total_squashes_launched", "Counter for Total squashes launched, used for rate")
 var SquashersStarted = MetricSet.NewCounter("substreams_total_squash_processes_launched", "Counter for Total squash processes launched, used for rate")
 var SquashersEnded = MetricSet.NewCounter("substreams_total_squash_processes_closed", "Counter for Total squash processes closed, used for active processes")
+
+var WASMCompilationCacheHits = MetricSet.NewCounter("substreams_wasm_compilation_cache_hits", "Counter for WASM modules found compiled in the compilation cache, used for hit ratio")
+var WASMCompilationCacheMisses = MetricSet.NewCounter("substreams_wasm_compilation_cache_misses", "Counter for WASM modules compiled because not found in the compilation cache, used for hit ratio")
+var WASMCompilationCacheEvictions = MetricSet.NewCounter("substreams_wasm_compilation_cache_evictions", "Counter for compiled WASM modules evicted from the compilation cache")
+var WASMCompilationCacheSize = MetricSet.NewGauge("substreams_wasm_compilation_cache_size_bytes", "Size of the WASM code of the modules in the compilation cache")
@@ -176,6 +176,9 @@ func (s *MultiSquasher) getFinalStores() (out store.Map, err error) {
 		}
 	}
 	if len(errs) != 0 {
+		for _, squashedStore := range out.All() {
+			squashedStore.Close()
+		}
 		return nil, fmt.Errorf("%d errors: %s", len(errs), strings.Join(errs, "; "))
 	}
 	return out, nil
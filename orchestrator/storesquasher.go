@@ -272,6 +272,7 @@ func (s *StoreSquasher) processSquashableFile(ctx context.Context, eg *llerrgrou
 	)
 
 	nextStore := s.store.DerivePartialStore(squashableFile.Range.StartBlock)
+	defer nextStore.Close()
 
 	loadTime := time.Now()
 	if err := nextStore.Load(ctx, squashableFile); err != nil {
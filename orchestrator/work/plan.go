@@ -57,45 +57,45 @@ func BuildNewPlan(ctx context.Context, modulesStateMap storage.ModuleStorageStat
 	return plan, nil
 }
 
+// splitWorkIntoJobs creates the jobs for every schedulable module. Stores
+// are planned from their missing partials, while the output mapper (only
+// present in production mode) is planned from its missing execout segments,
+// so historical ranges of map-only pipelines are also processed in parallel
+// on tier2 and streamed back from the cache by the `execout.LinearReader`.
 func (p *Plan) splitWorkIntoJobs(subrequestSplitSize uint64, outputModuleName string, ancestorsFrom func(string) []string) error {
 
 	stepSize := calculateHighestDependencyDepth(p.schedulableModules, p.ModulesStateMap, ancestorsFrom)
 	highestJobOrdinal := int(p.upToBlock/subrequestSplitSize) * stepSize
 
-	for _, storeName := range p.schedulableModules {
-		modState := p.ModulesStateMap[storeName]
+	for _, modName := range p.schedulableModules {
+		modState := p.ModulesStateMap[modName]
 		if modState == nil {
 			continue
 		}
 		requests := modState.BatchRequests(subrequestSplitSize)
 		for _, requestRange := range requests {
-			requiredModules := ancestorsFrom(storeName)
-			dependencyDepth := ancestorsDepth(storeName, ancestorsFrom)
+			requiredModules := ancestorsFrom(modName)
+			dependencyDepth := ancestorsDepth(modName, ancestorsFrom)
 
 			jobOrdinal := int(requestRange.StartBlock/subrequestSplitSize) * stepSize
 			priority := highestJobOrdinal - jobOrdinal - (dependencyDepth - 1)
-			if storeName == outputModuleName {
+			if modName == outputModuleName {
 				priority += stepSize // always run our outputModule 1 step ahead of its dependencies, it only needs the previous stores to be completed and should start ahead
 			}
 
 			p.logger.Debug("adding job",
-				zap.String("module", storeName),
+				zap.String("module", modName),
 				zap.Uint64("start_block", requestRange.StartBlock),
 				zap.Uint64("end_block", requestRange.ExclusiveEndBlock),
 				zap.Int("dependencyDepth", dependencyDepth),
 				zap.Int("priority", priority),
 			)
 
-			job := NewJob(storeName, requestRange, requiredModules, priority)
+			job := NewJob(modName, requestRange, requiredModules, priority)
 			p.waitingJobs = append(p.waitingJobs, job)
 		}
 	}
 
-	// Loop through `mappers` and schedule them, separately from the stores
-	// ModulesStateMap would be concerned ONLY with Stores
-	// and we add a MapperStateMap, concerned only with Mappers
-	// with the appropriate ranges in there, and not the
-	// store-specific `PartialsMissing`, `PartialsPresent`, etc..
 	return nil
 }
 
@@ -61,6 +61,41 @@ func TestWorkPlanning(t *testing.T) {
 				TestJob("As", "50-60", 3),
 			},
 		},
+		{
+			name:        "production map only",
+			upToBlock:   30,
+			subreqSplit: 10,
+			state: TestModStateMap(
+				TestMapState("Am", "0-10,10-20,20-30"),
+			),
+			productionMode: true,
+			outMod:         "Am",
+			expectReadyJobs: []*Job{
+				TestJob("Am", "0-10", 4),
+				TestJob("Am", "10-20", 3),
+				TestJob("Am", "20-30", 2),
+			},
+		},
+		{
+			name:        "production map with store dependency",
+			upToBlock:   30,
+			subreqSplit: 10,
+			state: TestModStateMap(
+				TestStoreState("As", "0-10,10-20"),
+				TestMapState("C", "0-10,10-20,20-30"),
+			),
+			productionMode: true,
+			outMod:         "C",
+			expectWaitingJobs: []*Job{
+				TestJobDeps("C", "10-20", 5, "As"),
+				TestJobDeps("C", "20-30", 3, "As"),
+			},
+			expectReadyJobs: []*Job{
+				TestJobDeps("C", "0-10", 7, "As"),
+				TestJob("As", "0-10", 6),
+				TestJob("As", "10-20", 4),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -178,10 +178,7 @@ func (w *RemoteWorker) Work(ctx context.Context, request *pbssinternal.ProcessRa
 				//respFunc(toRPCProcessedBytes(resp.ModuleName, bm.BytesReadDelta(), bm.BytesWrittenDelta(), bm.BytesRead(), bm.BytesWritten(), 0))
 
 			case *pbssinternal.ProcessRangeResponse_Failed:
-				// FIXME(abourget): we do NOT emit those Failed objects anymore. There was a flow
-				// for that that would pick up the errors, and pack the remaining logs
-				// and reasons into a message. This is nowhere to be found now.
-				forwardResponse := toRPCFailedProgressResponse(resp.ModuleName, r.Failed.Reason, r.Failed.Logs, r.Failed.LogsTruncated)
+				forwardResponse := toRPCFailedProgressResponse(resp.ModuleName, r.Failed)
 				respFunc(forwardResponse)
 				err := fmt.Errorf("module %s failed on host: %s", resp.ModuleName, r.Failed.Reason)
 				span.SetStatus(codes.Error, err.Error())
@@ -219,7 +216,61 @@ func (w *RemoteWorker) Work(ctx context.Context, request *pbssinternal.ProcessRa
 		}
 	}
 }
-func toRPCFailedProgressResponse(moduleName, reason string, logs []string, logsTruncated bool) *pbsubstreamsrpc.Response {
+
+// LocalWorker runs jobs in-process through `processRange`, usually a tier2
+// service's, instead of sending them to a remote tier2 endpoint.
+type LocalWorker struct {
+	processRange func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) error
+	logger       *zap.Logger
+	id           uint64
+}
+
+func NewLocalWorker(processRange func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) error, logger *zap.Logger) *LocalWorker {
+	return &LocalWorker{
+		processRange: processRange,
+		logger:       logger,
+		id:           atomic.AddUint64(&lastWorkerID, 1),
+	}
+}
+
+func (w *LocalWorker) ID() string {
+	return fmt.Sprintf("%d", w.id)
+}
+
+func (w *LocalWorker) Work(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *Result {
+	w.logger.Info("launching local worker",
+		zap.Int64("start_block_num", int64(request.StartBlockNum)),
+		zap.Uint64("stop_block_num", request.StopBlockNum),
+		zap.String("output_module", request.OutputModule),
+	)
+
+	result := &Result{}
+	err := w.processRange(ctx, request, func(respAny substreams.ResponseFromAnyTier) error {
+		resp := respAny.(*pbssinternal.ProcessRangeResponse)
+		switch r := resp.Type.(type) {
+		case *pbssinternal.ProcessRangeResponse_ProcessedRange:
+			return respFunc(toRPCRangeProgressResponse(resp.ModuleName, r.ProcessedRange.StartBlock, r.ProcessedRange.EndBlock))
+		case *pbssinternal.ProcessRangeResponse_Completed:
+			result.PartialFilesWritten = toRPCPartialFiles(r.Completed)
+		}
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return &Result{
+				Error: ctx.Err(),
+			}
+		}
+		return &Result{
+			Error: fmt.Errorf("processing range locally: %w", err),
+		}
+	}
+
+	w.logger.Info("worker done")
+	return result
+}
+
+func toRPCFailedProgressResponse(moduleName string, failed *pbssinternal.Failed) *pbsubstreamsrpc.Response {
 	return &pbsubstreamsrpc.Response{
 		Message: &pbsubstreamsrpc.Response_Progress{
 			Progress: &pbsubstreamsrpc.ModulesProgress{
@@ -228,9 +279,10 @@ func toRPCFailedProgressResponse(moduleName, reason string, logs []string, logsT
 						Name: moduleName,
 						Type: &pbsubstreamsrpc.ModuleProgress_Failed_{
 							Failed: &pbsubstreamsrpc.ModuleProgress_Failed{
-								Reason:        reason,
-								Logs:          logs,
-								LogsTruncated: logsTruncated,
+								Reason:        failed.Reason,
+								Logs:          failed.Logs,
+								LogsTruncated: failed.LogsTruncated,
+								StackTrace:    failed.StackTrace,
 							},
 						},
 					},
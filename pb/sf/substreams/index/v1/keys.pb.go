@@ -0,0 +1,148 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.0
+// 	protoc        (unknown)
+// source: sf/substreams/index/v1/keys.proto
+
+package pbindex
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Keys is the output of a `block_index` module for a given block.
+type Keys struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *Keys) Reset() {
+	*x = Keys{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_substreams_index_v1_keys_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Keys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Keys) ProtoMessage() {}
+
+func (x *Keys) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_substreams_index_v1_keys_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Keys.ProtoReflect.Descriptor instead.
+func (*Keys) Descriptor() ([]byte, []int) {
+	return file_sf_substreams_index_v1_keys_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Keys) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+var File_sf_substreams_index_v1_keys_proto protoreflect.FileDescriptor
+
+var file_sf_substreams_index_v1_keys_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x73, 0x66, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x2f, 0x76, 0x31, 0x2f, 0x6b, 0x65, 0x79, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x16, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x73, 0x2e, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x76, 0x31, 0x22, 0x1a, 0x0a, 0x04, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66,
+	0x61, 0x73, 0x74, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f, 0x70,
+	0x62, 0x2f, 0x73, 0x66, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sf_substreams_index_v1_keys_proto_rawDescOnce sync.Once
+	file_sf_substreams_index_v1_keys_proto_rawDescData = file_sf_substreams_index_v1_keys_proto_rawDesc
+)
+
+func file_sf_substreams_index_v1_keys_proto_rawDescGZIP() []byte {
+	file_sf_substreams_index_v1_keys_proto_rawDescOnce.Do(func() {
+		file_sf_substreams_index_v1_keys_proto_rawDescData = protoimpl.X.CompressGZIP(file_sf_substreams_index_v1_keys_proto_rawDescData)
+	})
+	return file_sf_substreams_index_v1_keys_proto_rawDescData
+}
+
+var file_sf_substreams_index_v1_keys_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_sf_substreams_index_v1_keys_proto_goTypes = []interface{}{
+	(*Keys)(nil), // 0: sf.substreams.index.v1.Keys
+}
+var file_sf_substreams_index_v1_keys_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_sf_substreams_index_v1_keys_proto_init() }
+func file_sf_substreams_index_v1_keys_proto_init() {
+	if File_sf_substreams_index_v1_keys_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_sf_substreams_index_v1_keys_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Keys); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sf_substreams_index_v1_keys_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_sf_substreams_index_v1_keys_proto_goTypes,
+		DependencyIndexes: file_sf_substreams_index_v1_keys_proto_depIdxs,
+		MessageInfos:      file_sf_substreams_index_v1_keys_proto_msgTypes,
+	}.Build()
+	File_sf_substreams_index_v1_keys_proto = out.File
+	file_sf_substreams_index_v1_keys_proto_rawDesc = nil
+	file_sf_substreams_index_v1_keys_proto_goTypes = nil
+	file_sf_substreams_index_v1_keys_proto_depIdxs = nil
+}
@@ -73,6 +73,61 @@ func (StoreDelta_Operation) EnumDescriptor() ([]byte, []int) {
 	return file_sf_substreams_intern_v2_deltas_proto_rawDescGZIP(), []int{1, 0}
 }
 
+type LogMetadata_Level int32
+
+const (
+	LogMetadata_UNSET LogMetadata_Level = 0
+	LogMetadata_DEBUG LogMetadata_Level = 1
+	LogMetadata_INFO  LogMetadata_Level = 2
+	LogMetadata_WARN  LogMetadata_Level = 3
+	LogMetadata_ERROR LogMetadata_Level = 4
+)
+
+// Enum value maps for LogMetadata_Level.
+var (
+	LogMetadata_Level_name = map[int32]string{
+		0: "UNSET",
+		1: "DEBUG",
+		2: "INFO",
+		3: "WARN",
+		4: "ERROR",
+	}
+	LogMetadata_Level_value = map[string]int32{
+		"UNSET": 0,
+		"DEBUG": 1,
+		"INFO":  2,
+		"WARN":  3,
+		"ERROR": 4,
+	}
+)
+
+func (x LogMetadata_Level) Enum() *LogMetadata_Level {
+	p := new(LogMetadata_Level)
+	*p = x
+	return p
+}
+
+func (x LogMetadata_Level) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogMetadata_Level) Descriptor() protoreflect.EnumDescriptor {
+	return file_sf_substreams_intern_v2_deltas_proto_enumTypes[1].Descriptor()
+}
+
+func (LogMetadata_Level) Type() protoreflect.EnumType {
+	return &file_sf_substreams_intern_v2_deltas_proto_enumTypes[1]
+}
+
+func (x LogMetadata_Level) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogMetadata_Level.Descriptor instead.
+func (LogMetadata_Level) EnumDescriptor() ([]byte, []int) {
+	return file_sf_substreams_intern_v2_deltas_proto_rawDescGZIP(), []int{3, 0}
+}
+
 type StoreDeltas struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -212,6 +267,9 @@ type ModuleOutput struct {
 	Logs               []string            `protobuf:"bytes,4,rep,name=logs,proto3" json:"logs,omitempty"`
 	DebugLogsTruncated bool                `protobuf:"varint,5,opt,name=debug_logs_truncated,json=debugLogsTruncated,proto3" json:"debug_logs_truncated,omitempty"`
 	Cached             bool                `protobuf:"varint,6,opt,name=cached,proto3" json:"cached,omitempty"`
+	// DebugLogsMetadata holds the level and fields of the log of `logs` at the same index,
+	// empty when the module only logged through `println`.
+	DebugLogsMetadata []*LogMetadata `protobuf:"bytes,7,rep,name=debug_logs_metadata,json=debugLogsMetadata,proto3" json:"debug_logs_metadata,omitempty"`
 }
 
 func (x *ModuleOutput) Reset() {
@@ -295,6 +353,13 @@ func (x *ModuleOutput) GetCached() bool {
 	return false
 }
 
+func (x *ModuleOutput) GetDebugLogsMetadata() []*LogMetadata {
+	if x != nil {
+		return x.DebugLogsMetadata
+	}
+	return nil
+}
+
 type isModuleOutput_Data interface {
 	isModuleOutput_Data()
 }
@@ -311,6 +376,116 @@ func (*ModuleOutput_MapOutput) isModuleOutput_Data() {}
 
 func (*ModuleOutput_StoreDeltas) isModuleOutput_Data() {}
 
+type LogMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level  LogMetadata_Level `protobuf:"varint,1,opt,name=level,proto3,enum=sf.substreams.internal.v2.LogMetadata_Level" json:"level,omitempty"`
+	Fields []*LogField       `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *LogMetadata) Reset() {
+	*x = LogMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_substreams_intern_v2_deltas_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogMetadata) ProtoMessage() {}
+
+func (x *LogMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_substreams_intern_v2_deltas_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogMetadata.ProtoReflect.Descriptor instead.
+func (*LogMetadata) Descriptor() ([]byte, []int) {
+	return file_sf_substreams_intern_v2_deltas_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LogMetadata) GetLevel() LogMetadata_Level {
+	if x != nil {
+		return x.Level
+	}
+	return LogMetadata_UNSET
+}
+
+func (x *LogMetadata) GetFields() []*LogField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type LogField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *LogField) Reset() {
+	*x = LogField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_substreams_intern_v2_deltas_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogField) ProtoMessage() {}
+
+func (x *LogField) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_substreams_intern_v2_deltas_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogField.ProtoReflect.Descriptor instead.
+func (*LogField) Descriptor() ([]byte, []int) {
+	return file_sf_substreams_intern_v2_deltas_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LogField) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LogField) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 var File_sf_substreams_intern_v2_deltas_proto protoreflect.FileDescriptor
 
 var file_sf_substreams_intern_v2_deltas_proto_rawDesc = []byte{
@@ -341,7 +516,7 @@ var file_sf_substreams_intern_v2_deltas_proto_rawDesc = []byte{
 	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10,
 	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a,
 	0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c,
-	0x45, 0x54, 0x45, 0x10, 0x03, 0x22, 0x99, 0x02, 0x0a, 0x0c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x45, 0x54, 0x45, 0x10, 0x03, 0x22, 0xf1, 0x02, 0x0a, 0x0c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
 	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
 	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x64,
 	0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x6d, 0x61, 0x70, 0x5f, 0x6f,
@@ -358,13 +533,35 @@ var file_sf_substreams_intern_v2_deltas_proto_rawDesc = []byte{
 	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x64,
 	0x65, 0x62, 0x75, 0x67, 0x4c, 0x6f, 0x67, 0x73, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65,
 	0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x42, 0x4d, 0x5a, 0x4b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x73, 0x75,
-	0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x66, 0x2f, 0x73,
-	0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x2f, 0x76, 0x32, 0x3b, 0x70, 0x62, 0x73, 0x73, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x56, 0x0a, 0x13, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x5f, 0x6c, 0x6f, 0x67, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e,
+	0x76, 0x32, 0x2e, 0x4c, 0x6f, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x11,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x4c, 0x6f, 0x67, 0x73, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xcc, 0x01, 0x0a, 0x0b, 0x4c, 0x6f,
+	0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x42, 0x0a, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x6f, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x3b, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3c, 0x0a, 0x05, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46,
+	0x4f, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x03, 0x12, 0x09, 0x0a,
+	0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x22, 0x32, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x4d, 0x5a, 0x4b,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x66, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x2f, 0x76, 0x32, 0x3b, 0x70,
+	0x62, 0x73, 0x73, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -379,25 +576,31 @@ func file_sf_substreams_intern_v2_deltas_proto_rawDescGZIP() []byte {
 	return file_sf_substreams_intern_v2_deltas_proto_rawDescData
 }
 
-var file_sf_substreams_intern_v2_deltas_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_sf_substreams_intern_v2_deltas_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_sf_substreams_intern_v2_deltas_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_sf_substreams_intern_v2_deltas_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_sf_substreams_intern_v2_deltas_proto_goTypes = []interface{}{
 	(StoreDelta_Operation)(0), // 0: sf.substreams.internal.v2.StoreDelta.Operation
-	(*StoreDeltas)(nil),       // 1: sf.substreams.internal.v2.StoreDeltas
-	(*StoreDelta)(nil),        // 2: sf.substreams.internal.v2.StoreDelta
-	(*ModuleOutput)(nil),      // 3: sf.substreams.internal.v2.ModuleOutput
-	(*anypb.Any)(nil),         // 4: google.protobuf.Any
+	(LogMetadata_Level)(0),    // 1: sf.substreams.internal.v2.LogMetadata.Level
+	(*StoreDeltas)(nil),       // 2: sf.substreams.internal.v2.StoreDeltas
+	(*StoreDelta)(nil),        // 3: sf.substreams.internal.v2.StoreDelta
+	(*ModuleOutput)(nil),      // 4: sf.substreams.internal.v2.ModuleOutput
+	(*LogMetadata)(nil),       // 5: sf.substreams.internal.v2.LogMetadata
+	(*LogField)(nil),          // 6: sf.substreams.internal.v2.LogField
+	(*anypb.Any)(nil),         // 7: google.protobuf.Any
 }
 var file_sf_substreams_intern_v2_deltas_proto_depIdxs = []int32{
-	2, // 0: sf.substreams.internal.v2.StoreDeltas.store_deltas:type_name -> sf.substreams.internal.v2.StoreDelta
+	3, // 0: sf.substreams.internal.v2.StoreDeltas.store_deltas:type_name -> sf.substreams.internal.v2.StoreDelta
 	0, // 1: sf.substreams.internal.v2.StoreDelta.operation:type_name -> sf.substreams.internal.v2.StoreDelta.Operation
-	4, // 2: sf.substreams.internal.v2.ModuleOutput.map_output:type_name -> google.protobuf.Any
-	1, // 3: sf.substreams.internal.v2.ModuleOutput.store_deltas:type_name -> sf.substreams.internal.v2.StoreDeltas
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	7, // 2: sf.substreams.internal.v2.ModuleOutput.map_output:type_name -> google.protobuf.Any
+	2, // 3: sf.substreams.internal.v2.ModuleOutput.store_deltas:type_name -> sf.substreams.internal.v2.StoreDeltas
+	5, // 4: sf.substreams.internal.v2.ModuleOutput.debug_logs_metadata:type_name -> sf.substreams.internal.v2.LogMetadata
+	1, // 5: sf.substreams.internal.v2.LogMetadata.level:type_name -> sf.substreams.internal.v2.LogMetadata.Level
+	6, // 6: sf.substreams.internal.v2.LogMetadata.fields:type_name -> sf.substreams.internal.v2.LogField
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_sf_substreams_intern_v2_deltas_proto_init() }
@@ -442,6 +645,30 @@ func file_sf_substreams_intern_v2_deltas_proto_init() {
 				return nil
 			}
 		}
+		file_sf_substreams_intern_v2_deltas_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_substreams_intern_v2_deltas_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_sf_substreams_intern_v2_deltas_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*ModuleOutput_MapOutput)(nil),
@@ -452,8 +679,8 @@ func file_sf_substreams_intern_v2_deltas_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sf_substreams_intern_v2_deltas_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -2,6 +2,7 @@ package pbsubstreamsrpc
 
 import (
 	"fmt"
+	"strings"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
@@ -40,6 +41,45 @@ func (a *AnyModuleOutput) IsEmpty() bool {
 	return len(a.StoreOutput.DebugStoreDeltas) == 0
 }
 
+// FilteredLogs returns the logs of level `minLevel` or higher, prefixed by their level
+// and followed by their fields when they have metadata. Logs without metadata are of
+// level INFO.
+func (x *OutputDebugInfo) FilteredLogs(minLevel LogMetadata_Level) (out []string) {
+	for i, log := range x.GetLogs() {
+		if i >= len(x.LogsMetadata) {
+			if LogMetadata_INFO >= minLevel {
+				out = append(out, log)
+			}
+			continue
+		}
+
+		metadata := x.LogsMetadata[i]
+		level := metadata.Level
+		if level == LogMetadata_UNSET {
+			level = LogMetadata_INFO
+		}
+		if level < minLevel {
+			continue
+		}
+
+		formatted := fmt.Sprintf("[%s] %s", level, log)
+		for _, field := range metadata.Fields {
+			formatted += fmt.Sprintf(" %s=%q", field.Key, field.Value)
+		}
+		out = append(out, formatted)
+	}
+	return
+}
+
+// ParseLogLevel parses the level named `in`, case-insensitively.
+func ParseLogLevel(in string) (LogMetadata_Level, error) {
+	level, found := LogMetadata_Level_value[strings.ToUpper(in)]
+	if !found || level == int32(LogMetadata_UNSET) {
+		return 0, fmt.Errorf("invalid log level %q, expecting one of 'debug', 'info', 'warn' or 'error'", in)
+	}
+	return LogMetadata_Level(level), nil
+}
+
 func (m *MapModuleOutput) ToAny() *AnyModuleOutput {
 	return &AnyModuleOutput{
 		MapOutput: m,
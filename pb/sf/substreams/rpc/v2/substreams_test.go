@@ -34,3 +34,30 @@ func Test_ValidateRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputDebugInfo_FilteredLogs(t *testing.T) {
+	plain := &OutputDebugInfo{Logs: []string{"first", "second"}}
+	assert.Equal(t, []string{"first", "second"}, plain.FilteredLogs(LogMetadata_DEBUG))
+	assert.Equal(t, []string{"first", "second"}, plain.FilteredLogs(LogMetadata_INFO))
+	assert.Empty(t, plain.FilteredLogs(LogMetadata_WARN))
+
+	leveled := &OutputDebugInfo{
+		Logs: []string{"plain", "details", "failing"},
+		LogsMetadata: []*LogMetadata{
+			{Level: LogMetadata_INFO},
+			{Level: LogMetadata_DEBUG},
+			{Level: LogMetadata_ERROR, Fields: []*LogField{{Key: "tx", Value: "0xab"}, {Key: "reason", Value: "out of gas"}}},
+		},
+	}
+	assert.Equal(t, []string{"[INFO] plain", "[DEBUG] details", `[ERROR] failing tx="0xab" reason="out of gas"`}, leveled.FilteredLogs(LogMetadata_DEBUG))
+	assert.Equal(t, []string{`[ERROR] failing tx="0xab" reason="out of gas"`}, leveled.FilteredLogs(LogMetadata_WARN))
+}
+
+func TestParseLogLevel(t *testing.T) {
+	level, err := ParseLogLevel("warn")
+	require.NoError(t, err)
+	assert.Equal(t, LogMetadata_WARN, level)
+
+	_, err = ParseLogLevel("unset")
+	assert.EqualError(t, err, `invalid log level "unset", expecting one of 'debug', 'info', 'warn' or 'error'`)
+}
@@ -7,6 +7,7 @@ type ModuleKind int
 const (
 	ModuleKindStore = ModuleKind(iota)
 	ModuleKindMap
+	ModuleKindBlockIndex
 )
 
 func (x *Module) ModuleKind() ModuleKind {
@@ -15,6 +16,8 @@ func (x *Module) ModuleKind() ModuleKind {
 		return ModuleKindMap
 	case *Module_KindStore_:
 		return ModuleKindStore
+	case *Module_KindBlockIndex_:
+		return ModuleKindBlockIndex
 	}
 	panic("unsupported kind")
 }
@@ -30,6 +33,9 @@ func (x *Module_Input) Pretty() string {
 		result = x.GetSource().GetType()
 	case *Module_Input_Params_:
 		result = x.GetParams().GetValue()
+		if paramsType := x.GetParams().GetType(); paramsType != "" {
+			result = paramsType
+		}
 	default:
 		result = "unknown"
 	}
@@ -84,7 +84,7 @@ func (x Module_KindStore_UpdatePolicy) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Module_KindStore_UpdatePolicy.Descriptor instead.
 func (Module_KindStore_UpdatePolicy) EnumDescriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 1, 0}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 4, 0}
 }
 
 type Module_Input_Store_Mode int32
@@ -133,7 +133,7 @@ func (x Module_Input_Store_Mode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Module_Input_Store_Mode.Descriptor instead.
 func (Module_Input_Store_Mode) EnumDescriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 2, 2, 0}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 5, 2, 0}
 }
 
 type Modules struct {
@@ -256,12 +256,19 @@ type Module struct {
 	// Types that are assignable to Kind:
 	//	*Module_KindMap_
 	//	*Module_KindStore_
+	//	*Module_KindBlockIndex_
 	Kind             isModule_Kind   `protobuf_oneof:"kind"`
 	BinaryIndex      uint32          `protobuf:"varint,4,opt,name=binary_index,json=binaryIndex,proto3" json:"binary_index,omitempty"`
 	BinaryEntrypoint string          `protobuf:"bytes,5,opt,name=binary_entrypoint,json=binaryEntrypoint,proto3" json:"binary_entrypoint,omitempty"`
 	Inputs           []*Module_Input `protobuf:"bytes,6,rep,name=inputs,proto3" json:"inputs,omitempty"`
 	Output           *Module_Output  `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
 	InitialBlock     uint64          `protobuf:"varint,8,opt,name=initial_block,json=initialBlock,proto3" json:"initial_block,omitempty"`
+	// When set, the module is only executed on blocks for which the output
+	// of the referenced `block_index` module matches the query.
+	BlockFilter *Module_BlockFilter `protobuf:"bytes,9,opt,name=block_filter,json=blockFilter,proto3" json:"block_filter,omitempty"`
+	// Resource limits of the module, capped by the maximums of the server
+	// which replace the unset ones.
+	Limits *Module_Limits `protobuf:"bytes,11,opt,name=limits,proto3" json:"limits,omitempty"`
 }
 
 func (x *Module) Reset() {
@@ -324,6 +331,13 @@ func (x *Module) GetKindStore() *Module_KindStore {
 	return nil
 }
 
+func (x *Module) GetKindBlockIndex() *Module_KindBlockIndex {
+	if x, ok := x.GetKind().(*Module_KindBlockIndex_); ok {
+		return x.KindBlockIndex
+	}
+	return nil
+}
+
 func (x *Module) GetBinaryIndex() uint32 {
 	if x != nil {
 		return x.BinaryIndex
@@ -359,6 +373,20 @@ func (x *Module) GetInitialBlock() uint64 {
 	return 0
 }
 
+func (x *Module) GetBlockFilter() *Module_BlockFilter {
+	if x != nil {
+		return x.BlockFilter
+	}
+	return nil
+}
+
+func (x *Module) GetLimits() *Module_Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
 type isModule_Kind interface {
 	isModule_Kind()
 }
@@ -371,10 +399,149 @@ type Module_KindStore_ struct {
 	KindStore *Module_KindStore `protobuf:"bytes,3,opt,name=kind_store,json=kindStore,proto3,oneof"`
 }
 
+type Module_KindBlockIndex_ struct {
+	KindBlockIndex *Module_KindBlockIndex `protobuf:"bytes,10,opt,name=kind_block_index,json=kindBlockIndex,proto3,oneof"`
+}
+
 func (*Module_KindMap_) isModule_Kind() {}
 
 func (*Module_KindStore_) isModule_Kind() {}
 
+func (*Module_KindBlockIndex_) isModule_Kind() {}
+
+type Module_Limits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// WASM fuel a module execution can consume per block, only enforced by
+	// the runtimes metering fuel.
+	MaxFuelPerBlock uint64 `protobuf:"varint,1,opt,name=max_fuel_per_block,json=maxFuelPerBlock,proto3" json:"max_fuel_per_block,omitempty"`
+	// Bytes of logs kept per block, the following logs being truncated.
+	MaxLogBytes uint64 `protobuf:"varint,2,opt,name=max_log_bytes,json=maxLogBytes,proto3" json:"max_log_bytes,omitempty"`
+	// Bytes the keys and values of a store module can hold.
+	MaxStoreSize uint64 `protobuf:"varint,3,opt,name=max_store_size,json=maxStoreSize,proto3" json:"max_store_size,omitempty"`
+	// Bytes of a single value of a store module.
+	MaxStoreItemSize uint64 `protobuf:"varint,4,opt,name=max_store_item_size,json=maxStoreItemSize,proto3" json:"max_store_item_size,omitempty"`
+}
+
+func (x *Module_Limits) Reset() {
+	*x = Module_Limits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Module_Limits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module_Limits) ProtoMessage() {}
+
+func (x *Module_Limits) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module_Limits.ProtoReflect.Descriptor instead.
+func (*Module_Limits) Descriptor() ([]byte, []int) {
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Module_Limits) GetMaxFuelPerBlock() uint64 {
+	if x != nil {
+		return x.MaxFuelPerBlock
+	}
+	return 0
+}
+
+func (x *Module_Limits) GetMaxLogBytes() uint64 {
+	if x != nil {
+		return x.MaxLogBytes
+	}
+	return 0
+}
+
+func (x *Module_Limits) GetMaxStoreSize() uint64 {
+	if x != nil {
+		return x.MaxStoreSize
+	}
+	return 0
+}
+
+func (x *Module_Limits) GetMaxStoreItemSize() uint64 {
+	if x != nil {
+		return x.MaxStoreItemSize
+	}
+	return 0
+}
+
+type Module_BlockFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of a module of kind `block_index` producing the keys of each block.
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// Boolean expression over the index keys, ex: "transfer && (erc20 || erc721)".
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *Module_BlockFilter) Reset() {
+	*x = Module_BlockFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Module_BlockFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module_BlockFilter) ProtoMessage() {}
+
+func (x *Module_BlockFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module_BlockFilter.ProtoReflect.Descriptor instead.
+func (*Module_BlockFilter) Descriptor() ([]byte, []int) {
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *Module_BlockFilter) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *Module_BlockFilter) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
 type Module_KindMap struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -386,7 +553,7 @@ type Module_KindMap struct {
 func (x *Module_KindMap) Reset() {
 	*x = Module_KindMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[3]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -399,7 +566,7 @@ func (x *Module_KindMap) String() string {
 func (*Module_KindMap) ProtoMessage() {}
 
 func (x *Module_KindMap) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[3]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +579,7 @@ func (x *Module_KindMap) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_KindMap.ProtoReflect.Descriptor instead.
 func (*Module_KindMap) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 0}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 2}
 }
 
 func (x *Module_KindMap) GetOutputType() string {
@@ -422,6 +589,55 @@ func (x *Module_KindMap) GetOutputType() string {
 	return ""
 }
 
+// KindBlockIndex modules output a `sf.substreams.index.v1.Keys` message for
+// each block, consumed by the `block_filter` of downstream modules.
+type Module_KindBlockIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OutputType string `protobuf:"bytes,1,opt,name=output_type,json=outputType,proto3" json:"output_type,omitempty"`
+}
+
+func (x *Module_KindBlockIndex) Reset() {
+	*x = Module_KindBlockIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Module_KindBlockIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module_KindBlockIndex) ProtoMessage() {}
+
+func (x *Module_KindBlockIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module_KindBlockIndex.ProtoReflect.Descriptor instead.
+func (*Module_KindBlockIndex) Descriptor() ([]byte, []int) {
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Module_KindBlockIndex) GetOutputType() string {
+	if x != nil {
+		return x.OutputType
+	}
+	return ""
+}
+
 type Module_KindStore struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -442,7 +658,7 @@ type Module_KindStore struct {
 func (x *Module_KindStore) Reset() {
 	*x = Module_KindStore{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[4]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -455,7 +671,7 @@ func (x *Module_KindStore) String() string {
 func (*Module_KindStore) ProtoMessage() {}
 
 func (x *Module_KindStore) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[4]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -468,7 +684,7 @@ func (x *Module_KindStore) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_KindStore.ProtoReflect.Descriptor instead.
 func (*Module_KindStore) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 1}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 4}
 }
 
 func (x *Module_KindStore) GetUpdatePolicy() Module_KindStore_UpdatePolicy {
@@ -501,7 +717,7 @@ type Module_Input struct {
 func (x *Module_Input) Reset() {
 	*x = Module_Input{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[5]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -514,7 +730,7 @@ func (x *Module_Input) String() string {
 func (*Module_Input) ProtoMessage() {}
 
 func (x *Module_Input) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[5]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,7 +743,7 @@ func (x *Module_Input) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_Input.ProtoReflect.Descriptor instead.
 func (*Module_Input) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 2}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 5}
 }
 
 func (m *Module_Input) GetInput() isModule_Input_Input {
@@ -604,7 +820,7 @@ type Module_Output struct {
 func (x *Module_Output) Reset() {
 	*x = Module_Output{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[6]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -617,7 +833,7 @@ func (x *Module_Output) String() string {
 func (*Module_Output) ProtoMessage() {}
 
 func (x *Module_Output) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[6]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -630,7 +846,7 @@ func (x *Module_Output) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_Output.ProtoReflect.Descriptor instead.
 func (*Module_Output) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 3}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 6}
 }
 
 func (x *Module_Output) GetType() string {
@@ -651,7 +867,7 @@ type Module_Input_Source struct {
 func (x *Module_Input_Source) Reset() {
 	*x = Module_Input_Source{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[7]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -664,7 +880,7 @@ func (x *Module_Input_Source) String() string {
 func (*Module_Input_Source) ProtoMessage() {}
 
 func (x *Module_Input_Source) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[7]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -677,7 +893,7 @@ func (x *Module_Input_Source) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_Input_Source.ProtoReflect.Descriptor instead.
 func (*Module_Input_Source) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 2, 0}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 5, 0}
 }
 
 func (x *Module_Input_Source) GetType() string {
@@ -698,7 +914,7 @@ type Module_Input_Map struct {
 func (x *Module_Input_Map) Reset() {
 	*x = Module_Input_Map{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[8]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -711,7 +927,7 @@ func (x *Module_Input_Map) String() string {
 func (*Module_Input_Map) ProtoMessage() {}
 
 func (x *Module_Input_Map) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[8]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,7 +940,7 @@ func (x *Module_Input_Map) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_Input_Map.ProtoReflect.Descriptor instead.
 func (*Module_Input_Map) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 2, 1}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 5, 1}
 }
 
 func (x *Module_Input_Map) GetModuleName() string {
@@ -746,7 +962,7 @@ type Module_Input_Store struct {
 func (x *Module_Input_Store) Reset() {
 	*x = Module_Input_Store{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[9]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -759,7 +975,7 @@ func (x *Module_Input_Store) String() string {
 func (*Module_Input_Store) ProtoMessage() {}
 
 func (x *Module_Input_Store) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[9]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,7 +988,7 @@ func (x *Module_Input_Store) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_Input_Store.ProtoReflect.Descriptor instead.
 func (*Module_Input_Store) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 2, 2}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 5, 2}
 }
 
 func (x *Module_Input_Store) GetModuleName() string {
@@ -795,12 +1011,16 @@ type Module_Input_Params struct {
 	unknownFields protoimpl.UnknownFields
 
 	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// For typed params, the `proto:` type of the message given to the
+	// module, `typed_value` holding its protobuf encoding instead of `value`.
+	Type       string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	TypedValue []byte `protobuf:"bytes,3,opt,name=typed_value,json=typedValue,proto3" json:"typed_value,omitempty"`
 }
 
 func (x *Module_Input_Params) Reset() {
 	*x = Module_Input_Params{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_substreams_v1_modules_proto_msgTypes[10]
+		mi := &file_sf_substreams_v1_modules_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -813,7 +1033,7 @@ func (x *Module_Input_Params) String() string {
 func (*Module_Input_Params) ProtoMessage() {}
 
 func (x *Module_Input_Params) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_substreams_v1_modules_proto_msgTypes[10]
+	mi := &file_sf_substreams_v1_modules_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -826,7 +1046,7 @@ func (x *Module_Input_Params) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Module_Input_Params.ProtoReflect.Descriptor instead.
 func (*Module_Input_Params) Descriptor() ([]byte, []int) {
-	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 2, 3}
+	return file_sf_substreams_v1_modules_proto_rawDescGZIP(), []int{2, 5, 3}
 }
 
 func (x *Module_Input_Params) GetValue() string {
@@ -836,6 +1056,20 @@ func (x *Module_Input_Params) GetValue() string {
 	return ""
 }
 
+func (x *Module_Input_Params) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Module_Input_Params) GetTypedValue() []byte {
+	if x != nil {
+		return x.TypedValue
+	}
+	return nil
+}
+
 var File_sf_substreams_v1_modules_proto protoreflect.FileDescriptor
 
 var file_sf_substreams_v1_modules_proto_rawDesc = []byte{
@@ -853,7 +1087,7 @@ var file_sf_substreams_v1_modules_proto_rawDesc = []byte{
 	0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22,
-	0xa3, 0x0a, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0xd0, 0x0e, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
 	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d,
 	0x0a, 0x08, 0x6b, 0x69, 0x6e, 0x64, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x20, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73,
@@ -863,84 +1097,119 @@ var file_sf_substreams_v1_modules_proto_rawDesc = []byte{
 	0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
 	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64,
 	0x53, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x09, 0x6b, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x69, 0x6e, 0x64,
-	0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f,
-	0x65, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x10, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x6f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x66, 0x2e,
-	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f,
-	0x64, 0x75, 0x6c, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x06, 0x6f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x62,
-	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x69, 0x6e, 0x69, 0x74,
-	0x69, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x1a, 0x2a, 0x0a, 0x07, 0x4b, 0x69, 0x6e, 0x64,
-	0x4d, 0x61, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x1a, 0xc5, 0x02, 0x0a, 0x09, 0x4b, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x12, 0x54, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x6c,
-	0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x73, 0x66, 0x2e, 0x73,
-	0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64,
-	0x75, 0x6c, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0xc2, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x50, 0x44, 0x41,
-	0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10,
-	0x00, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49,
-	0x43, 0x59, 0x5f, 0x53, 0x45, 0x54, 0x10, 0x01, 0x12, 0x23, 0x0a, 0x1f, 0x55, 0x50, 0x44, 0x41,
-	0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x53, 0x45, 0x54, 0x5f, 0x49, 0x46,
-	0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x02, 0x12, 0x15, 0x0a,
-	0x11, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x41,
-	0x44, 0x44, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50,
-	0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4d, 0x49, 0x4e, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x55,
-	0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4d, 0x41, 0x58,
-	0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c,
-	0x49, 0x43, 0x59, 0x5f, 0x41, 0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x06, 0x1a, 0x80, 0x04, 0x0a,
-	0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x72, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x6b, 0x69, 0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x73,
+	0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x48, 0x00, 0x52, 0x0e, 0x6b, 0x69, 0x6e, 0x64, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x79, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12,
+	0x37, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x47, 0x0a,
+	0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73,
 	0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
-	0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52,
-	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x03, 0x6d, 0x61, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x49,
-	0x6e, 0x70, 0x75, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x12,
-	0x3c, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x1a,
+	0xae, 0x01, 0x0a, 0x06, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x61,
+	0x78, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x46, 0x75, 0x65, 0x6c, 0x50,
+	0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6c,
+	0x6f, 0x67, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x67, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d,
+	0x61, 0x78, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x2d, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x69,
+	0x74, 0x65, 0x6d, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10,
+	0x6d, 0x61, 0x78, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x69, 0x7a, 0x65,
+	0x1a, 0x3b, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x2a, 0x0a,
+	0x07, 0x4b, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x31, 0x0a, 0x0e, 0x4b, 0x69, 0x6e,
+	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1f, 0x0a, 0x0b, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0xc5, 0x02, 0x0a,
+	0x09, 0x4b, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x54, 0x0a, 0x0d, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2f, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22,
+	0xc2, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x17, 0x0a, 0x13, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
+	0x59, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x50, 0x44,
+	0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x53, 0x45, 0x54, 0x10, 0x01,
+	0x12, 0x23, 0x0a, 0x1f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
+	0x59, 0x5f, 0x53, 0x45, 0x54, 0x5f, 0x49, 0x46, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x58, 0x49,
+	0x53, 0x54, 0x53, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f,
+	0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x41, 0x44, 0x44, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11,
+	0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4d, 0x49,
+	0x4e, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f,
+	0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4d, 0x41, 0x58, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x55, 0x50,
+	0x44, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x41, 0x50, 0x50, 0x45,
+	0x4e, 0x44, 0x10, 0x06, 0x1a, 0xb5, 0x04, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x3f,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
 	0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76,
 	0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x3f, 0x0a,
-	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
-	0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1c,
-	0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x26, 0x0a, 0x03,
-	0x4d, 0x61, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x8f, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x1f,
-	0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x3d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e,
-	0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x53, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x26,
-	0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10,
-	0x00, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45,
-	0x4c, 0x54, 0x41, 0x53, 0x10, 0x02, 0x1a, 0x1e, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x1a,
-	0x1c, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x42, 0x06, 0x0a,
-	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73,
-	0x74, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f, 0x70, 0x62, 0x2f,
-	0x73, 0x66, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f, 0x76, 0x31,
-	0x3b, 0x70, 0x62, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x36, 0x0a, 0x03, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73,
+	0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x4d, 0x61, 0x70,
+	0x48, 0x00, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x12, 0x3c, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x05,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x48, 0x00, 0x52, 0x06,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1c, 0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x1a, 0x26, 0x0a, 0x03, 0x4d, 0x61, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x8f, 0x01, 0x0a,
+	0x05, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x73, 0x66, 0x2e, 0x73, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x26, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x09,
+	0x0a, 0x05, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x54, 0x41, 0x53, 0x10, 0x02, 0x1a, 0x53,
+	0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x64, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x1c, 0x0a, 0x06,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x73,
+	0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x66, 0x2f,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -956,40 +1225,46 @@ func file_sf_substreams_v1_modules_proto_rawDescGZIP() []byte {
 }
 
 var file_sf_substreams_v1_modules_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_sf_substreams_v1_modules_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_sf_substreams_v1_modules_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_sf_substreams_v1_modules_proto_goTypes = []interface{}{
 	(Module_KindStore_UpdatePolicy)(0), // 0: sf.substreams.v1.Module.KindStore.UpdatePolicy
 	(Module_Input_Store_Mode)(0),       // 1: sf.substreams.v1.Module.Input.Store.Mode
 	(*Modules)(nil),                    // 2: sf.substreams.v1.Modules
 	(*Binary)(nil),                     // 3: sf.substreams.v1.Binary
 	(*Module)(nil),                     // 4: sf.substreams.v1.Module
-	(*Module_KindMap)(nil),             // 5: sf.substreams.v1.Module.KindMap
-	(*Module_KindStore)(nil),           // 6: sf.substreams.v1.Module.KindStore
-	(*Module_Input)(nil),               // 7: sf.substreams.v1.Module.Input
-	(*Module_Output)(nil),              // 8: sf.substreams.v1.Module.Output
-	(*Module_Input_Source)(nil),        // 9: sf.substreams.v1.Module.Input.Source
-	(*Module_Input_Map)(nil),           // 10: sf.substreams.v1.Module.Input.Map
-	(*Module_Input_Store)(nil),         // 11: sf.substreams.v1.Module.Input.Store
-	(*Module_Input_Params)(nil),        // 12: sf.substreams.v1.Module.Input.Params
+	(*Module_Limits)(nil),              // 5: sf.substreams.v1.Module.Limits
+	(*Module_BlockFilter)(nil),         // 6: sf.substreams.v1.Module.BlockFilter
+	(*Module_KindMap)(nil),             // 7: sf.substreams.v1.Module.KindMap
+	(*Module_KindBlockIndex)(nil),      // 8: sf.substreams.v1.Module.KindBlockIndex
+	(*Module_KindStore)(nil),           // 9: sf.substreams.v1.Module.KindStore
+	(*Module_Input)(nil),               // 10: sf.substreams.v1.Module.Input
+	(*Module_Output)(nil),              // 11: sf.substreams.v1.Module.Output
+	(*Module_Input_Source)(nil),        // 12: sf.substreams.v1.Module.Input.Source
+	(*Module_Input_Map)(nil),           // 13: sf.substreams.v1.Module.Input.Map
+	(*Module_Input_Store)(nil),         // 14: sf.substreams.v1.Module.Input.Store
+	(*Module_Input_Params)(nil),        // 15: sf.substreams.v1.Module.Input.Params
 }
 var file_sf_substreams_v1_modules_proto_depIdxs = []int32{
 	4,  // 0: sf.substreams.v1.Modules.modules:type_name -> sf.substreams.v1.Module
 	3,  // 1: sf.substreams.v1.Modules.binaries:type_name -> sf.substreams.v1.Binary
-	5,  // 2: sf.substreams.v1.Module.kind_map:type_name -> sf.substreams.v1.Module.KindMap
-	6,  // 3: sf.substreams.v1.Module.kind_store:type_name -> sf.substreams.v1.Module.KindStore
-	7,  // 4: sf.substreams.v1.Module.inputs:type_name -> sf.substreams.v1.Module.Input
-	8,  // 5: sf.substreams.v1.Module.output:type_name -> sf.substreams.v1.Module.Output
-	0,  // 6: sf.substreams.v1.Module.KindStore.update_policy:type_name -> sf.substreams.v1.Module.KindStore.UpdatePolicy
-	9,  // 7: sf.substreams.v1.Module.Input.source:type_name -> sf.substreams.v1.Module.Input.Source
-	10, // 8: sf.substreams.v1.Module.Input.map:type_name -> sf.substreams.v1.Module.Input.Map
-	11, // 9: sf.substreams.v1.Module.Input.store:type_name -> sf.substreams.v1.Module.Input.Store
-	12, // 10: sf.substreams.v1.Module.Input.params:type_name -> sf.substreams.v1.Module.Input.Params
-	1,  // 11: sf.substreams.v1.Module.Input.Store.mode:type_name -> sf.substreams.v1.Module.Input.Store.Mode
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	7,  // 2: sf.substreams.v1.Module.kind_map:type_name -> sf.substreams.v1.Module.KindMap
+	9,  // 3: sf.substreams.v1.Module.kind_store:type_name -> sf.substreams.v1.Module.KindStore
+	8,  // 4: sf.substreams.v1.Module.kind_block_index:type_name -> sf.substreams.v1.Module.KindBlockIndex
+	10, // 5: sf.substreams.v1.Module.inputs:type_name -> sf.substreams.v1.Module.Input
+	11, // 6: sf.substreams.v1.Module.output:type_name -> sf.substreams.v1.Module.Output
+	6,  // 7: sf.substreams.v1.Module.block_filter:type_name -> sf.substreams.v1.Module.BlockFilter
+	5,  // 8: sf.substreams.v1.Module.limits:type_name -> sf.substreams.v1.Module.Limits
+	0,  // 9: sf.substreams.v1.Module.KindStore.update_policy:type_name -> sf.substreams.v1.Module.KindStore.UpdatePolicy
+	12, // 10: sf.substreams.v1.Module.Input.source:type_name -> sf.substreams.v1.Module.Input.Source
+	13, // 11: sf.substreams.v1.Module.Input.map:type_name -> sf.substreams.v1.Module.Input.Map
+	14, // 12: sf.substreams.v1.Module.Input.store:type_name -> sf.substreams.v1.Module.Input.Store
+	15, // 13: sf.substreams.v1.Module.Input.params:type_name -> sf.substreams.v1.Module.Input.Params
+	1,  // 14: sf.substreams.v1.Module.Input.Store.mode:type_name -> sf.substreams.v1.Module.Input.Store.Mode
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_sf_substreams_v1_modules_proto_init() }
@@ -1035,7 +1310,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Module_KindMap); i {
+			switch v := v.(*Module_Limits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1047,7 +1322,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Module_KindStore); i {
+			switch v := v.(*Module_BlockFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1059,7 +1334,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Module_Input); i {
+			switch v := v.(*Module_KindMap); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1071,7 +1346,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Module_Output); i {
+			switch v := v.(*Module_KindBlockIndex); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1083,7 +1358,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Module_Input_Source); i {
+			switch v := v.(*Module_KindStore); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1095,7 +1370,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Module_Input_Map); i {
+			switch v := v.(*Module_Input); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1107,7 +1382,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Module_Input_Store); i {
+			switch v := v.(*Module_Output); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1119,6 +1394,42 @@ func file_sf_substreams_v1_modules_proto_init() {
 			}
 		}
 		file_sf_substreams_v1_modules_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Module_Input_Source); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_substreams_v1_modules_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Module_Input_Map); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_substreams_v1_modules_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Module_Input_Store); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_substreams_v1_modules_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Module_Input_Params); i {
 			case 0:
 				return &v.state
@@ -1134,8 +1445,9 @@ func file_sf_substreams_v1_modules_proto_init() {
 	file_sf_substreams_v1_modules_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*Module_KindMap_)(nil),
 		(*Module_KindStore_)(nil),
+		(*Module_KindBlockIndex_)(nil),
 	}
-	file_sf_substreams_v1_modules_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_sf_substreams_v1_modules_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*Module_Input_Source_)(nil),
 		(*Module_Input_Map_)(nil),
 		(*Module_Input_Store_)(nil),
@@ -1147,7 +1459,7 @@ func file_sf_substreams_v1_modules_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sf_substreams_v1_modules_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -7,6 +7,8 @@ import (
 	"github.com/streamingfast/substreams/reqctx"
 
 	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/block"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/service/config"
 	"github.com/streamingfast/substreams/storage/execout"
@@ -22,6 +24,7 @@ type Engine struct {
 	blockType         string
 	reversibleBuffers map[uint64]*execout.Buffer // block num to modules' outputs for that given block
 	writableFiles     *execout.Writer            // moduleName => irreversible File
+	cachedFiles       []*execout.File            // previously written outputs, served instead of executing the module
 	runtimeConfig     config.RuntimeConfig
 	logger            *zap.Logger
 }
@@ -44,11 +47,47 @@ func (e *Engine) NewBuffer(block *bstream.Block, clock *pbsubstreams.Clock, curs
 		return nil, fmt.Errorf("setting up map: %w", err)
 	}
 
+	for _, file := range e.cachedFiles {
+		if !file.Contains(clock.Number) {
+			continue
+		}
+		if value, found := file.Get(clock); found {
+			execOutBuf.SetCached(file.ModuleName, value)
+		}
+	}
+
 	e.reversibleBuffers[clock.Number] = execOutBuf
 
 	return execOutBuf, nil
 }
 
+// LoadCachedOutputs loads the execution output files of `moduleNames` that were
+// already written for the [startBlock, exclusiveEndBlock) range. Ranges without
+// a file are simply executed.
+func (e *Engine) LoadCachedOutputs(ctx context.Context, configs *execout.Configs, moduleNames []string, startBlock, exclusiveEndBlock uint64) error {
+	for _, name := range moduleNames {
+		config := configs.ConfigMap[name]
+		if config == nil || config.ModuleInitialBlock() >= exclusiveEndBlock {
+			continue
+		}
+
+		targetRange := block.NewBoundedRange(config.ModuleInitialBlock(), e.runtimeConfig.CacheSaveInterval, startBlock, exclusiveEndBlock)
+		for file := config.NewFile(targetRange); file != nil; file = file.NextFile() {
+			err := file.Load(ctx)
+			if err == dstore.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("loading %s cached outputs %q: %w", name, file.Filename(), err)
+			}
+
+			e.logger.Debug("using cached outputs", zap.Object("file", file))
+			e.cachedFiles = append(e.cachedFiles, file)
+		}
+	}
+	return nil
+}
+
 func (e *Engine) HandleUndo(clock *pbsubstreams.Clock) {
 	delete(e.reversibleBuffers, clock.Number)
 }
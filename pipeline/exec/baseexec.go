@@ -7,7 +7,9 @@ import (
 
 	ttrace "go.opentelemetry.io/otel/trace"
 
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/storage/execout"
+	"github.com/streamingfast/substreams/storage/store"
 	"github.com/streamingfast/substreams/wasm"
 )
 
@@ -20,26 +22,30 @@ type BaseExecutor struct {
 	wasmModule    wasm.Module
 	wasmArguments []wasm.Argument
 	entrypoint    string
+	limits        *pbsubstreams.Module_Limits
 	tracer        ttrace.Tracer
 
-	instanceCacheEnabled bool
-	cachedInstance       wasm.Instance
+	instanceCacheEnabled   bool
+	instancePoolingEnabled bool
+	cachedInstance         wasm.Instance
 
 	// Results
-	logs           []string
+	logs           []wasm.Log
 	logsTruncated  bool
 	executionStack []string
 }
 
-func NewBaseExecutor(ctx context.Context, moduleName string, wasmModule wasm.Module, cacheEnabled bool, wasmArguments []wasm.Argument, entrypoint string, tracer ttrace.Tracer) *BaseExecutor {
+func NewBaseExecutor(ctx context.Context, moduleName string, wasmModule wasm.Module, cacheEnabled bool, poolingEnabled bool, wasmArguments []wasm.Argument, entrypoint string, limits *pbsubstreams.Module_Limits, tracer ttrace.Tracer) *BaseExecutor {
 	return &BaseExecutor{
-		ctx:                  ctx,
-		moduleName:           moduleName,
-		wasmModule:           wasmModule,
-		instanceCacheEnabled: cacheEnabled,
-		wasmArguments:        wasmArguments,
-		entrypoint:           entrypoint,
-		tracer:               tracer,
+		ctx:                    ctx,
+		moduleName:             moduleName,
+		wasmModule:             wasmModule,
+		instanceCacheEnabled:   cacheEnabled,
+		instancePoolingEnabled: poolingEnabled,
+		wasmArguments:          wasmArguments,
+		entrypoint:             entrypoint,
+		limits:                 limits,
+		tracer:                 tracer,
 	}
 }
 
@@ -79,14 +85,39 @@ func (e *BaseExecutor) wasmCall(outputGetter execout.ExecutionOutputGetter) (cal
 
 		//t0 := time.Now()
 		call = wasm.NewCall(clock, e.moduleName, e.entrypoint, e.wasmArguments)
+		call.SetLimits(e.limits.GetMaxFuelPerBlock(), e.limits.GetMaxLogBytes())
 		inst, err = e.wasmModule.ExecuteNewCall(e.ctx, call, e.cachedInstance, e.wasmArguments)
 		//Timer += time.Since(t0)
-		if panicErr := call.Err(); panicErr != nil {
+		// the backend of a store failing isn't the module's fault, nor deterministic
+		if storeErr := storesErr(e.wasmArguments); storeErr != nil {
+			return nil, fmt.Errorf("block %d: module %q: %w", clock.Number, e.moduleName, storeErr)
+		}
+		// the divergences of the runtimes already tell the block and the module, and
+		// aren't failures of the module, even when it panicked in one of the runtimes
+		var divergenceErr *wasm.RuntimeDivergenceError
+		if errors.As(err, &divergenceErr) {
+			return nil, divergenceErr
+		}
+		// a panic ends with a trap, and a trap fails deterministically on its own as well
+		var trapErr *wasm.TrapError
+		errors.As(err, &trapErr)
+		if panicErr := call.Err(); panicErr != nil || trapErr != nil {
 			errExecutor := &ErrorExecutor{
-				message:    panicErr.Error(),
-				stackTrace: call.ExecutionStack,
+				stackTrace:    call.ExecutionStack,
+				logsTruncated: call.ReachedLogsMaxByteCount(),
+			}
+			if panicErr != nil {
+				errExecutor.message = panicErr.Error()
+			} else {
+				errExecutor.message = trapErr.Error()
+			}
+			if trapErr != nil {
+				errExecutor.wasmStackTrace = trapErr.StackTrace()
 			}
-			return nil, fmt.Errorf("block %d: module %q: %w: %s", clock.Number, e.moduleName, ErrWasmDeterministicExec, errExecutor.Error())
+			for _, log := range call.Logs {
+				errExecutor.logs = append(errExecutor.logs, log.Message)
+			}
+			return nil, fmt.Errorf("block %d: module %q: %w", clock.Number, e.moduleName, errExecutor)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("block %d: module %q: general wasm execution failed: %v", clock.Number, e.moduleName, err)
@@ -97,8 +128,20 @@ func (e *BaseExecutor) wasmCall(outputGetter execout.ExecutionOutputGetter) (cal
 			}
 			e.cachedInstance = inst
 		} else {
-			if err := inst.Close(e.ctx); err != nil {
-				return nil, fmt.Errorf("block %d: module %q: failed to close module: %w", clock.Number, e.moduleName, err)
+			reused := false
+			if restorable, ok := inst.(wasm.RestorableInstance); ok && e.instancePoolingEnabled {
+				reused, err = restorable.Restore(e.ctx)
+				if err != nil {
+					return nil, fmt.Errorf("block %d: module %q: failed to restore module: %w", clock.Number, e.moduleName, err)
+				}
+			}
+			if reused {
+				e.cachedInstance = inst
+			} else {
+				e.cachedInstance = nil
+				if err := inst.Close(e.ctx); err != nil {
+					return nil, fmt.Errorf("block %d: module %q: failed to close module: %w", clock.Number, e.moduleName, err)
+				}
 			}
 		}
 		e.logs = call.Logs
@@ -108,6 +151,25 @@ func (e *BaseExecutor) wasmCall(outputGetter execout.ExecutionOutputGetter) (cal
 	return
 }
 
+// storesErr returns the first error of the backends of the stores among `arguments`.
+func storesErr(arguments []wasm.Argument) error {
+	for _, argument := range arguments {
+		var s store.Store
+		switch v := argument.(type) {
+		case *wasm.StoreWriterOutput:
+			s = v.Store
+		case *wasm.StoreReaderInput:
+			s = v.Store
+		default:
+			continue
+		}
+		if err := s.Err(); err != nil {
+			return fmt.Errorf("store %q: %w", s.Name(), err)
+		}
+	}
+	return nil
+}
+
 func (e *BaseExecutor) Close(ctx context.Context) error {
 	if e.cachedInstance != nil {
 		return e.cachedInstance.Close(ctx)
@@ -115,7 +177,7 @@ func (e *BaseExecutor) Close(ctx context.Context) error {
 	return nil
 }
 
-func (e *BaseExecutor) lastExecutionLogs() (logs []string, truncated bool) {
+func (e *BaseExecutor) lastExecutionLogs() (logs []wasm.Log, truncated bool) {
 	return e.logs, e.logsTruncated
 }
 func (e *BaseExecutor) lastExecutionStack() []string {
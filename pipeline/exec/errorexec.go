@@ -2,16 +2,31 @@ package exec
 
 import "bytes"
 
+// An ErrorExecutor is the deterministic failure of the execution of a module,
+// on a panic or a trap, with what the execution logged and its WASM stack trace.
 type ErrorExecutor struct {
-	message    string
-	stackTrace []string
+	message        string
+	stackTrace     []string
+	logs           []string
+	logsTruncated  bool
+	wasmStackTrace []string
 }
 
 func (e *ErrorExecutor) Error() string {
 	b := bytes.NewBuffer(nil)
 
+	b.WriteString(ErrWasmDeterministicExec.Error())
+	b.WriteString(": ")
 	b.WriteString(e.message)
 
+	if len(e.wasmStackTrace) > 0 {
+		b.WriteString("\n----- wasm stack trace -----\n")
+		for _, frame := range e.wasmStackTrace {
+			b.WriteString(frame)
+			b.WriteString("\n")
+		}
+	}
+
 	if len(e.stackTrace) > 0 {
 		// stack trace section will also contain the logs of the execution
 		b.WriteString("\n----- stack trace -----\n")
@@ -23,3 +38,21 @@ func (e *ErrorExecutor) Error() string {
 
 	return b.String()
 }
+
+func (e *ErrorExecutor) Unwrap() error {
+	return ErrWasmDeterministicExec
+}
+
+func (e *ErrorExecutor) Reason() string {
+	return e.message
+}
+
+func (e *ErrorExecutor) Logs() (logs []string, truncated bool) {
+	return e.logs, e.logsTruncated
+}
+
+// WASMStackTrace returns the frames of the WASM stack when the module trapped,
+// innermost first, empty when the failure isn't a trap.
+func (e *ErrorExecutor) WASMStackTrace() []string {
+	return e.wasmStackTrace
+}
@@ -0,0 +1,34 @@
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorExecutor(t *testing.T) {
+	failure := &ErrorExecutor{
+		message:        "wasm trap: unreachable",
+		stackTrace:     []string{"log: starting"},
+		logs:           []string{"starting"},
+		wasmStackTrace: []string{"boom at src/lib.rs:14:5", "run"},
+	}
+	err := fmt.Errorf("block 10: module %q: %w", "map_transfers", failure)
+
+	assert.True(t, errors.Is(err, ErrWasmDeterministicExec))
+	assert.Equal(t, `block 10: module "map_transfers": wasm execution failed deterministically: wasm trap: unreachable
+----- wasm stack trace -----
+boom at src/lib.rs:14:5
+run
+
+----- stack trace -----
+log: starting
+`, err.Error())
+
+	var unwrapped *ErrorExecutor
+	assert.True(t, errors.As(err, &unwrapped))
+	assert.Equal(t, "wasm trap: unreachable", unwrapped.Reason())
+	assert.Equal(t, []string{"boom at src/lib.rs:14:5", "run"}, unwrapped.WASMStackTrace())
+}
@@ -5,6 +5,7 @@ import (
 
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
 	"github.com/streamingfast/substreams/storage/execout"
+	"github.com/streamingfast/substreams/wasm"
 )
 
 type ModuleExecutor interface {
@@ -17,6 +18,6 @@ type ModuleExecutor interface {
 	toModuleOutput(data []byte) (*pbssinternal.ModuleOutput, error)
 	HasValidOutput() bool
 
-	lastExecutionLogs() (logs []string, truncated bool)
+	lastExecutionLogs() (logs []wasm.Log, truncated bool)
 	lastExecutionStack() []string
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/streamingfast/substreams/reqctx"
 
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	"github.com/streamingfast/substreams/wasm"
 )
 
 func RunModule(ctx context.Context, executor ModuleExecutor, execOutput execout.ExecutionOutputGetter) (*pbssinternal.ModuleOutput, []byte, error) {
@@ -73,7 +74,31 @@ func fillModuleOutputMetadata(executor ModuleExecutor, in *pbssinternal.ModuleOu
 	logs, truncated := executor.lastExecutionLogs()
 
 	in.ModuleName = executor.Name()
-	in.Logs = logs
+	in.Logs, in.DebugLogsMetadata = toModuleOutputLogs(logs)
 	in.DebugLogsTruncated = truncated
 	return
 }
+
+// toModuleOutputLogs returns the messages of `logs` and their metadata, which is
+// omitted when they are all of level info without fields, as logged by `println`.
+func toModuleOutputLogs(logs []wasm.Log) (messages []string, metadata []*pbssinternal.LogMetadata) {
+	plain := true
+	for _, log := range logs {
+		messages = append(messages, log.Message)
+		if log.Level != wasm.LogLevelInfo || len(log.Fields) != 0 {
+			plain = false
+		}
+	}
+	if plain {
+		return messages, nil
+	}
+
+	for _, log := range logs {
+		logMetadata := &pbssinternal.LogMetadata{Level: pbssinternal.LogMetadata_Level(log.Level)}
+		for _, field := range log.Fields {
+			logMetadata.Fields = append(logMetadata.Fields, &pbssinternal.LogField{Key: field.Key, Value: field.Value})
+		}
+		metadata = append(metadata, logMetadata)
+	}
+	return messages, metadata
+}
@@ -10,6 +10,7 @@ import (
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/storage/execout"
+	"github.com/streamingfast/substreams/wasm"
 )
 
 type MockExecOutput struct {
@@ -41,7 +42,7 @@ type MockModuleExecutor struct {
 
 	RunFunc      func(ctx context.Context, reader execout.ExecutionOutputGetter) (out []byte, moduleOutputData *pbssinternal.ModuleOutput, err error)
 	ApplyFunc    func(value []byte) error
-	LogsFunc     func() (logs []string, truncated bool)
+	LogsFunc     func() (logs []wasm.Log, truncated bool)
 	StackFunc    func() []string
 	ToOutputFunc func(data []byte) (*pbssinternal.ModuleOutput, error)
 	cacheable    bool
@@ -75,7 +76,7 @@ func (t *MockModuleExecutor) toModuleOutput(data []byte) (*pbssinternal.ModuleOu
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (t *MockModuleExecutor) lastExecutionLogs() (logs []string, truncated bool) {
+func (t *MockModuleExecutor) lastExecutionLogs() (logs []wasm.Log, truncated bool) {
 	if t.LogsFunc != nil {
 		return t.LogsFunc()
 	}
@@ -100,8 +101,8 @@ func TestModuleExecutorRunner_Run_HappyPath(t *testing.T) {
 				},
 			}, nil
 		},
-		LogsFunc: func() (logs []string, truncated bool) {
-			return []string{"test"}, false
+		LogsFunc: func() (logs []wasm.Log, truncated bool) {
+			return []wasm.Log{{Level: wasm.LogLevelInfo, Message: "test"}}, false
 		},
 	}
 	output := &MockExecOutput{
@@ -142,8 +143,8 @@ func TestModuleExecutorRunner_Run_CachedOutput(t *testing.T) {
 			applied = true
 			return nil
 		},
-		LogsFunc: func() (logs []string, truncated bool) {
-			return []string{"test"}, false
+		LogsFunc: func() (logs []wasm.Log, truncated bool) {
+			return []wasm.Log{{Level: wasm.LogLevelInfo, Message: "test"}}, false
 		},
 	}
 	output := &MockExecOutput{
@@ -162,3 +163,25 @@ func TestModuleExecutorRunner_Run_CachedOutput(t *testing.T) {
 	assert.NotEmpty(t, moduleOutput)
 	assert.True(t, moduleOutput.Cached)
 }
+
+func TestToModuleOutputLogs(t *testing.T) {
+	messages, metadata := toModuleOutputLogs([]wasm.Log{
+		{Level: wasm.LogLevelInfo, Message: "plain"},
+		{Level: wasm.LogLevelInfo, Message: "other"},
+	})
+	assert.Equal(t, []string{"plain", "other"}, messages)
+	assert.Nil(t, metadata)
+
+	messages, metadata = toModuleOutputLogs([]wasm.Log{
+		{Level: wasm.LogLevelInfo, Message: "plain"},
+		{Level: wasm.LogLevelWarn, Message: "leveled", Fields: []wasm.LogField{{Key: "block", Value: "10"}}},
+	})
+	assert.Equal(t, []string{"plain", "leveled"}, messages)
+	if assert.Len(t, metadata, 2) {
+		assert.Equal(t, pbssinternal.LogMetadata_INFO, metadata[0].Level)
+		assert.Empty(t, metadata[0].Fields)
+		assert.Equal(t, pbssinternal.LogMetadata_WARN, metadata[1].Level)
+		assert.Equal(t, "block", metadata[1].Fields[0].Key)
+		assert.Equal(t, "10", metadata[1].Fields[0].Value)
+	}
+}
@@ -33,6 +33,9 @@ func (e *StoreModuleExecutor) applyCachedOutput(value []byte) error {
 		return fmt.Errorf("unmarshalling output deltas: %w", err)
 	}
 	e.outputStore.SetDeltas(deltas.StoreDeltas)
+	if closable, ok := e.outputStore.(store.Closable); ok && closable.Err() != nil {
+		return fmt.Errorf("applying output deltas: %w", closable.Err())
+	}
 	return nil
 }
 
@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/substreams/index"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/execout"
+)
+
+// moduleFilter decides whether a module runs on a given block. A module is
+// skipped when its own block filter doesn't match the keys produced by its
+// index module, or when one of the modules it consumes was itself skipped.
+type moduleFilter struct {
+	indexModule string
+	query       *index.Query
+	valueInputs []string
+}
+
+// buildModuleFilters returns a filter for every module that could be skipped,
+// `stages` being walked in execution order so that dependencies are known first.
+// Modules that always run are not part of the returned map.
+func buildModuleFilters(stages [][]*pbsubstreams.Module) (map[string]*moduleFilter, error) {
+	out := map[string]*moduleFilter{}
+	for _, stage := range stages {
+		for _, module := range stage {
+			filter := &moduleFilter{}
+			skippable := false
+
+			if blockFilter := module.BlockFilter; blockFilter != nil {
+				query, err := index.ParseQuery(blockFilter.Query)
+				if err != nil {
+					return nil, fmt.Errorf("module %q: invalid block filter query: %w", module.Name, err)
+				}
+				filter.indexModule = blockFilter.Module
+				filter.query = query
+				skippable = true
+			}
+
+			for _, input := range module.Inputs {
+				var name string
+				switch in := input.Input.(type) {
+				case *pbsubstreams.Module_Input_Map_:
+					name = in.Map.ModuleName
+				case *pbsubstreams.Module_Input_Store_:
+					if in.Store.Mode != pbsubstreams.Module_Input_Store_DELTAS {
+						continue
+					}
+					name = in.Store.ModuleName
+				default:
+					continue
+				}
+
+				filter.valueInputs = append(filter.valueInputs, name)
+				if out[name] != nil {
+					skippable = true
+				}
+			}
+
+			if skippable {
+				out[module.Name] = filter
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *moduleFilter) shouldSkip(execOutput execout.ExecutionOutputGetter) (bool, error) {
+	for _, name := range f.valueInputs {
+		if _, _, err := execOutput.Get(name); err == execout.NotFound {
+			return true, nil
+		}
+	}
+
+	if f.query == nil {
+		return false, nil
+	}
+
+	data, _, err := execOutput.Get(f.indexModule)
+	if err == execout.NotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting index %q output: %w", f.indexModule, err)
+	}
+
+	keys, err := index.DecodeKeys(data)
+	if err != nil {
+		return false, fmt.Errorf("index %q: %w", f.indexModule, err)
+	}
+	return !f.query.Matches(keys), nil
+}
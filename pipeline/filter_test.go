@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pbindex "github.com/streamingfast/substreams/pb/sf/substreams/index/v1"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/execout"
+)
+
+func Test_moduleFilters(t *testing.T) {
+	idx := &pbsubstreams.Module{Name: "idx", Kind: &pbsubstreams.Module_KindBlockIndex_{}}
+	filtered := &pbsubstreams.Module{
+		Name:        "filtered",
+		Kind:        &pbsubstreams.Module_KindMap_{},
+		BlockFilter: &pbsubstreams.Module_BlockFilter{Module: "idx", Query: "transfer && !approval"},
+	}
+	downstream := &pbsubstreams.Module{
+		Name: "downstream",
+		Kind: &pbsubstreams.Module_KindStore_{},
+		Inputs: []*pbsubstreams.Module_Input{
+			{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "filtered"}}},
+		},
+	}
+	unfiltered := &pbsubstreams.Module{
+		Name: "unfiltered",
+		Kind: &pbsubstreams.Module_KindMap_{},
+		Inputs: []*pbsubstreams.Module_Input{
+			{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.test.Block"}}},
+		},
+	}
+
+	filters, err := buildModuleFilters([][]*pbsubstreams.Module{{idx, unfiltered, filtered}, {downstream}})
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	assert.Nil(t, filters["unfiltered"])
+
+	tests := []struct {
+		name       string
+		keys       []string
+		setOutputs []string
+		module     string
+		expectSkip bool
+	}{
+		{"matching keys", []string{"transfer"}, nil, "filtered", false},
+		{"excluded key", []string{"transfer", "approval"}, nil, "filtered", true},
+		{"no keys", nil, nil, "filtered", true},
+		{"input present", []string{"transfer"}, []string{"filtered"}, "downstream", false},
+		{"input skipped", []string{"transfer"}, nil, "downstream", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf, err := execout.NewBuffer("sf.test.Block", nil, &pbsubstreams.Clock{Number: 1, Id: "1a"})
+			require.NoError(t, err)
+
+			keys, err := proto.Marshal(&pbindex.Keys{Keys: test.keys})
+			require.NoError(t, err)
+			require.NoError(t, buf.Set("idx", keys))
+			for _, name := range test.setOutputs {
+				require.NoError(t, buf.Set(name, []byte{}))
+			}
+
+			skip, err := filters[test.module].shouldSkip(buf)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectSkip, skip)
+		})
+	}
+}
@@ -13,6 +13,7 @@ type Graph struct {
 	stagedUsedModules [][]*pbsubstreams.Module // all modules that need to be processed (requested directly or a required module ancestor)
 	moduleHashes      *manifest.ModuleHashes
 	stores            []*pbsubstreams.Module // subset of allModules: only the stores
+	indexes           []*pbsubstreams.Module // subset of allModules: only the block indexes
 
 	outputModule *pbsubstreams.Module
 
@@ -22,6 +23,7 @@ type Graph struct {
 
 func (g *Graph) OutputModule() *pbsubstreams.Module          { return g.outputModule }
 func (g *Graph) Stores() []*pbsubstreams.Module              { return g.stores }
+func (g *Graph) IndexModules() []*pbsubstreams.Module        { return g.indexes }
 func (g *Graph) UsedModules() []*pbsubstreams.Module         { return g.usedModules }
 func (g *Graph) StagedUsedModules() [][]*pbsubstreams.Module { return g.stagedUsedModules }
 func (g *Graph) IsOutputModule(name string) bool             { return g.outputModule.Name == name }
@@ -51,6 +53,7 @@ func (g *Graph) computeGraph(outputModule string, productionMode bool, modules *
 	}
 	g.usedModules = processModules
 	g.stagedUsedModules = computeStages(processModules)
+	g.indexes = computeIndexModules(processModules)
 
 	if err := g.hashModules(graph); err != nil {
 		return fmt.Errorf("cannot hash module: %w", err)
@@ -86,7 +89,7 @@ func computeStages(mods []*pbsubstreams.Module) (stages [][]*pbsubstreams.Module
 	modLoop:
 		for _, mod := range mods {
 			switch mod.Kind.(type) {
-			case *pbsubstreams.Module_KindMap_:
+			case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindBlockIndex_:
 				if i%2 == 0 {
 					continue
 				}
@@ -100,6 +103,10 @@ func computeStages(mods []*pbsubstreams.Module) (stages [][]*pbsubstreams.Module
 				continue
 			}
 
+			if filter := mod.BlockFilter; filter != nil && !seen[filter.Module] {
+				continue
+			}
+
 			for _, dep := range mod.Inputs {
 				var depModName string
 				switch input := dep.Input.(type) {
@@ -131,6 +138,15 @@ func computeStages(mods []*pbsubstreams.Module) (stages [][]*pbsubstreams.Module
 	return stages
 }
 
+func computeIndexModules(mods []*pbsubstreams.Module) (out []*pbsubstreams.Module) {
+	for _, mod := range mods {
+		if mod.GetKindBlockIndex() != nil {
+			out = append(out, mod)
+		}
+	}
+	return
+}
+
 func computeOutputModule(mods []*pbsubstreams.Module, outputModule string) *pbsubstreams.Module {
 	for _, module := range mods {
 		if module.Name == outputModule {
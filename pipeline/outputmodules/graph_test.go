@@ -54,3 +54,17 @@ func TestGraph_computeSchedulableModules(t *testing.T) {
 	}
 
 }
+
+func TestGraph_computeStages_withBlockFilter(t *testing.T) {
+	idx := &pbsubstreams.Module{Name: "idx", Kind: &pbsubstreams.Module_KindBlockIndex_{}}
+	storeA := pbsubstreamsrpc.TestNewStoreModule("store_a")
+	mapA := pbsubstreamsrpc.TestNewMapModule("map_a")
+	mapA.BlockFilter = &pbsubstreams.Module_BlockFilter{Module: "idx", Query: "a"}
+	storeA.Inputs = []*pbsubstreams.Module_Input{
+		{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "map_a"}}},
+	}
+
+	stages := computeStages([]*pbsubstreams.Module{storeA, mapA, idx})
+
+	assert.Equal(t, [][]*pbsubstreams.Module{{idx}, {mapA}, {storeA}}, stages)
+}
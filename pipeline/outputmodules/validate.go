@@ -7,6 +7,7 @@ import (
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
 	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/wasm"
 )
 
 // Deprecated: use ValidateTier1Request
@@ -84,8 +85,8 @@ func validateModuleGraph(mods []*pbsubstreams.Module, outputModule string, block
 
 func validateBinaryTypes(bins []*pbsubstreams.Binary) error {
 	for _, binary := range bins {
-		if binary.Type != "wasm/rust-v1" {
-			return fmt.Errorf(`unsupported binary type: %q, please use "wasm/rust-v1"`, binary.Type)
+		if !wasm.IsBinaryTypeSupported(binary.Type) {
+			return fmt.Errorf(`unsupported binary type: %q, please use %q`, binary.Type, wasm.WASMBinaryType)
 		}
 	}
 	return nil
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/stream"
 
 	"github.com/streamingfast/substreams"
 	"github.com/streamingfast/substreams/orchestrator"
@@ -45,6 +47,7 @@ type Pipeline struct {
 	outputGraph     *outputmodules.Graph
 	loadedModules   map[uint32]wasm.Module
 	moduleExecutors [][]exec.ModuleExecutor
+	moduleFilters   map[string]*moduleFilter
 
 	mapModuleOutput         *pbsubstreamsrpc.MapModuleOutput
 	extraMapModuleOutputs   []*pbsubstreamsrpc.MapModuleOutput
@@ -262,6 +265,7 @@ func toRPCStoreModuleOutputs(in *pbssinternal.ModuleOutput) (out *pbsubstreamsrp
 			Logs:          in.Logs,
 			LogsTruncated: in.DebugLogsTruncated,
 			Cached:        in.Cached,
+			LogsMetadata:  toRPCLogsMetadata(in.DebugLogsMetadata),
 		},
 	}
 }
@@ -284,6 +288,23 @@ func toRPCDeltas(in *pbssinternal.StoreDeltas) (out []*pbsubstreamsrpc.StoreDelt
 	return
 }
 
+func toRPCLogsMetadata(in []*pbssinternal.LogMetadata) (out []*pbsubstreamsrpc.LogMetadata) {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out = make([]*pbsubstreamsrpc.LogMetadata, len(in))
+	for i, m := range in {
+		out[i] = &pbsubstreamsrpc.LogMetadata{
+			Level: pbsubstreamsrpc.LogMetadata_Level(m.Level),
+		}
+		for _, field := range m.Fields {
+			out[i].Fields = append(out[i].Fields, &pbsubstreamsrpc.LogField{Key: field.Key, Value: field.Value})
+		}
+	}
+	return
+}
+
 func toRPCOperation(in pbssinternal.StoreDelta_Operation) (out pbsubstreamsrpc.StoreDelta_Operation) {
 	switch in {
 	case pbssinternal.StoreDelta_UPDATE:
@@ -308,6 +329,7 @@ func toRPCMapModuleOutputs(in *pbssinternal.ModuleOutput) (out *pbsubstreamsrpc.
 			Logs:          in.Logs,
 			LogsTruncated: in.DebugLogsTruncated,
 			Cached:        in.Cached,
+			LogsMetadata:  toRPCLogsMetadata(in.DebugLogsMetadata),
 		},
 	}
 }
@@ -359,15 +381,86 @@ func (p *Pipeline) returnInternalModuleProgressOutputs(clock *pbsubstreams.Clock
 	return nil
 }
 
+// returnFailureProgress sends the failure of `executor` when its execution failed
+// deterministically, with the logs and the WASM stack trace of the execution, before
+// the error ending the request.
+func (p *Pipeline) returnFailureProgress(ctx context.Context, err error, executor exec.ModuleExecutor) {
+	var failure *exec.ErrorExecutor
+	if p.respFunc == nil || !errors.As(err, &failure) {
+		return
+	}
+	logs, logsTruncated := failure.Logs()
+
+	var resp substreams.ResponseFromAnyTier
+	if reqctx.Details(ctx).IsSubRequest {
+		resp = &pbssinternal.ProcessRangeResponse{
+			ModuleName: executor.Name(),
+			Type: &pbssinternal.ProcessRangeResponse_Failed{
+				Failed: &pbssinternal.Failed{
+					Reason:        failure.Reason(),
+					Logs:          logs,
+					LogsTruncated: logsTruncated,
+					StackTrace:    failure.WASMStackTrace(),
+				},
+			},
+		}
+	} else {
+		resp = substreams.NewModulesProgressResponse([]*pbsubstreamsrpc.ModuleProgress{
+			{
+				Name: executor.Name(),
+				Type: &pbsubstreamsrpc.ModuleProgress_Failed_{
+					Failed: &pbsubstreamsrpc.ModuleProgress_Failed{
+						Reason:        failure.Reason(),
+						Logs:          logs,
+						LogsTruncated: logsTruncated,
+						StackTrace:    failure.WASMStackTrace(),
+					},
+				},
+			},
+		})
+	}
+
+	if err := p.respFunc(resp); err != nil {
+		reqctx.Logger(ctx).Warn("sending failure progress", zap.String("module_name", executor.Name()), zap.Error(err))
+	}
+}
+
 // TODO(abourget): have this being generated and the `buildWASM` by taking
 // this Graph as input, and creating the ModuleExecutors, and caching
 // them over there.
 // moduleExecutorsInitialized bool
 // moduleExecutors            []exec.ModuleExecutor
+// checkFuelMetering refuses modules declaring a fuel limit that the runtime
+// executing them wouldn't enforce, rather than silently ignoring it. The
+// server's maximum, applying to every module, is only enforced by the runtimes
+// metering fuel.
+func checkFuelMetering(wasmRuntime *wasm.Registry, module *pbsubstreams.Module, binaryType string) error {
+	if module.GetLimits().GetMaxFuelPerBlock() == 0 || wasmRuntime.MetersFuel(binaryType) {
+		return nil
+	}
+	if binaryType != wasm.WASMBinaryType {
+		return stream.NewErrInvalidArg("module %q: limit maxFuelPerBlock is not enforced for binaries of type %q, remove it", module.Name, binaryType)
+	}
+	return stream.NewErrInvalidArg("module %q: limit maxFuelPerBlock is not enforced by the %q wasm runtime of this server, remove it or use a server running a runtime metering fuel (wasmtime)", module.Name, wasmRuntime.RuntimeName())
+}
+
 func (p *Pipeline) buildWASM(ctx context.Context, stages [][]*pbsubstreams.Module) error {
 	reqModules := reqctx.Details(ctx).Modules
 	tracer := otel.GetTracerProvider().Tracer("executor")
 
+	limits := make(map[string]*pbsubstreams.Module_Limits)
+	for _, stage := range stages {
+		for _, module := range stage {
+			if err := checkFuelMetering(p.wasmRuntime, module, reqModules.Binaries[module.BinaryIndex].Type); err != nil {
+				return err
+			}
+			limits[module.Name] = p.runtimeConfig.ModuleLimits(module)
+			if limits[module.Name].MaxFuelPerBlock != 0 {
+				p.wasmRuntime.EnableFuelMetering()
+			}
+		}
+	}
+
 	loadedModules := make(map[uint32]wasm.Module)
 	for _, stage := range stages {
 		for _, module := range stage {
@@ -375,7 +468,7 @@ func (p *Pipeline) buildWASM(ctx context.Context, stages [][]*pbsubstreams.Modul
 				continue
 			}
 			code := reqModules.Binaries[module.BinaryIndex]
-			m, err := p.wasmRuntime.NewModule(ctx, code.Content)
+			m, err := p.wasmRuntime.NewBinaryModule(ctx, code.Type, code.Content)
 			if err != nil {
 				return fmt.Errorf("new wasm module: %w", err)
 			}
@@ -397,15 +490,17 @@ func (p *Pipeline) buildWASM(ctx context.Context, stages [][]*pbsubstreams.Modul
 			mod := loadedModules[module.BinaryIndex]
 
 			switch kind := module.Kind.(type) {
-			case *pbsubstreams.Module_KindMap_:
+			case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindBlockIndex_:
 				outType := strings.TrimPrefix(module.Output.Type, "proto:")
 				baseExecutor := exec.NewBaseExecutor(
 					ctx,
 					module.Name,
 					mod,
 					p.wasmRuntime.InstanceCacheEnabled(),
+					p.wasmRuntime.InstancePoolingEnabled(),
 					inputs,
 					entrypoint,
+					limits[module.Name],
 					tracer,
 				)
 				executor := exec.NewMapperModuleExecutor(baseExecutor, outType)
@@ -426,8 +521,10 @@ func (p *Pipeline) buildWASM(ctx context.Context, stages [][]*pbsubstreams.Modul
 					module.Name,
 					mod,
 					p.wasmRuntime.InstanceCacheEnabled(),
+					p.wasmRuntime.InstancePoolingEnabled(),
 					inputs,
 					entrypoint,
+					limits[module.Name],
 					tracer,
 				)
 				executor := exec.NewStoreModuleExecutor(baseExecutor, outputStore)
@@ -440,7 +537,13 @@ func (p *Pipeline) buildWASM(ctx context.Context, stages [][]*pbsubstreams.Modul
 		stagedModuleExecutors = append(stagedModuleExecutors, moduleExecutors)
 	}
 
+	moduleFilters, err := buildModuleFilters(stages)
+	if err != nil {
+		return fmt.Errorf("building module filters: %w", err)
+	}
+
 	p.moduleExecutors = stagedModuleExecutors
+	p.moduleFilters = moduleFilters
 	return nil
 }
 
@@ -473,7 +576,11 @@ func (p *Pipeline) renderWasmInputs(module *pbsubstreams.Module) (out []wasm.Arg
 	for _, input := range module.Inputs {
 		switch in := input.Input.(type) {
 		case *pbsubstreams.Module_Input_Params_:
-			out = append(out, wasm.NewParamsInput(input.GetParams().GetValue()))
+			if in.Params.Type != "" {
+				out = append(out, wasm.NewParamsInput(string(in.Params.TypedValue)))
+			} else {
+				out = append(out, wasm.NewParamsInput(in.Params.Value))
+			}
 		case *pbsubstreams.Module_Input_Map_:
 			out = append(out, wasm.NewMapInput(in.Map.ModuleName))
 		case *pbsubstreams.Module_Input_Store_:
@@ -93,11 +93,13 @@ func mapTestExecutor(t *testing.T, name string) *exec.MapperModuleExecutor {
 			name,
 			module,
 			false, // could exercice with cache enabled too
+			false,
 			[]wasm.Argument{
 				wasm.NewParamsInput("my test params"),
 				wasm.NewSourceInput("sf.substreams.v1.test.Block"),
 			},
 			name,
+			nil,
 			otel.GetTracerProvider().Tracer("test"),
 		),
 		"",
@@ -239,3 +241,53 @@ func (cr *testCursorResolver) resolveCursor(_ context.Context, cursor *bstream.C
 	}
 	return resp.lastValid, resp.currentHead, resp.err
 }
+
+func TestCheckFuelMetering(t *testing.T) {
+	tests := []struct {
+		name       string
+		runtime    string
+		binaryType string
+		maxFuel    uint64
+		expectErr  string
+	}{
+		{
+			name:       "no fuel limit",
+			runtime:    "wazero",
+			binaryType: wasm.WASMBinaryType,
+		},
+		{
+			name:       "runtime metering fuel",
+			runtime:    "wasmtime",
+			binaryType: wasm.WASMBinaryType,
+			maxFuel:    1000,
+		},
+		{
+			name:       "runtime not metering fuel",
+			runtime:    "wazero",
+			binaryType: wasm.WASMBinaryType,
+			maxFuel:    1000,
+			expectErr:  `module "test_map": limit maxFuelPerBlock is not enforced by the "wazero" wasm runtime of this server, remove it or use a server running a runtime metering fuel (wasmtime)`,
+		},
+		{
+			name:       "native binary",
+			runtime:    "wasmtime",
+			binaryType: "native/go",
+			maxFuel:    1000,
+			expectErr:  `module "test_map": limit maxFuelPerBlock is not enforced for binaries of type "native/go", remove it`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SUBSTREAMS_WASM_RUNTIME", test.runtime)
+			module := &pbsubstreams.Module{Name: "test_map", Limits: &pbsubstreams.Module_Limits{MaxFuelPerBlock: test.maxFuel}}
+
+			err := checkFuelMetering(wasm.NewRegistry(nil, 0), module, test.binaryType)
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
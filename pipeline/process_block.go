@@ -229,7 +229,8 @@ func (p *Pipeline) handleStepNew(ctx context.Context, block *bstream.Block, cloc
 		}
 	}
 
-	if p.gate.shouldSendOutputs() {
+	// A nil output means the output module was filtered out on this block, nothing is sent for it.
+	if p.gate.shouldSendOutputs() && p.mapModuleOutput != nil {
 		logger.Debug("will return module outputs")
 		if p.pendingUndoMessage != nil {
 			if err := p.respFunc(p.pendingUndoMessage); err != nil {
@@ -269,6 +270,7 @@ func (p *Pipeline) executeModules(ctx context.Context, execOutput execout.Execut
 			for _, executor := range stage {
 				res := p.execute(ctx, executor, execOutput)
 				if err := p.applyExecutionResult(ctx, executor, res, execOutput); err != nil {
+					p.returnFailureProgress(ctx, err, executor)
 					return fmt.Errorf("applying executor results %q: %w", executor.Name(), res.err)
 				}
 			}
@@ -291,7 +293,7 @@ func (p *Pipeline) executeModules(ctx context.Context, execOutput execout.Execut
 			for i, result := range results {
 				executor := stage[i]
 				if result.err != nil {
-					//p.returnFailureProgress(ctx, err, executor)
+					p.returnFailureProgress(ctx, result.err, executor)
 					return fmt.Errorf("running executor %q: %w", executor.Name(), result.err)
 				}
 				if err := p.applyExecutionResult(ctx, executor, result, execOutput); err != nil {
@@ -306,25 +308,41 @@ func (p *Pipeline) executeModules(ctx context.Context, execOutput execout.Execut
 }
 
 type resultObj struct {
-	output *pbssinternal.ModuleOutput
-	bytes  []byte
-	err    error
+	output  *pbssinternal.ModuleOutput
+	bytes   []byte
+	err     error
+	skipped bool
 }
 
 func (p *Pipeline) execute(ctx context.Context, executor exec.ModuleExecutor, execOutput execout.ExecutionOutput) resultObj {
 	logger := reqctx.Logger(ctx)
 
 	executorName := executor.Name()
+	if filter := p.moduleFilters[executorName]; filter != nil {
+		skip, err := filter.shouldSkip(execOutput)
+		if err != nil {
+			return resultObj{err: fmt.Errorf("evaluating block filter: %w", err)}
+		}
+		if skip {
+			logger.Debug("skipping", zap.Uint64("block", execOutput.Clock().Number), zap.String("module_name", executorName))
+			return resultObj{skipped: true}
+		}
+	}
+
 	logger.Debug("executing", zap.Uint64("block", execOutput.Clock().Number), zap.String("module_name", executorName))
 
 	moduleOutput, outputBytes, runError := exec.RunModule(ctx, executor, execOutput)
-	return resultObj{moduleOutput, outputBytes, runError}
+	return resultObj{output: moduleOutput, bytes: outputBytes, err: runError}
 }
 
 func (p *Pipeline) applyExecutionResult(ctx context.Context, executor exec.ModuleExecutor, res resultObj, execOutput execout.ExecutionOutput) (err error) {
 	executorName := executor.Name()
 	hasValidOutput := executor.HasValidOutput()
 
+	if res.skipped {
+		return nil
+	}
+
 	moduleOutput, outputBytes, runError := res.output, res.bytes, res.err
 	if runError != nil {
 		if hasValidOutput {
@@ -11,6 +11,9 @@ import (
 	"github.com/streamingfast/substreams/reqctx"
 )
 
+// snapshotPageSize is the number of keys sent in each `InitialSnapshotData` message.
+const snapshotPageSize = 100
+
 func (p *Pipeline) sendSnapshots(ctx context.Context, storeMap store.Map) error {
 	if reqctx.Details(ctx).IsSubRequest {
 		return nil
@@ -40,7 +43,8 @@ func (p *Pipeline) sendSnapshots(ctx context.Context, storeMap store.Map) error
 		total := store.Length()
 		var accum []*pbsubstreamsrpc.StoreDelta
 
-		store.Iter(func(k string, v []byte) error {
+		// Keys are iterated in order, so that the snapshot pages are the same from one request to the other.
+		err := store.Iter(func(k string, v []byte) error {
 			count++
 			accum = append(accum, &pbsubstreamsrpc.StoreDelta{
 				Operation: pbsubstreamsrpc.StoreDelta_CREATE,
@@ -48,12 +52,15 @@ func (p *Pipeline) sendSnapshots(ctx context.Context, storeMap store.Map) error
 				NewValue:  v,
 			})
 
-			if count%100 == 0 {
+			if count%snapshotPageSize == 0 {
 				send(count, total, accum)
 				accum = nil
 			}
 			return nil
 		})
+		if err != nil {
+			return fmt.Errorf("iterating store %q: %w", modName, err)
+		}
 
 		if len(accum) != 0 {
 			send(count, total, accum)
@@ -39,6 +39,18 @@ func (s *Stores) SetStoreMap(storeMap store.Map) {
 	s.StoreMap = storeMap
 }
 
+// Close releases the backends of the stores, logging their errors.
+func (s *Stores) Close(ctx context.Context) {
+	if s.StoreMap == nil {
+		return
+	}
+	for name, oneStore := range s.StoreMap.All() {
+		if err := oneStore.Close(); err != nil {
+			reqctx.Logger(ctx).Warn("closing store", zap.String("store", name), zap.Error(err))
+		}
+	}
+}
+
 func (s *Stores) resetStores() {
 	for _, s := range s.StoreMap.All() {
 		if resetableStore, ok := s.(store.Resettable); ok {
@@ -4,6 +4,9 @@ import (
 	"github.com/streamingfast/dstore"
 
 	"github.com/streamingfast/substreams/orchestrator/work"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/wasm"
 )
 
 // RuntimeConfig is a global configuration for the service.
@@ -19,6 +22,19 @@ type RuntimeConfig struct {
 	// and `outputs/` for execution output of both `map` and `store` module kinds
 	BaseObjectStore dstore.Store
 	WorkerFactory   work.WorkerFactory
+	// StoreKVBackend, when set, creates the backends holding the stores' state,
+	// which are kept in memory otherwise.
+	StoreKVBackend store.KVBackendFactory
+
+	// Maximums of the resource limits of modules, replacing the unset ones, see
+	// `ModuleLimits`. 0 means no limit, the log bytes being always limited.
+	MaxLogBytes      uint64
+	MaxStoreSize     uint64
+	MaxStoreItemSize uint64
+
+	// VerificationWASMRuntime, when set, is the WASM runtime executing each call a
+	// second time to compare its results, see `wasm.Registry.EnableRuntimeVerification`.
+	VerificationWASMRuntime string
 
 	WithRequestStats       bool
 	ModuleExecutionTracing bool
@@ -41,7 +57,29 @@ func NewRuntimeConfig(
 		MaxWasmFuel:          maxWasmFuel,
 		BaseObjectStore:      baseObjectStore,
 		WorkerFactory:        workerFactory,
+		MaxLogBytes:          wasm.MaxLogByteCount,
+		MaxStoreSize:         store.DefaultMaxStoreSize,
+		MaxStoreItemSize:     store.DefaultMaxStoreItemSize,
 		// overridden by Tier Options
 		ModuleExecutionTracing: false,
 	}
 }
+
+// ModuleLimits returns the resource limits `module` is executed with: the ones
+// it declares capped by the maximums of the config, which replace the unset ones.
+func (c RuntimeConfig) ModuleLimits(module *pbsubstreams.Module) *pbsubstreams.Module_Limits {
+	declared := module.GetLimits()
+	return &pbsubstreams.Module_Limits{
+		MaxFuelPerBlock:  capLimit(declared.GetMaxFuelPerBlock(), c.MaxWasmFuel),
+		MaxLogBytes:      capLimit(declared.GetMaxLogBytes(), c.MaxLogBytes),
+		MaxStoreSize:     capLimit(declared.GetMaxStoreSize(), c.MaxStoreSize),
+		MaxStoreItemSize: capLimit(declared.GetMaxStoreItemSize(), c.MaxStoreItemSize),
+	}
+}
+
+func capLimit(declared, max uint64) uint64 {
+	if declared == 0 || (max != 0 && declared > max) {
+		return max
+	}
+	return declared
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/wasm"
+)
+
+func TestRuntimeConfig_ModuleLimits(t *testing.T) {
+	defaults := &pbsubstreams.Module_Limits{
+		MaxLogBytes:      wasm.MaxLogByteCount,
+		MaxStoreSize:     store.DefaultMaxStoreSize,
+		MaxStoreItemSize: store.DefaultMaxStoreItemSize,
+	}
+
+	tests := []struct {
+		name     string
+		config   func(c *RuntimeConfig)
+		declared *pbsubstreams.Module_Limits
+		expected *pbsubstreams.Module_Limits
+	}{
+		{
+			name:     "unset",
+			expected: defaults,
+		},
+		{
+			name:     "lower than maximums",
+			declared: &pbsubstreams.Module_Limits{MaxFuelPerBlock: 10, MaxLogBytes: 1024, MaxStoreSize: 2048, MaxStoreItemSize: 512},
+			expected: &pbsubstreams.Module_Limits{MaxFuelPerBlock: 10, MaxLogBytes: 1024, MaxStoreSize: 2048, MaxStoreItemSize: 512},
+		},
+		{
+			name:     "capped by maximums",
+			config:   func(c *RuntimeConfig) { c.MaxWasmFuel = 100 },
+			declared: &pbsubstreams.Module_Limits{MaxFuelPerBlock: 1000, MaxLogBytes: 1 << 30, MaxStoreSize: 1 << 40, MaxStoreItemSize: 1 << 30},
+			expected: &pbsubstreams.Module_Limits{MaxFuelPerBlock: 100, MaxLogBytes: wasm.MaxLogByteCount, MaxStoreSize: store.DefaultMaxStoreSize, MaxStoreItemSize: store.DefaultMaxStoreItemSize},
+		},
+		{
+			name:     "unlimited maximums",
+			config:   func(c *RuntimeConfig) { c.MaxStoreSize = 0 },
+			declared: &pbsubstreams.Module_Limits{MaxStoreSize: 1 << 40},
+			expected: &pbsubstreams.Module_Limits{MaxLogBytes: wasm.MaxLogByteCount, MaxStoreSize: 1 << 40, MaxStoreItemSize: store.DefaultMaxStoreItemSize},
+		},
+		{
+			name:     "store kv backend",
+			config:   func(c *RuntimeConfig) { c.StoreKVBackend = store.NewDiskKVBackendFactory("") },
+			expected: &pbsubstreams.Module_Limits{MaxLogBytes: wasm.MaxLogByteCount, MaxStoreSize: store.DefaultMaxStoreSize, MaxStoreItemSize: store.DefaultMaxStoreItemSize},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewRuntimeConfig(1000, 10, 1, 10, 0, nil, nil)
+			if test.config != nil {
+				test.config(&c)
+			}
+			assert.Equal(t, test.expected, c.ModuleLimits(&pbsubstreams.Module{Name: "mod", Limits: test.declared}))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/stream"
+	"github.com/streamingfast/dstore"
+	tracing "github.com/streamingfast/sf-tracing"
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/orchestrator/work"
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	"github.com/streamingfast/substreams/pipeline/outputmodules"
+	"github.com/streamingfast/substreams/reqctx"
+	"github.com/streamingfast/substreams/service/config"
+)
+
+// LocalService runs requests in-process, without any server: blocks are read
+// from the merged blocks files of a store, and the back-processing jobs of the
+// tier1 pipeline are executed by a tier2 pipeline in the same process.
+//
+// All blocks read from files being final, requests with a non-final cursor or
+// a negative start block are refused.
+type LocalService struct {
+	tier1 *Tier1Service
+	tier2 *Tier2Service
+}
+
+func NewLocal(
+	stateStore dstore.Store,
+	mergedBlocksStore dstore.Store,
+	blockType string,
+	parallelSubRequests uint64,
+	subrequestSplitSize uint64,
+	opts ...Option,
+) *LocalService {
+	initLocalBlockReader()
+
+	noLiveFeed := func() (uint64, error) { return 0, fmt.Errorf("no live feed in local mode") }
+	streamFactory := &LocalStreamFactory{mergedBlocksStore: mergedBlocksStore}
+
+	tier2 := &Tier2Service{
+		blockType:         blockType,
+		streamFactoryFunc: streamFactory.New,
+		runtimeConfig:     config.NewRuntimeConfig(1000, 0, 0, 0, 0, stateStore, nil),
+		tracer:            tracing.GetTracer(),
+		logger:            zlog,
+	}
+
+	tier1 := &Tier1Service{
+		blockType:         blockType,
+		streamFactoryFunc: streamFactory.New,
+		tracer:            tracing.GetTracer(),
+		logger:            zlog,
+		failedRequests:    make(map[string]*recordedFailure),
+		runtimeConfig: config.NewRuntimeConfig(
+			1000, // overridden by Options
+			subrequestSplitSize,
+			parallelSubRequests,
+			10,
+			0,
+			stateStore,
+			func(logger *zap.Logger) work.Worker {
+				return work.NewLocalWorker(func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) error {
+					return tier2.processRange(ctx, request, respFunc, tracing.GetTraceID(ctx).String())
+				}, logger)
+			},
+		),
+		getRecentFinalBlock: noLiveFeed,
+		getHeadBlock:        noLiveFeed,
+		resolveCursor: func(ctx context.Context, cursor *bstream.Cursor) (bstream.BlockRef, bstream.BlockRef, error) {
+			return nil, nil, fmt.Errorf("only cursors on final blocks can be resolved in local mode")
+		},
+	}
+
+	for _, opt := range opts {
+		opt(tier1)
+		opt(tier2)
+	}
+
+	return &LocalService{
+		tier1: tier1,
+		tier2: tier2,
+	}
+}
+
+// Blocks runs `request` to completion, sending the responses to `respFunc`,
+// which receives `*pbsubstreamsrpc.Response` messages.
+func (s *LocalService) Blocks(ctx context.Context, request *pbsubstreamsrpc.Request, respFunc substreams.ResponseFunc) error {
+	if request.StopBlockNum == 0 {
+		return stream.NewErrInvalidArg("a stop block is required in local mode")
+	}
+
+	logger := reqctx.Logger(ctx).Named("local")
+	ctx = reqctx.WithLogger(ctx, logger)
+	ctx = reqctx.WithTracer(ctx, s.tier1.tracer)
+	// Partial store files are named after the trace ID, it must be shared by tier1 and the jobs it runs.
+	ctx = tracing.WithTraceID(ctx, tracing.NewRandomTraceID())
+
+	if err := outputmodules.ValidateTier1Request(request, s.tier1.blockType); err != nil {
+		return stream.NewErrInvalidArg(fmt.Errorf("validate request: %w", err).Error())
+	}
+
+	outputGraph, err := outputmodules.NewOutputModuleGraph(request.OutputModule, request.ProductionMode, request.Modules)
+	if err != nil {
+		return stream.NewErrInvalidArg(err.Error())
+	}
+
+	return s.tier1.blocks(ctx, request, outputGraph, respFunc)
+}
+
+// LocalStreamFactory streams the final blocks of merged blocks files, it has
+// no live source to hand off to.
+type LocalStreamFactory struct {
+	mergedBlocksStore dstore.Store
+}
+
+func (sf *LocalStreamFactory) New(
+	ctx context.Context,
+	h bstream.Handler,
+	startBlockNum int64,
+	stopBlockNum uint64,
+	cursor string,
+	finalBlocksOnly bool,
+	cursorIsTarget bool,
+	logger *zap.Logger,
+) (Streamable, error) {
+	if startBlockNum < 0 {
+		return nil, stream.NewErrInvalidArg("negative start block %d is not supported in local mode", startBlockNum)
+	}
+	if cursor != "" {
+		// Cursors on final blocks are resolved to their next block before reaching here.
+		return nil, stream.NewErrInvalidArg("cursor %q cannot be used in local mode", cursor)
+	}
+
+	var options []bstream.FileSourceOption
+	if stopBlockNum != 0 {
+		options = append(options, bstream.FileSourceWithStopBlock(stopBlockNum))
+	}
+
+	return &localStream{
+		source: bstream.NewFileSource(sf.mergedBlocksStore, uint64(startBlockNum), h, logger, options...),
+	}, nil
+}
+
+type localStream struct {
+	source *bstream.FileSource
+}
+
+func (s *localStream) Run(ctx context.Context) error {
+	go func() {
+		select {
+		case <-s.source.Terminated():
+		case <-ctx.Done():
+			s.source.Shutdown(ctx.Err())
+		}
+	}()
+
+	s.source.Run()
+	if err := s.source.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return io.EOF
+}
+
+var initLocalBlockReaderOnce sync.Once
+
+// initLocalBlockReader registers a reader of merged blocks files keeping the
+// payload of blocks as raw bytes, unless a chain specific one already was.
+func initLocalBlockReader() {
+	initLocalBlockReaderOnce.Do(func() {
+		if bstream.GetBlockReaderFactory == nil {
+			bstream.GetBlockReaderFactory = bstream.BlockReaderFactoryFunc(func(reader io.Reader) (bstream.BlockReader, error) {
+				return bstream.NewDBinBlockReader(reader, nil)
+			})
+		}
+		if bstream.GetBlockPayloadSetter == nil {
+			bstream.GetBlockPayloadSetter = bstream.MemoryBlockPayloadSetter
+		}
+	})
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"crypto/ed25519"
+
 	"github.com/streamingfast/substreams/pipeline"
+	"github.com/streamingfast/substreams/storage/store"
 	"github.com/streamingfast/substreams/wasm"
 )
 
@@ -68,6 +71,74 @@ func WithMaxWasmFuelPerBlockModule(maxFuel uint64) Option {
 	}
 }
 
+// WithMaxModuleLogBytes caps the bytes of logs modules keep per block, 128KiB by
+// default, see `config.RuntimeConfig.ModuleLimits`.
+func WithMaxModuleLogBytes(maxBytes uint64) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.MaxLogBytes = maxBytes
+		case *Tier2Service:
+			s.runtimeConfig.MaxLogBytes = maxBytes
+		}
+	}
+}
+
+// WithMaxStoreSize caps the bytes store modules can hold, 1GiB by default, 0
+// lifting the limit, see `config.RuntimeConfig.ModuleLimits`.
+func WithMaxStoreSize(maxBytes uint64) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.MaxStoreSize = maxBytes
+		case *Tier2Service:
+			s.runtimeConfig.MaxStoreSize = maxBytes
+		}
+	}
+}
+
+// WithMaxStoreItemSize caps the bytes of a single value of store modules, 10MiB
+// by default, 0 lifting the limit, see `config.RuntimeConfig.ModuleLimits`.
+func WithMaxStoreItemSize(maxBytes uint64) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.MaxStoreItemSize = maxBytes
+		case *Tier2Service:
+			s.runtimeConfig.MaxStoreItemSize = maxBytes
+		}
+	}
+}
+
+// WithStoreKVBackend makes the stores hold their state in the backends created
+// by `factory` instead of memory, see `store.NewDiskKVBackendFactory`. Their
+// size is still capped by WithMaxStoreSize.
+func WithStoreKVBackend(factory store.KVBackendFactory) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.StoreKVBackend = factory
+		case *Tier2Service:
+			s.runtimeConfig.StoreKVBackend = factory
+		}
+	}
+}
+
+// WithWASMRuntimeVerification executes each module call in `runtime` as well as in
+// the runtime selected by `SUBSTREAMS_WASM_RUNTIME`, failing the requests on the
+// first call whose output, store deltas or logs differ between them. It doubles
+// the cost of the executions and is meant to validate runtime upgrades.
+func WithWASMRuntimeVerification(runtime string) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.VerificationWASMRuntime = runtime
+		case *Tier2Service:
+			s.runtimeConfig.VerificationWASMRuntime = runtime
+		}
+	}
+}
+
 func WithModuleExecutionTracing() Option {
 	return func(a anyTierService) {
 		switch s := a.(type) {
@@ -78,3 +149,14 @@ func WithModuleExecutionTracing() Option {
 		}
 	}
 }
+
+// WithRequiredPackageSignatures makes tier1 reject the requests of packages not signed
+// by one of `trustedKeys`, see `substreams sign`.
+func WithRequiredPackageSignatures(trustedKeys []ed25519.PublicKey) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.trustedPackageKeys = trustedKeys
+		}
+	}
+}
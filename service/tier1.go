@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"os"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/streamingfast/substreams"
 	"github.com/streamingfast/substreams/client"
+	"github.com/streamingfast/substreams/manifest"
 	"github.com/streamingfast/substreams/metrics"
 	"github.com/streamingfast/substreams/orchestrator/work"
 	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
@@ -53,6 +55,7 @@ type Tier1Service struct {
 	runtimeConfig      config.RuntimeConfig
 	tracer             ttrace.Tracer
 	logger             *zap.Logger
+	trustedPackageKeys []ed25519.PublicKey
 
 	getRecentFinalBlock func() (uint64, error)
 	resolveCursor       pipeline.CursorResolver
@@ -186,6 +189,12 @@ func (s *Tier1Service) Blocks(request *pbsubstreamsrpc.Request, streamSrv pbsubs
 		return toGRPCError(stream.NewErrInvalidArg(fmt.Errorf("validate request: %w", err).Error()))
 	}
 
+	if len(s.trustedPackageKeys) != 0 {
+		if err := manifest.VerifyModules(request.Modules, request.PackageMetadataDigest, request.PackageSignatures, s.trustedPackageKeys); err != nil {
+			return status.Error(codes.PermissionDenied, fmt.Sprintf("package signature: %s", err))
+		}
+	}
+
 	outputGraph, err := outputmodules.NewOutputModuleGraph(request.OutputModule, request.ProductionMode, request.Modules)
 	if err != nil {
 		return stream.NewErrInvalidArg(err.Error())
@@ -261,6 +270,11 @@ func (s *Tier1Service) blocks(ctx context.Context, request *pbsubstreamsrpc.Requ
 	}
 
 	wasmRuntime := wasm.NewRegistry(s.wasmExtensions, s.runtimeConfig.MaxWasmFuel)
+	if s.runtimeConfig.VerificationWASMRuntime != "" {
+		if err := wasmRuntime.EnableRuntimeVerification(s.runtimeConfig.VerificationWASMRuntime); err != nil {
+			return fmt.Errorf("wasm runtime verification: %w", err)
+		}
+	}
 
 	execOutputConfigs, err := execout.NewConfigs(s.runtimeConfig.BaseObjectStore, outputGraph.UsedModules(), outputGraph.ModuleHashes(), s.runtimeConfig.CacheSaveInterval, logger)
 	if err != nil {
@@ -271,8 +285,16 @@ func (s *Tier1Service) blocks(ctx context.Context, request *pbsubstreamsrpc.Requ
 	if err != nil {
 		return fmt.Errorf("configuring stores: %w", err)
 	}
+	if s.runtimeConfig.StoreKVBackend != nil {
+		storeConfigs.SetKVBackend(s.runtimeConfig.StoreKVBackend)
+	}
+	for _, storeModule := range outputGraph.Stores() {
+		limits := s.runtimeConfig.ModuleLimits(storeModule)
+		storeConfigs[storeModule.Name].SetLimits(limits.MaxStoreSize, limits.MaxStoreItemSize)
+	}
 
 	stores := pipeline.NewStores(storeConfigs, s.runtimeConfig.CacheSaveInterval, requestDetails.LinearHandoffBlockNum, request.StopBlockNum, false, "tier1")
+	defer stores.Close(ctx)
 
 	execOutputCacheEngine, err := cache.NewEngine(ctx, s.runtimeConfig, nil, s.blockType)
 	if err != nil {
@@ -186,6 +186,11 @@ func (s *Tier2Service) processRange(ctx context.Context, request *pbssinternal.P
 	}
 
 	wasmRuntime := wasm.NewRegistry(s.wasmExtensions, s.runtimeConfig.MaxWasmFuel)
+	if s.runtimeConfig.VerificationWASMRuntime != "" {
+		if err := wasmRuntime.EnableRuntimeVerification(s.runtimeConfig.VerificationWASMRuntime); err != nil {
+			return fmt.Errorf("wasm runtime verification: %w", err)
+		}
+	}
 
 	execOutputConfigs, err := execout.NewConfigs(s.runtimeConfig.BaseObjectStore, outputGraph.UsedModules(), outputGraph.ModuleHashes(), s.runtimeConfig.CacheSaveInterval, logger)
 	if err != nil {
@@ -196,7 +201,15 @@ func (s *Tier2Service) processRange(ctx context.Context, request *pbssinternal.P
 	if err != nil {
 		return fmt.Errorf("configuring stores: %w", err)
 	}
+	if s.runtimeConfig.StoreKVBackend != nil {
+		storeConfigs.SetKVBackend(s.runtimeConfig.StoreKVBackend)
+	}
+	for _, storeModule := range outputGraph.Stores() {
+		limits := s.runtimeConfig.ModuleLimits(storeModule)
+		storeConfigs[storeModule.Name].SetLimits(limits.MaxStoreSize, limits.MaxStoreItemSize)
+	}
 	stores := pipeline.NewStores(storeConfigs, s.runtimeConfig.CacheSaveInterval, requestDetails.ResolvedStartBlockNum, request.StopBlockNum, true, "tier2")
+	defer stores.Close(ctx)
 
 	// TODO(abourget): why would this start at the LinearHandoffBlockNum ?
 	//  * in direct mode, this would mean we start writing files after the handoff,
@@ -217,6 +230,17 @@ func (s *Tier2Service) processRange(ctx context.Context, request *pbssinternal.P
 		return fmt.Errorf("error building caching engine: %w", err)
 	}
 
+	// Block indexes computed by previous jobs let us skip executing, and even decoding, non-matching blocks.
+	var indexModuleNames []string
+	for _, mod := range outputGraph.IndexModules() {
+		if mod.Name != outputModule.Name {
+			indexModuleNames = append(indexModuleNames, mod.Name)
+		}
+	}
+	if err := execOutputCacheEngine.LoadCachedOutputs(ctx, execOutputConfigs, indexModuleNames, requestDetails.ResolvedStartBlockNum, request.StopBlockNum); err != nil {
+		return fmt.Errorf("loading cached block indexes: %w", err)
+	}
+
 	opts := s.buildPipelineOptions(ctx, request)
 	opts = append(opts, pipeline.WithFinalBlocksOnly())
 
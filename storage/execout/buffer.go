@@ -2,6 +2,7 @@ package execout
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/streamingfast/bstream"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -13,23 +14,30 @@ import (
 // as a sort of buffer.
 type Buffer struct {
 	values map[string][]byte
+	cached map[string]bool
 	clock  *pbsubstreams.Clock
+
+	// The block payload is only decoded when a module actually consumes it,
+	// so blocks on which every module is filtered out are never decoded.
+	blockType  string
+	block      *bstream.Block
+	blockOnce  sync.Once
+	blockBytes []byte
+	blockErr   error
 } // TODO(abourget): rename to `Buffer`
 
 func NewBuffer(blockType string, block *bstream.Block, clock *pbsubstreams.Clock) (*Buffer, error) {
-	blkBytes, err := block.Payload.Get()
-	if err != nil {
-		return nil, fmt.Errorf("getting block %d %q: %w", block.Number, block.Id, err)
-	}
 	clockBytes, err := proto.Marshal(clock)
 	if err != nil {
 		return nil, fmt.Errorf("marshalling clock %d %q: %w", clock.Number, clock.Id, err)
 	}
 
 	return &Buffer{
-		clock: clock,
+		clock:     clock,
+		blockType: blockType,
+		block:     block,
+		cached:    map[string]bool{},
 		values: map[string][]byte{
-			blockType:      blkBytes,
 			wasm.ClockType: clockBytes,
 		},
 	}, nil
@@ -40,14 +48,35 @@ func (i *Buffer) Clock() *pbsubstreams.Clock {
 }
 
 func (i *Buffer) Get(moduleName string) (value []byte, cached bool, err error) {
+	if moduleName == i.blockType {
+		return i.getBlock()
+	}
+
 	val, found := i.values[moduleName]
 	if !found {
 		return nil, false, NotFound
 	}
-	return val, false, nil
+	return val, i.cached[moduleName], nil
+}
+
+func (i *Buffer) getBlock() ([]byte, bool, error) {
+	i.blockOnce.Do(func() {
+		i.blockBytes, i.blockErr = i.block.Payload.Get()
+		if i.blockErr != nil {
+			i.blockErr = fmt.Errorf("getting block %d %q: %w", i.block.Number, i.block.Id, i.blockErr)
+		}
+	})
+	return i.blockBytes, false, i.blockErr
 }
 
 func (i *Buffer) Set(moduleName string, value []byte) (err error) {
 	i.values[moduleName] = value
 	return nil
 }
+
+// SetCached sets a value loaded from a previously written execution output
+// file, so the module producing it is not executed again for this block.
+func (i *Buffer) SetCached(moduleName string, value []byte) {
+	i.values[moduleName] = value
+	i.cached[moduleName] = true
+}
@@ -24,12 +24,14 @@ func (s *SnapshotsMap) String() string {
 	return strings.Join(out, ", ")
 }
 
+// FetchMappersState lists the execout snapshots of the output module. Only
+// the output module is tracked: intermediate mappers are re-executed by the
+// tier2 jobs that need them. Returns an empty map when the output module has
+// no execout config.
 func FetchMappersState(ctx context.Context, configs *execout.Configs, outputModule string) (*SnapshotsMap, error) {
-
 	config := configs.ConfigMap[outputModule]
-
 	if config == nil {
-		return nil, nil
+		return &SnapshotsMap{Snapshots: map[string]block.Ranges{}}, nil
 	}
 
 	snapshots, err := listSnapshots(ctx, config)
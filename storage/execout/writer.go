@@ -16,23 +16,23 @@ import (
 // Those files will then be read by the LinearExecOutReader.
 // `initialBlockBoundary` is expected to be on a boundary, or to be
 // modules' initial blocks.
+//
+// Outputs of `blockIndex` modules are always written alongside the output
+// module's, so later requests can consult them without executing anything.
 type Writer struct {
 	wg *sync.WaitGroup
 
-	files        map[string]*File // moduleName => file
-	outputModule string
-	configs      *Configs
+	files   map[string]*File // moduleName => file
+	configs *Configs
 }
 
 func NewWriter(initialBlockBoundary, exclusiveEndBlock uint64, outputModule string, configs *Configs, isSubRequest bool) *Writer {
 	w := &Writer{
-		wg:           &sync.WaitGroup{},
-		files:        make(map[string]*File),
-		configs:      configs,
-		outputModule: outputModule,
+		wg:      &sync.WaitGroup{},
+		files:   make(map[string]*File),
+		configs: configs,
 	}
 
-	modInitBlock := configs.ConfigMap[outputModule].ModuleInitialBlock()
 	var upperBound uint64
 	if isSubRequest {
 		upperBound = exclusiveEndBlock
@@ -40,29 +40,39 @@ func NewWriter(initialBlockBoundary, exclusiveEndBlock uint64, outputModule stri
 		// Push to the next boundary, so nothing would get flushed at the requested stop block boundary.
 		upperBound = exclusiveEndBlock - exclusiveEndBlock%configs.execOutputSaveInterval + configs.execOutputSaveInterval
 	}
-	targetRange := block.NewBoundedRange(modInitBlock, configs.execOutputSaveInterval, initialBlockBoundary, upperBound)
-	newFile := configs.NewFile(outputModule, targetRange)
-	w.files[outputModule] = newFile
+
+	for name, config := range configs.ConfigMap {
+		if name != outputModule {
+			if config.ModuleKind() != pbsubstreams.ModuleKindBlockIndex || config.ModuleInitialBlock() >= upperBound {
+				continue
+			}
+		}
+		targetRange := block.NewBoundedRange(config.ModuleInitialBlock(), configs.execOutputSaveInterval, initialBlockBoundary, upperBound)
+		w.files[name] = configs.NewFile(name, targetRange)
+	}
 
 	return w
 }
 
 func (w *Writer) Write(clock *pbsubstreams.Clock, buffer *Buffer) {
-	if val, found := buffer.values[w.outputModule]; found {
-		// TODO(abourget): triple check that we don't want to write
-		// if not found?
-		curFile, found := w.files[w.outputModule]
-		if found {
+	for name, curFile := range w.files {
+		// Modules filtered out on this block have no value, nothing is written for them.
+		if val, found := buffer.values[name]; found {
 			curFile.SetItem(clock, val)
 		}
 	}
 }
 
 func (w *Writer) MaybeRotate(ctx context.Context, clockNumber uint64) error {
-	curFile := w.files[w.outputModule]
-	if curFile == nil {
-		return nil
+	for name, curFile := range w.files {
+		if err := w.maybeRotateFile(ctx, name, curFile, clockNumber); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+func (w *Writer) maybeRotateFile(ctx context.Context, name string, curFile *File, clockNumber uint64) error {
 	if curFile.IsOutOfBounds(clockNumber) { // bounds are per file, because module init are per module
 		doSave, err := curFile.Save(ctx)
 		if err != nil {
@@ -92,9 +102,9 @@ func (w *Writer) MaybeRotate(ctx context.Context, clockNumber uint64) error {
 		}
 
 		if curFile == nil {
-			delete(w.files, w.outputModule)
+			delete(w.files, name)
 		} else {
-			w.files[w.outputModule] = curFile
+			w.files[name] = curFile
 		}
 	}
 	return nil
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"errors"
+	"sort"
+)
+
+// KVBackend holds the key space of a store, the state once all deltas have
+// been applied. The default backend keeps everything in memory, see
+// `NewDiskKVBackendFactory` for one keeping the key space on local disk.
+//
+// The values returned by a backend are owned by the caller. The store keeps the
+// first error of its backend, see `baseStore.Err`, for the operations that
+// can't return one.
+type KVBackend interface {
+	Get(key string) (value []byte, found bool, err error)
+	Set(key string, value []byte) error
+	Delete(key string) error
+	Len() int
+	// Iter calls `f` for every key, in no particular order, `f` not modifying the backend.
+	Iter(f func(key string, value []byte) error) error
+	// IterFrom calls `f` for every key from `startKey` inclusively, in ascending
+	// order, until `f` returns an error, `f` not modifying the backend.
+	IterFrom(startKey string, f func(key string, value []byte) error) error
+	// Close releases the resources of the backend, which can't be used afterwards.
+	Close() error
+}
+
+// KVBackendFactory creates an empty backend for the store named `storeName`.
+type KVBackendFactory func(storeName string) KVBackend
+
+// memoryKV is the default backend, a plain map.
+type memoryKV map[string][]byte
+
+func newMemoryKV(string) KVBackend { return memoryKV{} }
+
+func (m memoryKV) Get(key string) ([]byte, bool, error) {
+	val, found := m[key]
+	return val, found, nil
+}
+
+func (m memoryKV) Set(key string, value []byte) error {
+	m[key] = value
+	return nil
+}
+
+func (m memoryKV) Delete(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m memoryKV) Len() int     { return len(m) }
+func (m memoryKV) Close() error { return nil }
+
+func (m memoryKV) Iter(f func(key string, value []byte) error) error {
+	for k, v := range m {
+		if err := f(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m memoryKV) IterFrom(startKey string, f func(key string, value []byte) error) error {
+	var keys []string
+	for k := range m {
+		if k >= startKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := f(k, m[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errStopIter stops the iterations of the backends without failing them.
+var errStopIter = errors.New("stop iteration")
+
+// setErr keeps the first error of the backend, see Err.
+func (b *baseStore) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Err returns the first error of the store's backend, kept by the operations
+// that can't return it, like the getters and setters called by modules. The
+// state of the store is unreliable once it happened.
+func (b *baseStore) Err() error { return b.err }
+
+// Close releases the store's backend, the store can't be used afterwards.
+func (b *baseStore) Close() error {
+	return b.kv.Close()
+}
+
+// replaceKV makes `kv` the key space of the store, closing the previous one.
+func (b *baseStore) replaceKV(kv KVBackend) {
+	if b.kv != nil {
+		if err := b.kv.Close(); err != nil {
+			b.setErr(err)
+		}
+	}
+	b.kv = kv
+}
+
+func (b *baseStore) kvGet(key string) ([]byte, bool) {
+	val, found, err := b.kv.Get(key)
+	if err != nil {
+		b.setErr(err)
+		return nil, false
+	}
+	return val, found
+}
+
+func (b *baseStore) kvSet(key string, value []byte) {
+	if err := b.kv.Set(key, value); err != nil {
+		b.setErr(err)
+	}
+}
+
+func (b *baseStore) kvDelete(key string) {
+	if err := b.kv.Delete(key); err != nil {
+		b.setErr(err)
+	}
+}
+
+// kvIter is like KVBackend.Iter, keeping the error of the backend, not the ones of `f`.
+func (b *baseStore) kvIter(f func(key string, value []byte) error) error {
+	var fErr error
+	err := b.kv.Iter(func(key string, value []byte) error {
+		fErr = f(key, value)
+		return fErr
+	})
+	if err != nil && fErr == nil {
+		b.setErr(err)
+	}
+	return err
+}
+
+// kvIterFrom is like KVBackend.IterFrom, keeping the error of the backend, not the ones of `f`.
+func (b *baseStore) kvIterFrom(startKey string, f func(key string, value []byte) error) error {
+	var fErr error
+	err := b.kv.IterFrom(startKey, func(key string, value []byte) error {
+		fErr = f(key, value)
+		return fErr
+	})
+	if err != nil && fErr == nil {
+		b.setErr(err)
+	}
+	return err
+}
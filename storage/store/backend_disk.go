@@ -0,0 +1,200 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// diskKVCommitInterval is the number of writes after which the pending
+// transaction is committed, bounding the memory it holds.
+const diskKVCommitInterval = 10_000
+
+var diskKVBucket = []byte("kv")
+
+// NewDiskKVBackendFactory returns a factory of backends keeping the key space
+// in a B-tree stored in a file of `dir`, only the pages being accessed being
+// held in memory. Large stores then don't cost their size in memory.
+//
+// The files are unlinked as soon as they are opened, so they never outlive
+// the process, and their space is reclaimed once the store is closed.
+func NewDiskKVBackendFactory(dir string) KVBackendFactory {
+	return func(storeName string) KVBackend {
+		return &diskKV{
+			dir:  dir,
+			name: storeName,
+		}
+	}
+}
+
+// diskKV keeps its keys prefixed by a byte, bbolt refusing empty keys. The
+// file is only created on the first write.
+type diskKV struct {
+	dir  string
+	name string
+
+	db     *bolt.DB
+	tx     *bolt.Tx // pending writable transaction, every operation goes through it
+	writes int      // writes of `tx`
+	length int
+}
+
+func (d *diskKV) Get(key string) ([]byte, bool, error) {
+	if d.tx == nil {
+		return nil, false, nil
+	}
+
+	k, v := d.tx.Bucket(diskKVBucket).Cursor().Seek(diskKVKey(key))
+	if k == nil || !bytes.Equal(k[1:], []byte(key)) {
+		return nil, false, nil
+	}
+	return copyValue(v), true, nil
+}
+
+func (d *diskKV) Set(key string, value []byte) error {
+	if err := d.open(); err != nil {
+		return err
+	}
+
+	found, err := d.has(key)
+	if err != nil {
+		return err
+	}
+	// bbolt references the value until the transaction is committed.
+	if err := d.tx.Bucket(diskKVBucket).Put(diskKVKey(key), copyValue(value)); err != nil {
+		return fmt.Errorf("store %q: writing key %q to disk: %w", d.name, key, err)
+	}
+	if !found {
+		d.length++
+	}
+	return d.written()
+}
+
+func (d *diskKV) Delete(key string) error {
+	found, err := d.has(key)
+	if err != nil || !found {
+		return err
+	}
+
+	if err := d.tx.Bucket(diskKVBucket).Delete(diskKVKey(key)); err != nil {
+		return fmt.Errorf("store %q: deleting key %q from disk: %w", d.name, key, err)
+	}
+	d.length--
+	return d.written()
+}
+
+func (d *diskKV) Len() int { return d.length }
+
+func (d *diskKV) Iter(f func(key string, value []byte) error) error {
+	return d.IterFrom("", f)
+}
+
+func (d *diskKV) IterFrom(startKey string, f func(key string, value []byte) error) error {
+	if d.tx == nil {
+		return nil
+	}
+
+	cursor := d.tx.Bucket(diskKVBucket).Cursor()
+	for k, v := cursor.Seek(diskKVKey(startKey)); k != nil; k, v = cursor.Next() {
+		if err := f(string(k[1:]), copyValue(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *diskKV) Close() error {
+	if d.db == nil {
+		return nil
+	}
+
+	if err := d.tx.Rollback(); err != nil {
+		return fmt.Errorf("store %q: discarding disk backend transaction: %w", d.name, err)
+	}
+	d.tx = nil
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("store %q: closing disk backend: %w", d.name, err)
+	}
+	d.db = nil
+	return nil
+}
+
+func (d *diskKV) has(key string) (bool, error) {
+	_, found, err := d.Get(key)
+	return found, err
+}
+
+// written commits the pending transaction every `diskKVCommitInterval` writes.
+func (d *diskKV) written() error {
+	d.writes++
+	if d.writes < diskKVCommitInterval {
+		return nil
+	}
+
+	if err := d.tx.Commit(); err != nil {
+		return fmt.Errorf("store %q: committing to disk: %w", d.name, err)
+	}
+	return d.begin()
+}
+
+func (d *diskKV) open() error {
+	if d.db != nil {
+		return nil
+	}
+
+	file, err := os.CreateTemp(d.dir, "substreams-store-*.kv")
+	if err != nil {
+		return fmt.Errorf("store %q: creating disk backend file: %w", d.name, err)
+	}
+	path := file.Name()
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("store %q: creating disk backend file: %w", d.name, err)
+	}
+
+	// The content is disposable, nothing needs to survive a crash.
+	db, err := bolt.Open(path, 0600, &bolt.Options{NoSync: true, NoGrowSync: true, NoFreelistSync: true})
+	// The open file descriptor keeps the content reachable, and the space is reclaimed once it's closed.
+	if removeErr := os.Remove(path); err == nil && removeErr != nil {
+		db.Close()
+		return fmt.Errorf("store %q: unlinking disk backend file: %w", d.name, removeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("store %q: opening disk backend: %w", d.name, err)
+	}
+
+	d.db = db
+	if err := d.begin(); err != nil {
+		d.db.Close()
+		d.db = nil
+		return err
+	}
+	return nil
+}
+
+func (d *diskKV) begin() error {
+	tx, err := d.db.Begin(true)
+	if err != nil {
+		return fmt.Errorf("store %q: beginning disk backend transaction: %w", d.name, err)
+	}
+	if _, err := tx.CreateBucketIfNotExists(diskKVBucket); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("store %q: creating disk backend bucket: %w", d.name, err)
+	}
+	d.tx = tx
+	d.writes = 0
+	return nil
+}
+
+func diskKVKey(key string) []byte {
+	return append([]byte{0}, key...)
+}
+
+// copyValue copies a value, bbolt's memory being only valid during its
+// transaction, and not copying the values it's given.
+func copyValue(v []byte) []byte {
+	out := make([]byte, len(v))
+	copy(out, v)
+	return out
+}
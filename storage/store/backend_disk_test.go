@@ -0,0 +1,135 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskKV(t *testing.T) {
+	dir := t.TempDir()
+	kv := NewDiskKVBackendFactory(dir)("test")
+
+	_, found, err := kv.Get("a")
+	require.NoError(t, err)
+	assert.False(t, found)
+	require.NoError(t, kv.Delete("a"), "deleting before the first write")
+
+	require.NoError(t, kv.Set("a", []byte("value a")))
+	require.NoError(t, kv.Set("b", []byte("value b")))
+	require.NoError(t, kv.Set("", []byte("empty key")))
+	require.NoError(t, kv.Set("empty", nil))
+	require.NoError(t, kv.Set("a", []byte("new value a")))
+	require.NoError(t, kv.Delete("b"))
+	require.NoError(t, kv.Delete("unknown"))
+
+	value := []byte("c")
+	require.NoError(t, kv.Set("c", value))
+	value[0] = 'x'
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "backend file should be unlinked")
+
+	expected := []string{"", "a", "c", "empty"}
+	assertDiskKV(t, map[string][]byte{
+		"":      []byte("empty key"),
+		"a":     []byte("new value a"),
+		"c":     []byte("c"),
+		"empty": {},
+	}, expected, kv)
+
+	var fromA []string
+	require.NoError(t, kv.IterFrom("a\x00", func(key string, _ []byte) error {
+		fromA = append(fromA, key)
+		return nil
+	}))
+	assert.Equal(t, []string{"c", "empty"}, fromA)
+
+	stop := errors.New("stop")
+	assert.Equal(t, stop, kv.Iter(func(string, []byte) error { return stop }))
+
+	require.NoError(t, kv.Close())
+	require.NoError(t, kv.Close(), "closing twice")
+}
+
+func TestDiskKV_Commits(t *testing.T) {
+	kv := NewDiskKVBackendFactory(t.TempDir())("test")
+	defer kv.Close()
+
+	count := diskKVCommitInterval*2 + 10
+	for i := 0; i < count; i++ {
+		require.NoError(t, kv.Set(fmt.Sprintf("key%06d", i), []byte(fmt.Sprintf("value%d", i))))
+	}
+	for i := 0; i < count; i += 2 {
+		require.NoError(t, kv.Delete(fmt.Sprintf("key%06d", i)))
+	}
+
+	require.Equal(t, count/2, kv.Len())
+	value, found, err := kv.Get("key000001")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, []byte("value1"), value)
+
+	previous := ""
+	seen := 0
+	require.NoError(t, kv.Iter(func(key string, _ []byte) error {
+		assert.Greater(t, key, previous)
+		previous = key
+		seen++
+		return nil
+	}))
+	assert.Equal(t, count/2, seen)
+}
+
+func TestBaseStore_BackendErrors(t *testing.T) {
+	s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	backendErr := errors.New("disk full")
+	s.kv = &failingKV{KVBackend: memoryKV{}, err: backendErr}
+
+	s.Set(0, "key", "value")
+	assert.Equal(t, backendErr, s.Err())
+
+	_, err := s.marshalKV(nil, nil)
+	assert.ErrorIs(t, err, backendErr)
+}
+
+func assertDiskKV(t *testing.T, expected map[string][]byte, expectedKeys []string, kv KVBackend) {
+	t.Helper()
+
+	require.Equal(t, len(expected), kv.Len())
+	for k, v := range expected {
+		actual, found, err := kv.Get(k)
+		require.NoError(t, err)
+		require.True(t, found, "key %q", k)
+		require.Equal(t, v, actual, "key %q", k)
+	}
+
+	_, found, err := kv.Get("b")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	var keys []string
+	actual := map[string][]byte{}
+	require.NoError(t, kv.Iter(func(key string, value []byte) error {
+		keys = append(keys, key)
+		actual[key] = value
+		return nil
+	}))
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, expectedKeys, keys)
+}
+
+// failingKV fails all its writes with `err`.
+type failingKV struct {
+	KVBackend
+	err error
+}
+
+func (f *failingKV) Set(string, []byte) error { return f.err }
+func (f *failingKV) Delete(string) error      { return f.err }
@@ -1,8 +1,12 @@
 package store
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"os"
 
+	"github.com/streamingfast/derr"
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/storage/store/marshaller"
@@ -13,11 +17,12 @@ import (
 type baseStore struct {
 	*Config
 
-	kv             map[string][]byte          // kv is the state, and assumes all deltas were already applied to it.
+	kv             KVBackend                  // kv is the state, and assumes all deltas were already applied to it.
 	deltas         []*pbssinternal.StoreDelta // deltas are always deltas for the given block.
 	lastOrdinal    uint64
 	marshaller     marshaller.Marshaller
 	totalSizeBytes uint64
+	err            error // first error of `kv`, see Err
 
 	logger *zap.Logger
 }
@@ -34,7 +39,7 @@ func (b *baseStore) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("name", b.name)
 	enc.AddString("hash", b.moduleHash)
 	enc.AddUint64("module_initial_block", b.moduleInitialBlock)
-	enc.AddInt("key_count", len(b.kv))
+	enc.AddInt("key_count", b.kv.Len())
 	enc.AddUint64("total_size_bytes", b.totalSizeBytes)
 
 	return nil
@@ -42,7 +47,7 @@ func (b *baseStore) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 
 func (b *baseStore) Reset() {
 	if tracer.Enabled() {
-		b.logger.Debug("flushing store", zap.Int("delta_count", len(b.deltas)), zap.Int("entry_count", len(b.kv)), zap.Uint64("total_size_bytes", b.totalSizeBytes))
+		b.logger.Debug("flushing store", zap.Int("delta_count", len(b.deltas)), zap.Int("entry_count", b.kv.Len()), zap.Uint64("total_size_bytes", b.totalSizeBytes))
 	}
 	b.deltas = nil
 	b.lastOrdinal = 0
@@ -62,3 +67,124 @@ func (b *baseStore) ValueType() string {
 func (b *baseStore) UpdatePolicy() pbsubstreams.Module_KindStore_UpdatePolicy {
 	return b.updatePolicy
 }
+
+// loadKV replaces the key space with the content of `filename`, returning its
+// deleted prefixes and ranges. Backends other than the in-memory one are fed entry by
+// entry, without ever holding the whole file in memory.
+func (b *baseStore) loadKV(ctx context.Context, filename string) (deletedPrefixes []string, deletedRanges []*marshaller.DeleteRange, err error) {
+	kv := b.newKV()
+	if _, ok := kv.(memoryKV); ok {
+		data, err := loadStore(ctx, b.objStore, filename)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		storeData, size, err := b.marshaller.Unmarshal(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unmarshal store: %w", err)
+		}
+
+		if storeData.Kv == nil {
+			storeData.Kv = memoryKV{}
+		}
+		b.replaceKV(memoryKV(storeData.Kv))
+		b.totalSizeBytes = size
+		return storeData.DeletePrefixes, storeData.DeleteRanges, nil
+	}
+
+	var size uint64
+	err = derr.RetryContext(ctx, 5, func(ctx context.Context) error {
+		r, err := b.objStore.OpenObject(ctx, filename)
+		if err != nil {
+			return fmt.Errorf("opening file: %w", err)
+		}
+		defer r.Close()
+
+		if err := kv.Close(); err != nil {
+			return err
+		}
+		kv, size, deletedPrefixes, deletedRanges = b.newKV(), 0, nil, nil
+		var setErr error
+		err = marshaller.ReadStoreData(bufio.NewReader(r), func(key string, value []byte) {
+			if setErr == nil {
+				setErr = kv.Set(key, value)
+			}
+			size += uint64(len(key) + len(value))
+		}, func(prefix string) {
+			deletedPrefixes = append(deletedPrefixes, prefix)
+		}, func(deleteRange *marshaller.DeleteRange) {
+			deletedRanges = append(deletedRanges, deleteRange)
+		})
+		if err != nil {
+			return fmt.Errorf("reading data: %w", err)
+		}
+		if setErr != nil {
+			return fmt.Errorf("loading data: %w", setErr)
+		}
+		return nil
+	})
+	if err != nil {
+		kv.Close()
+		return nil, nil, err
+	}
+
+	b.replaceKV(kv)
+	b.totalSizeBytes = size
+	return deletedPrefixes, deletedRanges, nil
+}
+
+// marshalKV serializes the key space and deletions into a fileWriter,
+// whose `filename` is left to the caller. Backends other than the in-memory
+// one are serialized to a temporary file in the system's temp directory.
+func (b *baseStore) marshalKV(deletedPrefixes []string, deletedRanges []*marshaller.DeleteRange) (*fileWriter, error) {
+	if b.err != nil {
+		return nil, fmt.Errorf("store backend failed: %w", b.err)
+	}
+
+	if kv, ok := b.kv.(memoryKV); ok {
+		content, err := b.marshaller.Marshal(&marshaller.StoreData{
+			Kv:             kv,
+			DeletePrefixes: deletedPrefixes,
+			DeleteRanges:   deletedRanges,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &fileWriter{store: b.objStore, content: content}, nil
+	}
+
+	file, err := os.CreateTemp("", "substreams-store-*.kv")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file: %w", err)
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unlinking temporary file: %w", err)
+	}
+
+	w := marshaller.NewStoreDataWriter(file)
+	err = b.kvIter(func(key string, value []byte) error {
+		return w.WriteKV(key, value)
+	})
+	for _, prefix := range deletedPrefixes {
+		if err != nil {
+			break
+		}
+		err = w.WriteDeletePrefix(prefix)
+	}
+	for _, deleteRange := range deletedRanges {
+		if err != nil {
+			break
+		}
+		err = w.WriteDeleteRange(deleteRange)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("writing temporary file: %w", err)
+	}
+
+	return &fileWriter{store: b.objStore, contentFile: file}, nil
+}
@@ -12,6 +12,13 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// DefaultMaxStoreSize is the default maximum size of the keys and values of a store.
+	DefaultMaxStoreSize = 1_073_741_824 // 1GiB
+	// DefaultMaxStoreItemSize is the default maximum size of a store value.
+	DefaultMaxStoreItemSize = 10_485_760 // 10MiB
+)
+
 type Config struct {
 	name       string
 	moduleHash string
@@ -22,8 +29,10 @@ type Config struct {
 	valueType          string
 
 	appendLimit    uint64
-	totalSizeLimit uint64
-	itemSizeLimit  uint64
+	totalSizeLimit uint64 // 0 means no limit
+	itemSizeLimit  uint64 // 0 means no limit
+
+	kvFactory KVBackendFactory
 
 	// traceID uniquely identifies the connection ID so that store can be
 	// written to unique filename preventing some races when multiple Substreams
@@ -52,17 +61,38 @@ func NewConfig(
 		objStore:           subStore,
 		moduleInitialBlock: moduleInitialBlock,
 		moduleHash:         moduleHash,
-		appendLimit:        8_388_608,     // 8MiB = 8 * 1024 * 1024,
-		totalSizeLimit:     1_073_741_824, // 1GiB
-		itemSizeLimit:      10_485_760,    // 10MiB
+		appendLimit:        8_388_608, // 8MiB = 8 * 1024 * 1024,
+		totalSizeLimit:     DefaultMaxStoreSize,
+		itemSizeLimit:      DefaultMaxStoreItemSize,
 		traceID:            traceID,
+		kvFactory:          newMemoryKV,
 	}, nil
 }
 
+// SetKVBackend makes the stores created from this config hold their state in
+// backends built by `factory`, see SetLimits to bound their size.
+func (c *Config) SetKVBackend(factory KVBackendFactory) {
+	c.kvFactory = factory
+}
+
+// SetLimits sets the maximum size of the keys and values of the stores created
+// from this config, and of a single value, 0 meaning no limit.
+func (c *Config) SetLimits(totalSizeLimit, itemSizeLimit uint64) {
+	c.totalSizeLimit = totalSizeLimit
+	c.itemSizeLimit = itemSizeLimit
+}
+
+func (c *Config) newKV() KVBackend {
+	if c.kvFactory == nil {
+		return memoryKV{}
+	}
+	return c.kvFactory(c.name)
+}
+
 func (c *Config) newBaseStore(logger *zap.Logger) *baseStore {
 	return &baseStore{
 		Config:     c,
-		kv:         make(map[string][]byte),
+		kv:         c.newKV(),
 		logger:     logger.Named("store").With(zap.String("store_name", c.name), zap.String("module_hash", c.moduleHash)),
 		marshaller: marshaller.Default(),
 	}
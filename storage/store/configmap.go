@@ -29,3 +29,10 @@ func NewConfigMap(baseObjectStore dstore.Store, storeModules []*pbsubstreams.Mod
 	}
 	return out, nil
 }
+
+// SetKVBackend sets the backend factory of every config, see `Config.SetKVBackend`.
+func (m ConfigMap) SetKVBackend(factory KVBackendFactory) {
+	for _, c := range m {
+		c.SetKVBackend(factory)
+	}
+}
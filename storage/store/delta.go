@@ -20,7 +20,7 @@ func (b *baseStore) ApplyDelta(delta *pbssinternal.StoreDelta) {
 	keySize := uint64(len(delta.Key))
 	switch delta.Operation {
 	case pbssinternal.StoreDelta_UPDATE:
-		b.kv[delta.Key] = delta.NewValue
+		b.kvSet(delta.Key, delta.NewValue)
 		switch {
 		case newSize > oldSize:
 			b.totalSizeBytes += (newSize - oldSize)
@@ -29,19 +29,19 @@ func (b *baseStore) ApplyDelta(delta *pbssinternal.StoreDelta) {
 		}
 
 	case pbssinternal.StoreDelta_CREATE:
-		b.kv[delta.Key] = delta.NewValue
+		b.kvSet(delta.Key, delta.NewValue)
 		b.totalSizeBytes += newSize
 		b.totalSizeBytes += keySize
 
 	case pbssinternal.StoreDelta_DELETE:
-		delete(b.kv, delta.Key)
+		b.kvDelete(delta.Key)
 		b.totalSizeBytes -= oldSize
 		b.totalSizeBytes -= keySize
 		return
 	}
 
-	if b.totalSizeBytes > b.totalSizeLimit {
-		panic(fmt.Sprintf("store %q became too big at %d, maximum size: %d", b.Name(), b.totalSizeBytes, b.totalSizeLimit))
+	if b.totalSizeLimit != 0 && b.totalSizeBytes > b.totalSizeLimit {
+		panic(fmt.Sprintf("store %q became too big at %d bytes, exceeding the store size limit of %d bytes", b.Name(), b.totalSizeBytes, b.totalSizeLimit))
 	}
 }
 
@@ -54,7 +54,7 @@ func (b *baseStore) ApplyDeltasReverse(deltas []*pbssinternal.StoreDelta) {
 		keySize := uint64(len(delta.Key))
 		switch delta.Operation {
 		case pbssinternal.StoreDelta_UPDATE:
-			b.kv[delta.Key] = delta.OldValue
+			b.kvSet(delta.Key, delta.OldValue)
 			switch {
 			case newSize > oldSize:
 				b.totalSizeBytes -= (newSize - oldSize)
@@ -63,15 +63,14 @@ func (b *baseStore) ApplyDeltasReverse(deltas []*pbssinternal.StoreDelta) {
 			}
 
 		case pbssinternal.StoreDelta_CREATE:
-			delete(b.kv, delta.Key)
+			b.kvDelete(delta.Key)
 			b.totalSizeBytes -= newSize
 			b.totalSizeBytes -= keySize
 
 		case pbssinternal.StoreDelta_DELETE:
-			b.kv[delta.Key] = delta.OldValue
+			b.kvSet(delta.Key, delta.OldValue)
 			b.totalSizeBytes += oldSize
 			b.totalSizeBytes += keySize
-			return
 		}
 	}
 }
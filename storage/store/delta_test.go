@@ -84,20 +84,54 @@ func TestApplyDelta(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			s := &baseStore{
 				Config: baseStoreConfig,
-				kv:     make(map[string][]byte),
+				kv:     memoryKV{},
 			}
 			for _, delta := range test.deltas {
 				s.ApplyDelta(delta)
 			}
-			assert.Equal(t, test.expectedKV, s.kv)
+			assert.Equal(t, memoryKV(test.expectedKV), s.kv)
 		})
 	}
 }
 
+func TestApplyDeltasReverse(t *testing.T) {
+	s := &baseStore{
+		Config:         baseStoreConfig,
+		kv:             memoryKV{"A": []byte("a")},
+		totalSizeBytes: 2,
+	}
+	deltas := []*pbssinternal.StoreDelta{
+		{
+			Operation: pbssinternal.StoreDelta_CREATE,
+			Key:       "B",
+			NewValue:  []byte("b"),
+		},
+		{
+			Operation: pbssinternal.StoreDelta_UPDATE,
+			Key:       "B",
+			OldValue:  []byte("b"),
+			NewValue:  []byte("bb"),
+		},
+		{
+			Operation: pbssinternal.StoreDelta_DELETE,
+			Key:       "A",
+			OldValue:  []byte("a"),
+		},
+	}
+	for _, delta := range deltas {
+		s.ApplyDelta(delta)
+	}
+	assert.Equal(t, memoryKV{"B": []byte("bb")}, s.kv)
+
+	s.ApplyDeltasReverse(deltas)
+	assert.Equal(t, memoryKV{"A": []byte("a")}, s.kv)
+	assert.Equal(t, uint64(2), s.totalSizeBytes)
+}
+
 func Test_baseStore_SetDeltas(t *testing.T) {
 	s := baseStore{
 		Config:         baseStoreConfig,
-		kv:             map[string][]byte{"A": []byte("a")},
+		kv:             memoryKV{"A": []byte("a")},
 		totalSizeBytes: 2,
 	}
 	s.SetDeltas([]*pbssinternal.StoreDelta{
@@ -123,9 +157,9 @@ func Test_baseStore_SetDeltas(t *testing.T) {
 			NewValue:  []byte("d"),
 		},
 	})
-	assert.Len(t, s.kv, 2)
-	assert.Equal(t, "b", string(s.kv["B"]))
-	assert.Equal(t, "d", string(s.kv["C"]))
+	assert.Equal(t, 2, s.kv.Len())
+	assert.Equal(t, "b", string(s.kv.(memoryKV)["B"]))
+	assert.Equal(t, "d", string(s.kv.(memoryKV)["C"]))
 	assert.Equal(t, uint64(4), s.totalSizeBytes)
 	assert.Len(t, s.deltas, 4)
 }
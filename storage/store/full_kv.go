@@ -23,7 +23,7 @@ func (s *FullKV) Marshaller() marshaller.Marshaller {
 func (s *FullKV) DerivePartialStore(initialBlock uint64) *PartialKV {
 	b := &baseStore{
 		Config:     s.Config,
-		kv:         make(map[string][]byte),
+		kv:         s.newKV(),
 		logger:     s.logger,
 		marshaller: marshaller.Default(),
 	}
@@ -38,23 +38,11 @@ func (s *FullKV) Load(ctx context.Context, file *FileInfo) error {
 	s.loadedFrom = file.Filename
 	s.logger.Debug("loading full store state from file", zap.String("fileName", file.Filename))
 
-	data, err := loadStore(ctx, s.objStore, file.Filename)
-	if err != nil {
+	if _, _, err := s.loadKV(ctx, file.Filename); err != nil {
 		return fmt.Errorf("load full store %s at %s: %w", s.name, file.Filename, err)
 	}
 
-	storeData, size, err := s.marshaller.Unmarshal(data)
-	if err != nil {
-		return fmt.Errorf("unmarshal store: %w", err)
-	}
-
-	s.kv = storeData.Kv
-	s.totalSizeBytes = size
-	if s.kv == nil {
-		s.kv = make(map[string][]byte)
-	}
-
-	s.logger.Debug("full store loaded", zap.String("fileName", file.Filename), zap.Int("key_count", len(s.kv)), zap.Uint64("data_size", size))
+	s.logger.Debug("full store loaded", zap.String("fileName", file.Filename), zap.Int("key_count", s.kv.Len()), zap.Uint64("data_size", s.totalSizeBytes))
 	return nil
 }
 
@@ -64,11 +52,7 @@ func (s *FullKV) Load(ctx context.Context, file *FileInfo) error {
 func (s *FullKV) Save(endBoundaryBlock uint64) (*FileInfo, *fileWriter, error) {
 	s.logger.Debug("writing full store state", zap.Object("store", s))
 
-	stateData := &marshaller.StoreData{
-		Kv: s.kv,
-	}
-
-	content, err := s.marshaller.Marshal(stateData)
+	fw, err := s.marshalKV(nil, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("marshal kv state: %w", err)
 	}
@@ -80,23 +64,18 @@ func (s *FullKV) Save(endBoundaryBlock uint64) (*FileInfo, *fileWriter, error) {
 		zap.Object("block_range", file.Range),
 	)
 
-	fw := &fileWriter{
-		store:    s.objStore,
-		filename: file.Filename,
-		content:  content,
-	}
-
+	fw.filename = file.Filename
 	return file, fw, nil
 }
 
 func (s *FullKV) Reset() {
 	if tracer.Enabled() {
-		s.logger.Debug("flushing store", zap.Int("delta_count", len(s.deltas)), zap.Int("entry_count", len(s.kv)))
+		s.logger.Debug("flushing store", zap.Int("delta_count", len(s.deltas)), zap.Int("entry_count", s.kv.Len()))
 	}
 	s.deltas = nil
 	s.lastOrdinal = 0
 }
 
 func (s *FullKV) String() string {
-	return fmt.Sprintf("fullKV name %s moduleInitialBlock %d  keyCount %d loadFrom %s deltasCount %d", s.Name(), s.moduleInitialBlock, len(s.kv), s.loadedFrom, len(s.deltas))
+	return fmt.Sprintf("fullKV name %s moduleInitialBlock %d  keyCount %d loadFrom %s deltasCount %d", s.Name(), s.moduleInitialBlock, s.kv.Len(), s.loadedFrom, len(s.deltas))
 }
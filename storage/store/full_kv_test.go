@@ -24,7 +24,7 @@ func TestFullKV_Save_Load_Empty_MapNotNil(t *testing.T) {
 
 	kvs := &FullKV{
 		baseStore: &baseStore{
-			kv: map[string][]byte{},
+			kv: memoryKV{},
 
 			logger:     zap.NewNop(),
 			marshaller: marshaller.Default(),
@@ -44,7 +44,7 @@ func TestFullKV_Save_Load_Empty_MapNotNil(t *testing.T) {
 
 	kvl := &FullKV{
 		baseStore: &baseStore{
-			kv: map[string][]byte{},
+			kv: memoryKV{},
 
 			logger:     zap.NewNop(),
 			marshaller: marshaller.Default(),
@@ -31,7 +31,7 @@ func newTestBaseStore(
 	require.NoError(t, err)
 	return &baseStore{
 		Config:     config,
-		kv:         make(map[string][]byte),
+		kv:         memoryKV{},
 		logger:     zap.NewNop(),
 		marshaller: &marshaller.Binary{},
 	}
@@ -21,6 +21,7 @@ type Store interface {
 	Resettable
 	Mergeable
 	Named
+	Closable
 	// todoo: add fmt.Stringer ??
 
 	// intrinsics
@@ -67,9 +68,19 @@ type Named interface {
 	Name() string
 }
 
+// Closable stores hold resources in their backend, released by Close. Err
+// returns the first error of the backend, which the operations called by
+// modules can't return.
+type Closable interface {
+	Err() error
+	Close() error
+}
+
 type Iterable interface {
 	Length() uint64
+	// Iter and IterFrom go through the keys in ascending order.
 	Iter(func(key string, value []byte) error) error
+	IterFrom(startKey string, limit int, f func(key string, value []byte) error) error
 }
 
 type DeltaAccessor interface {
@@ -91,6 +102,10 @@ type Reader interface {
 	HasFirst(key string) bool
 	HasLast(key string) bool
 	HasAt(ord uint64, key string) bool
+
+	ScanFirst(keyRange KeyRange, limit int) (entries []*KeyValue, more bool)
+	ScanLast(keyRange KeyRange, limit int) (entries []*KeyValue, more bool)
+	ScanAt(ord uint64, keyRange KeyRange, limit int) (entries []*KeyValue, more bool)
 }
 
 type Mergeable interface {
@@ -114,10 +129,10 @@ type Appender interface {
 
 type Deleter interface {
 	DeletePrefix(ord uint64, prefix string)
-	//// Deletes a range of keys, lexicographically between `lowKey` and `highKey`
-	//DeleteRange(lowKey, highKey string)
-	//// Deletes a range of keys, first considering the _value_ of such keys as a _pointerSeparator_-separated list of keys to _also_ delete.
-	//DeleteRangePointers(lowKey, highKey, pointerSeparator string)
+	// Deletes a range of keys, lexicographically between `lowKey` (inclusive) and `highKey` (exclusive)
+	DeleteRange(ord uint64, lowKey, highKey string)
+	// Deletes a range of keys, first considering the _value_ of such keys as a _pointerSeparator_-separated list of keys to _also_ delete.
+	DeleteRangePointers(ord uint64, lowKey, highKey, pointerSeparator string)
 }
 
 type MaxBigIntSetter interface {
@@ -1,14 +1,28 @@
 package store
 
 func (b *baseStore) Length() uint64 {
-	return uint64(len(b.kv))
+	return uint64(b.kv.Len())
 }
 
+// Iter calls `f` for every key of the store, in ascending key order.
 func (b *baseStore) Iter(f func(key string, value []byte) error) error {
-	for k, v := range b.kv {
-		if err := f(k, v); err != nil {
-			return err
+	return b.IterFrom("", 0, f)
+}
+
+// IterFrom calls `f` for up to `limit` keys (all of them if 0), in ascending
+// key order, starting at `startKey` inclusively. The next page starts right
+// after the last key seen, at the last key followed by a zero byte.
+func (b *baseStore) IterFrom(startKey string, limit int, f func(key string, value []byte) error) error {
+	count := 0
+	err := b.kvIterFrom(startKey, func(key string, value []byte) error {
+		if limit > 0 && count == limit {
+			return errStopIter
 		}
+		count++
+		return f(key, value)
+	})
+	if err == errStopIter {
+		return nil
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestBaseStore_IterFrom(t *testing.T) {
+	s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	for _, key := range []string{"d", "b", "a", "c", "e"} {
+		s.kv.Set(key, []byte(key+key))
+	}
+
+	iter := func(startKey string, limit int) (out []string) {
+		require.NoError(t, s.IterFrom(startKey, limit, func(key string, value []byte) error {
+			out = append(out, fmt.Sprintf("%s=%s", key, value))
+			return nil
+		}))
+		return out
+	}
+
+	assert.Equal(t, []string{"a=aa", "b=bb", "c=cc", "d=dd", "e=ee"}, iter("", 0))
+	assert.Equal(t, []string{"a=aa", "b=bb"}, iter("", 2))
+	assert.Equal(t, []string{"c=cc", "d=dd"}, iter("b\x00", 2))
+	assert.Equal(t, []string{"e=ee"}, iter("e", 2))
+	assert.Nil(t, iter("f", 2))
+
+	var keys []string
+	require.NoError(t, s.Iter(func(key string, _ []byte) error {
+		keys = append(keys, key)
+		return nil
+	}))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, keys)
+
+	assert.EqualError(t, s.Iter(func(key string, _ []byte) error {
+		return fmt.Errorf("stop at %s", key)
+	}), "stop at a")
+}
@@ -1,8 +1,21 @@
 package marshaller
 
+import (
+	pbstore "github.com/streamingfast/substreams/storage/store/marshaller/pb"
+)
+
 type StoreData struct {
 	Kv             map[string][]byte
 	DeletePrefixes []string
+	DeleteRanges   []*DeleteRange
+}
+
+// DeleteRange deletes the keys between `LowKey` (inclusive) and `HighKey` (exclusive),
+// along with the keys listed in their values when `PointerSeparator` is set.
+type DeleteRange struct {
+	LowKey           string
+	HighKey          string
+	PointerSeparator string
 }
 
 type Marshaller interface {
@@ -13,3 +26,25 @@ type Marshaller interface {
 func Default() Marshaller {
 	return &VTproto{}
 }
+
+func toProtoDeleteRanges(ranges []*DeleteRange) (out []*pbstore.DeleteRange) {
+	for _, r := range ranges {
+		out = append(out, &pbstore.DeleteRange{
+			LowKey:           r.LowKey,
+			HighKey:          r.HighKey,
+			PointerSeparator: r.PointerSeparator,
+		})
+	}
+	return out
+}
+
+func fromProtoDeleteRanges(ranges []*pbstore.DeleteRange) (out []*DeleteRange) {
+	for _, r := range ranges {
+		out = append(out, &DeleteRange{
+			LowKey:           r.LowKey,
+			HighKey:          r.HighKey,
+			PointerSeparator: r.PointerSeparator,
+		})
+	}
+	return out
+}
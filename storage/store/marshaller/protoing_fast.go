@@ -12,6 +12,7 @@ const KVEntryProtoTag = 0x0a
 const KVEntryKeyProtoTag = 0x0a
 const KVEntryValueProtoTag = 0x12
 const DeletePrefixEntryProtoTag = 0x12
+const DeleteRangeEntryProtoTag = 0x1a
 
 // ProtoingFast is a custom proto marshaller, that will marshal and unmarshall the storeData into a predefined
 // proto struct (see below). The motivation here is that we want to write a proto message, making it readable by
@@ -20,6 +21,7 @@ const DeletePrefixEntryProtoTag = 0x12
 //	message StoreData {
 //		map<string, bytes> kv = 1;
 //		repeated string delete_prefixes = 2;
+//		repeated DeleteRange delete_ranges = 3;
 //	}
 type ProtoingFast struct{}
 
@@ -31,16 +33,22 @@ func (p *ProtoingFast) Unmarshal(in []byte) (*StoreData, uint64, error) {
 	return &StoreData{
 		Kv:             stateData.GetKv(),
 		DeletePrefixes: stateData.GetDeletePrefixes(),
+		DeleteRanges:   fromProtoDeleteRanges(stateData.GetDeleteRanges()),
 	}, 0, nil
 }
 
 func (p *ProtoingFast) Marshal(data *StoreData) ([]byte, error) {
 	sizeInBytes := p.kvByteSize(data.Kv)
 	sizeInBytes += p.listByteSize(data.DeletePrefixes)
+	deleteRanges := toProtoDeleteRanges(data.DeleteRanges)
+	sizeInBytes += p.deleteRangesByteSize(deleteRanges)
 	buffer := make([]byte, sizeInBytes)
 	cursor := buffer
 	cursor = p.writeKV(cursor, data.Kv)
-	p.writeDeletePrefix(cursor, data.DeletePrefixes)
+	cursor = p.writeDeletePrefix(cursor, data.DeletePrefixes)
+	if _, err := p.writeDeleteRanges(cursor, deleteRanges); err != nil {
+		return nil, err
+	}
 	return buffer, nil
 
 }
@@ -76,6 +84,17 @@ func (p *ProtoingFast) listByteSize(list []string) int {
 	return size
 }
 
+func (p *ProtoingFast) deleteRangesByteSize(ranges []*pbsubstreams.DeleteRange) int {
+	size := 0
+	for _, r := range ranges {
+		entrySize := r.SizeVT()
+		size += 1                                   // List element proto tag 0x1a (field number 3 [the DeleteRanges field], type LEN [message])
+		size += uvarintByteCount(uint64(entrySize)) // Number of bytes of the message
+		size += entrySize
+	}
+	return size
+}
+
 func (p *ProtoingFast) writeKV(cursor []byte, entries map[string][]byte) []byte {
 	for key, value := range entries {
 		copy(cursor, []byte{KVEntryProtoTag})
@@ -118,3 +137,20 @@ func (p *ProtoingFast) writeDeletePrefix(cursor []byte, entries []string) []byte
 	}
 	return cursor
 }
+
+func (p *ProtoingFast) writeDeleteRanges(cursor []byte, entries []*pbsubstreams.DeleteRange) ([]byte, error) {
+	for _, entry := range entries {
+		copy(cursor, []byte{DeleteRangeEntryProtoTag})
+		cursor = cursor[1:]
+
+		entrySize := entry.SizeVT()
+		written := binary.PutUvarint(cursor, uint64(entrySize))
+		cursor = cursor[written:]
+
+		if _, err := entry.MarshalToSizedBufferVT(cursor[:entrySize]); err != nil {
+			return nil, fmt.Errorf("marshal delete range: %w", err)
+		}
+		cursor = cursor[entrySize:]
+	}
+	return cursor, nil
+}
@@ -53,6 +53,15 @@ func TestProtoFast_CompareMarshal(t *testing.T) {
 				DeletePrefixes: []string{"22"},
 			},
 		},
+		{
+			name: "only delete ranges",
+			data: &StoreData{
+				DeleteRanges: []*DeleteRange{
+					{LowKey: "a", HighKey: "b"},
+					{LowKey: "c", HighKey: "d", PointerSeparator: ","},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,147 @@
+package marshaller
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	pbstore "github.com/streamingfast/substreams/storage/store/marshaller/pb"
+)
+
+// ReadStoreData decodes a protobuf-encoded `StoreData` (as written by the
+// `VTproto` and `ProtoingFast` marshallers) one entry at a time, so that it
+// can be loaded without materializing the whole key space in memory.
+func ReadStoreData(r *bufio.Reader, onKV func(key string, value []byte), onDeletePrefix func(prefix string), onDeleteRange func(deleteRange *DeleteRange)) error {
+	for {
+		field, data, err := readLenField(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch field {
+		case KVEntryProtoTag:
+			key, value, err := decodeKVEntry(data)
+			if err != nil {
+				return err
+			}
+			onKV(key, value)
+		case DeletePrefixEntryProtoTag:
+			onDeletePrefix(string(data))
+		case DeleteRangeEntryProtoTag:
+			deleteRange := &pbstore.DeleteRange{}
+			if err := deleteRange.UnmarshalVT(data); err != nil {
+				return fmt.Errorf("unmarshal delete range: %w", err)
+			}
+			onDeleteRange(fromProtoDeleteRanges([]*pbstore.DeleteRange{deleteRange})[0])
+		default:
+			return fmt.Errorf("unexpected store data field tag 0x%x", field)
+		}
+	}
+}
+
+func decodeKVEntry(data []byte) (key string, value []byte, err error) {
+	for len(data) > 0 {
+		tag := data[0]
+		length, n := binary.Uvarint(data[1:])
+		if n <= 0 || uint64(len(data)-1-n) < length {
+			return "", nil, fmt.Errorf("invalid kv entry")
+		}
+		content := data[1+n : 1+n+int(length)]
+		data = data[1+n+int(length):]
+
+		switch tag {
+		case KVEntryKeyProtoTag:
+			key = string(content)
+		case KVEntryValueProtoTag:
+			value = content
+		default:
+			return "", nil, fmt.Errorf("unexpected kv entry field tag 0x%x", tag)
+		}
+	}
+	return key, value, nil
+}
+
+func readLenField(r *bufio.Reader) (tag byte, data []byte, err error) {
+	tag, err = r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, fmt.Errorf("reading field length: %w", noEOF(err))
+	}
+
+	data = make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, fmt.Errorf("reading field content: %w", noEOF(err))
+	}
+	return tag, data, nil
+}
+
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// StoreDataWriter encodes a protobuf `StoreData` entry by entry, readable by
+// any of the protobuf marshallers.
+type StoreDataWriter struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+func NewStoreDataWriter(w io.Writer) *StoreDataWriter {
+	return &StoreDataWriter{w: bufio.NewWriter(w)}
+}
+
+func (s *StoreDataWriter) WriteKV(key string, value []byte) error {
+	entrySize := kvEntryByteSize(key, value)
+	s.buf = s.buf[:0]
+	s.buf = append(s.buf, KVEntryProtoTag)
+	s.buf = binary.AppendUvarint(s.buf, uint64(entrySize))
+	s.buf = append(s.buf, KVEntryKeyProtoTag)
+	s.buf = binary.AppendUvarint(s.buf, uint64(len(key)))
+	s.buf = append(s.buf, key...)
+	s.buf = append(s.buf, KVEntryValueProtoTag)
+	s.buf = binary.AppendUvarint(s.buf, uint64(len(value)))
+	s.buf = append(s.buf, value...)
+
+	_, err := s.w.Write(s.buf)
+	return err
+}
+
+func (s *StoreDataWriter) WriteDeletePrefix(prefix string) error {
+	s.buf = s.buf[:0]
+	s.buf = append(s.buf, DeletePrefixEntryProtoTag)
+	s.buf = binary.AppendUvarint(s.buf, uint64(len(prefix)))
+	s.buf = append(s.buf, prefix...)
+
+	_, err := s.w.Write(s.buf)
+	return err
+}
+
+func (s *StoreDataWriter) WriteDeleteRange(deleteRange *DeleteRange) error {
+	content, err := toProtoDeleteRanges([]*DeleteRange{deleteRange})[0].MarshalVT()
+	if err != nil {
+		return fmt.Errorf("marshal delete range: %w", err)
+	}
+
+	s.buf = s.buf[:0]
+	s.buf = append(s.buf, DeleteRangeEntryProtoTag)
+	s.buf = binary.AppendUvarint(s.buf, uint64(len(content)))
+	s.buf = append(s.buf, content...)
+
+	_, err = s.w.Write(s.buf)
+	return err
+}
+
+func (s *StoreDataWriter) Flush() error {
+	return s.w.Flush()
+}
@@ -0,0 +1,64 @@
+package marshaller
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreDataWriter_ReadStoreData(t *testing.T) {
+	data := &StoreData{
+		Kv: map[string][]byte{
+			"a":     {0xaa},
+			"b":     bytes.Repeat([]byte{0xbb}, 300),
+			"empty": {},
+		},
+		DeletePrefixes: []string{"c:", "d:"},
+		DeleteRanges: []*DeleteRange{
+			{LowKey: "e:1", HighKey: "e:5"},
+			{LowKey: "f:", HighKey: "f;", PointerSeparator: ","},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	w := NewStoreDataWriter(buf)
+	for k, v := range data.Kv {
+		require.NoError(t, w.WriteKV(k, v))
+	}
+	for _, prefix := range data.DeletePrefixes {
+		require.NoError(t, w.WriteDeletePrefix(prefix))
+	}
+	for _, deleteRange := range data.DeleteRanges {
+		require.NoError(t, w.WriteDeleteRange(deleteRange))
+	}
+	require.NoError(t, w.Flush())
+
+	vtData, _, err := (&VTproto{}).Unmarshal(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, data, vtData)
+
+	vtContent, err := (&VTproto{}).Marshal(data)
+	require.NoError(t, err)
+
+	read := &StoreData{Kv: map[string][]byte{}}
+	err = ReadStoreData(bufio.NewReader(bytes.NewReader(vtContent)), func(key string, value []byte) {
+		read.Kv[key] = value
+	}, func(prefix string) {
+		read.DeletePrefixes = append(read.DeletePrefixes, prefix)
+	}, func(deleteRange *DeleteRange) {
+		read.DeleteRanges = append(read.DeleteRanges, deleteRange)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+}
+
+func TestReadStoreData_Truncated(t *testing.T) {
+	content, err := (&VTproto{}).Marshal(&StoreData{Kv: map[string][]byte{"a": {0xaa}}})
+	require.NoError(t, err)
+
+	err = ReadStoreData(bufio.NewReader(bytes.NewReader(content[:len(content)-1])), func(string, []byte) {}, func(string) {}, func(*DeleteRange) {})
+	require.EqualError(t, err, "reading field content: unexpected EOF")
+}
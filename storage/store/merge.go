@@ -15,23 +15,23 @@ import (
 )
 
 func (b *baseStore) setKV(k string, v []byte) {
-	if prev, ok := b.kv[k]; ok {
+	if prev, ok := b.kvGet(k); ok {
 		b.totalSizeBytes -= uint64(len(prev))
 	} else {
 		b.totalSizeBytes += uint64(len(k))
 	}
 	b.totalSizeBytes += uint64(len(v))
-	b.kv[k] = v
+	b.kvSet(k, v)
 }
 
 func (b *baseStore) setNewKV(k string, v []byte) {
 	b.totalSizeBytes += uint64(len(k) + len(v))
-	b.kv[k] = v
+	b.kvSet(k, v)
 }
 
 // Merge nextStore _into_ `s`, where nextStore is for the next contiguous segment's store output.
 func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
-	b.logger.Debug("merging store", zap.Int("current_key_count", len(b.kv)), zap.Uint64("mod_init_block", b.moduleInitialBlock), zap.Int("partial_key_count", len(kvPartialStore.kv)), zap.Uint64("partial_start_block", kvPartialStore.initialBlock))
+	b.logger.Debug("merging store", zap.Int("current_key_count", b.kv.Len()), zap.Uint64("mod_init_block", b.moduleInitialBlock), zap.Int("partial_key_count", kvPartialStore.kv.Len()), zap.Uint64("partial_start_block", kvPartialStore.initialBlock))
 
 	if kvPartialStore.updatePolicy != b.updatePolicy {
 		return fmt.Errorf("incompatible update policies: policy %q cannot merge policy %q", b.updatePolicy, kvPartialStore.updatePolicy)
@@ -49,22 +49,37 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 		b.logger.Info("merging: applied delete prefixes", zap.Duration("duration", time.Since(partialKvTime)))
 	}
 
+	partialKvTime = time.Now()
+	for _, r := range kvPartialStore.DeletedRanges {
+		if r.PointerSeparator != "" {
+			b.DeleteRangePointers(kvPartialStore.lastOrdinal, r.LowKey, r.HighKey, r.PointerSeparator)
+		} else {
+			b.DeleteRange(kvPartialStore.lastOrdinal, r.LowKey, r.HighKey)
+		}
+	}
+	if len(kvPartialStore.DeletedRanges) > 0 {
+		b.logger.Info("merging: applied delete ranges", zap.Duration("duration", time.Since(partialKvTime)))
+	}
+
 	intoValueTypeLower := strings.ToLower(b.valueType)
 
+	var merge func(k string, v []byte) error
 	switch b.updatePolicy {
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_SET:
-		for k, v := range kvPartialStore.kv {
+		merge = func(k string, v []byte) error {
 			b.setKV(k, v)
+			return nil
 		}
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_IF_NOT_EXISTS:
-		for k, v := range kvPartialStore.kv {
-			if _, found := b.kv[k]; !found {
+		merge = func(k string, v []byte) error {
+			if _, found := b.kvGet(k); !found {
 				b.setNewKV(k, v)
 			}
+			return nil
 		}
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_APPEND:
-		for k, v := range kvPartialStore.kv {
-			if prevVal, found := b.kv[k]; found {
+		merge = func(k string, v []byte) error {
+			if prevVal, found := b.kvGet(k); found {
 				newLen := len(prevVal) + len(v)
 				if b.appendLimit > 0 && uint64(newLen) >= b.appendLimit {
 					return fmt.Errorf("append would exceed limit of %d bytes", b.appendLimit)
@@ -77,6 +92,7 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 			} else {
 				b.setNewKV(k, v)
 			}
+			return nil
 		}
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD:
 		// check valueType to do the right thing
@@ -85,40 +101,44 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 			sum := func(a, b int64) int64 {
 				return a + b
 			}
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := b.kv[k]
+			merge = func(k string, v []byte) error {
+				v0b, fv0 := b.kvGet(k)
 				v0 := foundOrZeroInt64(v0b, fv0)
 				v1 := foundOrZeroInt64(v, true)
 				b.setKV(k, []byte(fmt.Sprintf("%d", sum(v0, v1))))
+				return nil
 			}
 		case manifest.OutputValueTypeFloat64:
 			sum := func(a, b float64) float64 {
 				return a + b
 			}
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := b.kv[k]
+			merge = func(k string, v []byte) error {
+				v0b, fv0 := b.kvGet(k)
 				v0 := foundOrZeroFloat(v0b, fv0)
 				v1 := foundOrZeroFloat(v, true)
 				b.setKV(k, floatToBytes(sum(v0, v1)))
+				return nil
 			}
 		case manifest.OutputValueTypeBigInt:
 			sum := func(a, b *big.Int) *big.Int {
 				return new(big.Int).Add(a, b)
 			}
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := b.kv[k]
+			merge = func(k string, v []byte) error {
+				v0b, fv0 := b.kvGet(k)
 				v0 := foundOrZeroBigInt(v0b, fv0)
 				v1 := foundOrZeroBigInt(v, true)
 				b.setKV(k, []byte(fmt.Sprintf("%d", sum(v0, v1))))
+				return nil
 			}
 		case manifest.OutputValueTypeBigFloat:
 			fallthrough
 		case manifest.OutputValueTypeBigDecimal:
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := b.kv[k]
+			merge = func(k string, v []byte) error {
+				v0b, fv0 := b.kvGet(k)
 				v0 := foundOrZeroBigDecimal(v0b, fv0)
 				v1 := foundOrZeroBigDecimal(v, true)
 				b.setKV(k, []byte(v0.Add(v1).String()))
+				return nil
 			}
 		default:
 			return fmt.Errorf("update policy %q not supported for value type %q", b.updatePolicy, b.valueType)
@@ -132,16 +152,17 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroInt64(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, []byte(fmt.Sprintf("%d", v1)))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroInt64(v, true)
 
 				b.setKV(k, []byte(fmt.Sprintf("%d", max(v0, v1))))
+				return nil
 			}
 		case manifest.OutputValueTypeFloat64:
 			max := func(a, b float64) float64 {
@@ -150,16 +171,17 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return a
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroFloat(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, floatToBytes(v1))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroFloat(v, true)
 
 				b.setKV(k, floatToBytes(max(v0, v1)))
+				return nil
 			}
 		case manifest.OutputValueTypeBigInt:
 			max := func(a, b *big.Int) *big.Int {
@@ -168,16 +190,17 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return a
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroBigInt(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, []byte(v1.String()))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroBigInt(v, true)
 
 				b.setKV(k, []byte(fmt.Sprintf("%d", max(v0, v1))))
+				return nil
 			}
 		case manifest.OutputValueTypeBigFloat:
 			fallthrough
@@ -188,16 +211,17 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return a
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroBigDecimal(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, []byte(v1.String()))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroBigDecimal(v, true)
 
 				b.setNewKV(k, []byte(max(v0, v1).String()))
+				return nil
 			}
 		default:
 			return fmt.Errorf("update policy %q not supported for value type %q", kvPartialStore.updatePolicy, kvPartialStore.valueType)
@@ -211,16 +235,17 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroInt64(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, []byte(fmt.Sprintf("%d", v1)))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroInt64(v, true)
 
 				b.setKV(k, []byte(fmt.Sprintf("%d", min(v0, v1))))
+				return nil
 			}
 		case manifest.OutputValueTypeFloat64:
 			min := func(a, b float64) float64 {
@@ -229,16 +254,17 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroFloat(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, floatToBytes(v1))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroFloat(v, true)
 
 				b.setKV(k, floatToBytes(min(v0, v1)))
+				return nil
 			}
 		case manifest.OutputValueTypeBigInt:
 			min := func(a, b *big.Int) *big.Int {
@@ -247,16 +273,17 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroBigInt(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, []byte(v1.String()))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroBigInt(v, true)
 
 				b.setKV(k, []byte(fmt.Sprintf("%d", min(v0, v1))))
+				return nil
 			}
 		case manifest.OutputValueTypeBigFloat:
 			fallthrough
@@ -267,15 +294,16 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			merge = func(k string, v []byte) error {
 				v1 := foundOrZeroBigDecimal(v, true)
-				v, found := b.kv[k]
+				v, found := b.kvGet(k)
 				if !found {
 					b.setNewKV(k, []byte(v1.String()))
-					continue
+					return nil
 				}
 				v0 := foundOrZeroBigDecimal(v, true)
 				b.setNewKV(k, []byte(min(v0, v1).String()))
+				return nil
 			}
 		default:
 			return fmt.Errorf("update policy %q not supported for value type %q", b.updatePolicy, b.valueType)
@@ -284,6 +312,16 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 		return fmt.Errorf("update policy %q not supported", b.updatePolicy) // should have been validated already
 	}
 
+	if err := kvPartialStore.kvIter(merge); err != nil {
+		return err
+	}
+	if err := kvPartialStore.Err(); err != nil {
+		return fmt.Errorf("partial store backend failed: %w", err)
+	}
+	if err := b.Err(); err != nil {
+		return fmt.Errorf("store backend failed: %w", err)
+	}
+
 	b.Reset() // Merge should never keep deltas or ordinals
 	return nil
 }
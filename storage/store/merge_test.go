@@ -351,7 +351,7 @@ func TestStore_Merge(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			for k, v := range test.prev.kv {
+			for k, v := range test.prev.kv.(memoryKV) {
 				if test.latest.valueType == manifest.OutputValueTypeBigDecimal {
 					actual, _ := foundOrZeroBigFloat(v, true).Float64()
 					expected, _ := foundOrZeroBigFloat(test.expectedKV[k], true).Float64()
@@ -366,10 +366,10 @@ func TestStore_Merge(t *testing.T) {
 			for k, v := range test.expectedKV {
 				if test.latest.valueType == manifest.OutputValueTypeBigDecimal {
 					actual, _ := foundOrZeroBigFloat(v, true).Float64()
-					expected, _ := foundOrZeroBigFloat(test.prev.kv[k], true).Float64()
+					expected, _ := foundOrZeroBigFloat(test.prev.kv.(memoryKV)[k], true).Float64()
 					assert.InDelta(t, actual, expected, 0.01)
 				} else {
-					expected := string(test.prev.kv[k])
+					expected := string(test.prev.kv.(memoryKV)[k])
 					actual := string(v)
 					assert.Equal(t, expected, actual)
 				}
@@ -382,7 +382,7 @@ func TestStore_Merge(t *testing.T) {
 
 func newPartialStore(kv map[string][]byte, updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy, valueType string, deletedPrefixes []string) *PartialKV {
 	b := &baseStore{
-		kv: kv,
+		kv: memoryKV(kv),
 		Config: &Config{
 			updatePolicy: updatePolicy,
 			valueType:    valueType,
@@ -394,7 +394,7 @@ func newPartialStore(kv map[string][]byte, updatePolicy pbsubstreams.Module_Kind
 
 func newStore(kv map[string][]byte, updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy, valueType string) *FullKV {
 	b := &baseStore{
-		kv: kv,
+		kv: memoryKV(kv),
 		Config: &Config{
 			updatePolicy: updatePolicy,
 			valueType:    valueType,
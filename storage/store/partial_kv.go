@@ -15,14 +15,16 @@ type PartialKV struct {
 
 	initialBlock    uint64 // block at which we initialized this store
 	DeletedPrefixes []string
+	DeletedRanges   []*marshaller.DeleteRange
 
 	loadedFrom string
 	seen       map[string]bool
+	seenRanges map[marshaller.DeleteRange]bool
 }
 
 func (p *PartialKV) Roll(lastBlock uint64) {
 	p.initialBlock = lastBlock
-	p.baseStore.kv = map[string][]byte{}
+	p.replaceKV(p.newKV())
 }
 
 func (p *PartialKV) InitialBlock() uint64 { return p.initialBlock }
@@ -31,36 +33,21 @@ func (p *PartialKV) Load(ctx context.Context, file *FileInfo) error {
 	p.loadedFrom = file.Filename
 	p.logger.Debug("loading partial store state from file", zap.String("filename", file.Filename))
 
-	data, err := loadStore(ctx, p.objStore, file.Filename)
+	deletedPrefixes, deletedRanges, err := p.loadKV(ctx, file.Filename)
 	if err != nil {
 		return fmt.Errorf("load partial store %s at %s: %w", p.name, file.Filename, err)
 	}
+	p.DeletedPrefixes = deletedPrefixes
+	p.DeletedRanges = deletedRanges
 
-	storeData, size, err := p.marshaller.Unmarshal(data)
-	if err != nil {
-		return fmt.Errorf("unmarshal store: %w", err)
-	}
-
-	p.kv = storeData.Kv
-	if p.kv == nil {
-		p.kv = map[string][]byte{}
-	}
-	p.totalSizeBytes = size
-	p.DeletedPrefixes = storeData.DeletePrefixes
-
-	p.logger.Debug("partial store loaded", zap.String("filename", file.Filename), zap.Int("key_count", len(p.kv)), zap.Uint64("data_size", size))
+	p.logger.Debug("partial store loaded", zap.String("filename", file.Filename), zap.Int("key_count", p.kv.Len()), zap.Uint64("data_size", p.totalSizeBytes))
 	return nil
 }
 
 func (p *PartialKV) Save(endBoundaryBlock uint64) (*FileInfo, *fileWriter, error) {
 	p.logger.Debug("writing partial store state", zap.Object("store", p))
 
-	stateData := &marshaller.StoreData{
-		Kv:             p.kv,
-		DeletePrefixes: p.DeletedPrefixes,
-	}
-
-	content, err := p.marshaller.Marshal(stateData)
+	fw, err := p.marshalKV(p.DeletedPrefixes, p.DeletedRanges)
 	if err != nil {
 		return nil, nil, fmt.Errorf("marshal partial data: %w", err)
 	}
@@ -68,12 +55,7 @@ func (p *PartialKV) Save(endBoundaryBlock uint64) (*FileInfo, *fileWriter, error
 	file := NewPartialFileInfo(p.initialBlock, endBoundaryBlock, p.traceID)
 	p.logger.Info("partial store save written", zap.String("file_name", file.Filename), zap.Stringer("block_range", file.Range))
 
-	fw := &fileWriter{
-		store:    p.objStore,
-		filename: file.Filename,
-		content:  content,
-	}
-
+	fw.filename = file.Filename
 	return file, fw, nil
 }
 
@@ -86,6 +68,64 @@ func (p *PartialKV) DeletePrefix(ord uint64, prefix string) {
 	}
 }
 
+func (p *PartialKV) DeleteRange(ord uint64, lowKey, highKey string) {
+	p.baseStore.DeleteRange(ord, lowKey, highKey)
+
+	p.addDeletedRange(marshaller.DeleteRange{LowKey: lowKey, HighKey: highKey})
+}
+
+// DeleteRangePointers also records the keys pointed to by the keys deleted in
+// this partial store: when merging, the range is deleted from the previous
+// store using the pointers listed in its own values.
+func (p *PartialKV) DeleteRangePointers(ord uint64, lowKey, highKey, pointerSeparator string) {
+	_, pointers := p.rangeAndPointerKeys(lowKey, highKey, pointerSeparator)
+	p.baseStore.DeleteRangePointers(ord, lowKey, highKey, pointerSeparator)
+
+	p.addDeletedRange(marshaller.DeleteRange{LowKey: lowKey, HighKey: highKey, PointerSeparator: pointerSeparator})
+	for _, pointer := range pointers {
+		p.addDeletedRange(singleKeyRange(pointer))
+	}
+}
+
+func (p *PartialKV) addDeletedRange(deleteRange marshaller.DeleteRange) {
+	if p.seenRanges[deleteRange] {
+		return
+	}
+	if p.seenRanges == nil {
+		p.seenRanges = make(map[marshaller.DeleteRange]bool)
+	}
+	p.DeletedRanges = append(p.DeletedRanges, &deleteRange)
+	p.seenRanges[deleteRange] = true
+}
+
+// SnapshotDeletions returns a function restoring the deleted prefixes and ranges
+// recorded by the store to the ones recorded when SnapshotDeletions was called,
+// which reverting its deltas doesn't.
+func (p *PartialKV) SnapshotDeletions() (restore func()) {
+	deletedPrefixes := append([]string(nil), p.DeletedPrefixes...)
+	deletedRanges := append([]*marshaller.DeleteRange(nil), p.DeletedRanges...)
+	seen := make(map[string]bool, len(p.seen))
+	for prefix := range p.seen {
+		seen[prefix] = true
+	}
+	seenRanges := make(map[marshaller.DeleteRange]bool, len(p.seenRanges))
+	for deleteRange := range p.seenRanges {
+		seenRanges[deleteRange] = true
+	}
+
+	return func() {
+		p.DeletedPrefixes = deletedPrefixes
+		p.DeletedRanges = deletedRanges
+		p.seen = seen
+		p.seenRanges = seenRanges
+	}
+}
+
+// singleKeyRange covers exactly `key`, no other key sorting between `key` and `key + "\x00"`.
+func singleKeyRange(key string) marshaller.DeleteRange {
+	return marshaller.DeleteRange{LowKey: key, HighKey: key + "\x00"}
+}
+
 func (p *PartialKV) DeleteStore(ctx context.Context, file *FileInfo) (err error) {
 	zlog.Debug("deleting partial store file", zap.String("file_name", file.Filename))
 
@@ -96,5 +136,5 @@ func (p *PartialKV) DeleteStore(ctx context.Context, file *FileInfo) (err error)
 }
 
 func (p *PartialKV) String() string {
-	return fmt.Sprintf("partialKV name %s moduleInitialBlock %d  keyCount %d deltasCount %d loadFrom %s", p.Name(), p.moduleInitialBlock, len(p.kv), len(p.deltas), p.loadedFrom)
+	return fmt.Sprintf("partialKV name %s moduleInitialBlock %d  keyCount %d deltasCount %d loadFrom %s", p.Name(), p.moduleInitialBlock, p.kv.Len(), len(p.deltas), p.loadedFrom)
 }
@@ -24,7 +24,7 @@ func TestPartialKV_Save_Load_Empty_MapNotNil(t *testing.T) {
 
 	kvs := &PartialKV{
 		baseStore: &baseStore{
-			kv: map[string][]byte{},
+			kv: memoryKV{},
 
 			logger:     zap.NewNop(),
 			marshaller: marshaller.Default(),
@@ -44,7 +44,7 @@ func TestPartialKV_Save_Load_Empty_MapNotNil(t *testing.T) {
 
 	kvl := &PartialKV{
 		baseStore: &baseStore{
-			kv: map[string][]byte{},
+			kv: memoryKV{},
 
 			logger:     zap.NewNop(),
 			marshaller: marshaller.Default(),
@@ -60,3 +60,65 @@ func TestPartialKV_Save_Load_Empty_MapNotNil(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNilf(t, kvl.kv, "kvl.kv is nil")
 }
+
+func TestPartialKV_Save_Load_DiskBackend(t *testing.T) {
+	var writtenBytes []byte
+	store := dstore.NewMockStore(func(base string, f io.Reader) (err error) {
+		writtenBytes, err = io.ReadAll(f)
+		return err
+	})
+	store.OpenObjectFunc = func(ctx context.Context, name string) (out io.ReadCloser, err error) {
+		return io.NopCloser(bytes.NewBuffer(writtenBytes)), nil
+	}
+
+	config := &Config{
+		name:               "test",
+		moduleInitialBlock: 0,
+		objStore:           store,
+	}
+	config.SetKVBackend(NewDiskKVBackendFactory(t.TempDir()))
+
+	kvs := &PartialKV{
+		baseStore: &baseStore{
+			kv:         config.newKV(),
+			logger:     zap.NewNop(),
+			marshaller: marshaller.Default(),
+			Config:     config,
+		},
+		DeletedPrefixes: []string{"c:"},
+	}
+	defer kvs.Close()
+	kvs.kv.Set("a", []byte("value a"))
+	kvs.kv.Set("b", []byte{})
+
+	file, writer, err := kvs.Save(123)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(context.Background()))
+
+	// Files written from a disk backend are readable by the in-memory one.
+	storeData, _, err := marshaller.Default().Unmarshal(writtenBytes)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"a": []byte("value a"), "b": {}}, storeData.Kv)
+	require.Equal(t, []string{"c:"}, storeData.DeletePrefixes)
+
+	kvl := &PartialKV{
+		baseStore: &baseStore{
+			kv:         config.newKV(),
+			logger:     zap.NewNop(),
+			marshaller: marshaller.Default(),
+			Config:     config,
+		},
+	}
+
+	defer kvl.Close()
+
+	require.NoError(t, kvl.Load(context.Background(), file))
+	require.IsType(t, &diskKV{}, kvl.kv)
+	require.Equal(t, 2, kvl.kv.Len())
+
+	value, found := kvl.GetLast("a")
+	require.True(t, found)
+	require.Equal(t, "value a", string(value))
+	require.Equal(t, []string{"c:"}, kvl.DeletedPrefixes)
+	require.Equal(t, uint64(9), kvl.totalSizeBytes)
+}
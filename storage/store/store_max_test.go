@@ -48,9 +48,9 @@ func TestStoreSetMaxBigInt(t *testing.T) {
 	}
 
 	initTestStore := func(b *baseStore, key string, value *big.Int) {
-		b.kv = map[string][]byte{}
+		b.kv = memoryKV{}
 		if value != nil {
-			b.kv[key] = []byte(value.String())
+			b.kv.Set(key, []byte(value.String()))
 		}
 	}
 
@@ -114,9 +114,9 @@ func TestStoreSetMaxInt64(t *testing.T) {
 	}
 
 	initTestStore := func(b *baseStore, key string, value *int64) {
-		b.kv = map[string][]byte{}
+		b.kv = memoryKV{}
 		if value != nil {
-			b.kv[key] = []byte(fmt.Sprintf("%d", *value))
+			b.kv.Set(key, []byte(fmt.Sprintf("%d", *value)))
 		}
 	}
 
@@ -181,9 +181,9 @@ func TestStoreSetMaxFloat64(t *testing.T) {
 	}
 
 	initTestStore := func(b *baseStore, key string, value *float64) {
-		b.kv = map[string][]byte{}
+		b.kv = memoryKV{}
 		if value != nil {
-			b.kv[key] = []byte(strconv.FormatFloat(*value, 'g', 100, 64))
+			b.kv.Set(key, []byte(strconv.FormatFloat(*value, 'g', 100, 64)))
 		}
 	}
 
@@ -242,9 +242,9 @@ func TestStoreSetMaxBigFloat(t *testing.T) {
 	}
 
 	initTestStore := func(b *baseStore, key string, value decimal.Decimal) {
-		b.kv = map[string][]byte{}
+		b.kv = memoryKV{}
 		if value != nilDecimal {
-			b.kv[key] = []byte(value.String())
+			b.kv.Set(key, []byte(value.String()))
 		}
 	}
 
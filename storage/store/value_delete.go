@@ -27,23 +27,92 @@ import (
 func (b *baseStore) DeletePrefix(ord uint64, prefix string) {
 	b.bumpOrdinal(ord)
 
-	var deltas []*pbssinternal.StoreDelta
-	for key, val := range b.kv {
-		if !strings.HasPrefix(key, prefix) {
+	b.deleteKeys(ord, b.matchingKeys(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	}))
+}
+
+// DeleteRange deletes the keys between `lowKey` (inclusive) and `highKey` (exclusive).
+func (b *baseStore) DeleteRange(ord uint64, lowKey, highKey string) {
+	b.bumpOrdinal(ord)
+
+	b.deleteKeys(ord, b.rangeKeys(lowKey, highKey))
+}
+
+// DeleteRangePointers deletes the keys between `lowKey` (inclusive) and `highKey` (exclusive),
+// along with the keys listed in their values, separated by `pointerSeparator`.
+func (b *baseStore) DeleteRangePointers(ord uint64, lowKey, highKey, pointerSeparator string) {
+	b.bumpOrdinal(ord)
+
+	keys, _ := b.rangeAndPointerKeys(lowKey, highKey, pointerSeparator)
+	b.deleteKeys(ord, keys)
+}
+
+// rangeAndPointerKeys returns the keys in the range along with the existing keys they
+// point to, and all the pointers found in their values, existing or not.
+func (b *baseStore) rangeAndPointerKeys(lowKey, highKey, pointerSeparator string) (keys map[string][]byte, pointers []string) {
+	keys = b.rangeKeys(lowKey, highKey)
+	for _, val := range keys {
+		for _, pointer := range strings.Split(string(val), pointerSeparator) {
+			if pointer != "" {
+				pointers = append(pointers, pointer)
+			}
+		}
+	}
+
+	for _, pointer := range pointers {
+		if _, found := keys[pointer]; found {
 			continue
 		}
-		delta := &pbssinternal.StoreDelta{
+		if val, found := b.kvGet(pointer); found {
+			keys[pointer] = val
+		}
+	}
+	return keys, pointers
+}
+
+// rangeKeys returns the keys between `lowKey` (inclusive) and `highKey` (exclusive),
+// mapped to their current value, seeking to `lowKey` instead of visiting every key.
+func (b *baseStore) rangeKeys(lowKey, highKey string) map[string][]byte {
+	keys := make(map[string][]byte)
+	_ = b.kvIterFrom(lowKey, func(key string, val []byte) error {
+		if key >= highKey {
+			return errStopIter
+		}
+		keys[key] = val
+		return nil
+	})
+	return keys
+}
+
+func (b *baseStore) matchingKeys(match func(key string) bool) map[string][]byte {
+	keys := make(map[string][]byte)
+	_ = b.kvIter(func(key string, val []byte) error {
+		if match(key) {
+			keys[key] = val
+		}
+		return nil
+	})
+	return keys
+}
+
+// deleteKeys deletes `keys` (mapped to their current value), recording the deltas in key order.
+func (b *baseStore) deleteKeys(ord uint64, keys map[string][]byte) {
+	deltas := make([]*pbssinternal.StoreDelta, 0, len(keys))
+	for key, val := range keys {
+		deltas = append(deltas, &pbssinternal.StoreDelta{
 			Operation: pbssinternal.StoreDelta_DELETE,
 			Ordinal:   ord,
 			Key:       key,
 			OldValue:  val,
 			NewValue:  nil,
-		}
-		b.ApplyDelta(delta)
-		deltas = append(deltas, delta)
+		})
 	}
 	sort.Slice(deltas, func(i, j int) bool {
 		return deltas[i].Key < deltas[j].Key
 	})
+	for _, delta := range deltas {
+		b.ApplyDelta(delta)
+	}
 	b.deltas = append(b.deltas, deltas...)
 }
@@ -0,0 +1,190 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store/marshaller"
+)
+
+func TestBaseStore_DeleteRange(t *testing.T) {
+	tests := []struct {
+		name              string
+		kv                map[string]string
+		delete            func(s *baseStore)
+		expectDeletedKeys []string
+		expectKV          map[string]string
+	}{
+		{
+			name: "range",
+			kv:   map[string]string{"a": "1", "b:1": "2", "b:2": "3", "c": "4"},
+			delete: func(s *baseStore) {
+				s.DeleteRange(1, "b", "c")
+			},
+			expectDeletedKeys: []string{"b:1", "b:2"},
+			expectKV:          map[string]string{"a": "1", "c": "4"},
+		},
+		{
+			name: "range excludes high key",
+			kv:   map[string]string{"a": "1", "b": "2", "c": "3"},
+			delete: func(s *baseStore) {
+				s.DeleteRange(1, "a", "c")
+			},
+			expectDeletedKeys: []string{"a", "b"},
+			expectKV:          map[string]string{"c": "3"},
+		},
+		{
+			name: "empty range",
+			kv:   map[string]string{"a": "1"},
+			delete: func(s *baseStore) {
+				s.DeleteRange(1, "b", "a")
+			},
+			expectKV: map[string]string{"a": "1"},
+		},
+		{
+			name: "range pointers",
+			kv: map[string]string{
+				"owner:1": "token:a,token:b",
+				"owner:2": "token:c,,token:unknown",
+				"owner:3": "",
+				"token:a": "1",
+				"token:b": "2",
+				"token:c": "3",
+				"token:d": "4",
+			},
+			delete: func(s *baseStore) {
+				s.DeleteRangePointers(1, "owner:1", "owner:3", ",")
+			},
+			expectDeletedKeys: []string{"owner:1", "owner:2", "token:a", "token:b", "token:c"},
+			expectKV:          map[string]string{"owner:3": "", "token:d": "4"},
+		},
+		{
+			name: "range pointers pointing in range",
+			kv: map[string]string{
+				"a": "b",
+				"b": "c",
+				"c": "",
+			},
+			delete: func(s *baseStore) {
+				s.DeleteRangePointers(1, "a", "c", ",")
+			},
+			expectDeletedKeys: []string{"a", "b", "c"},
+			expectKV:          map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+			for k, v := range test.kv {
+				s.kv.Set(k, []byte(v))
+			}
+
+			test.delete(s)
+
+			var deletedKeys []string
+			for _, delta := range s.GetDeltas() {
+				assert.Equal(t, pbssinternal.StoreDelta_DELETE, delta.Operation)
+				assert.Equal(t, test.kv[delta.Key], string(delta.OldValue))
+				deletedKeys = append(deletedKeys, delta.Key)
+			}
+			assert.Equal(t, test.expectDeletedKeys, deletedKeys)
+
+			kv := map[string]string{}
+			require.NoError(t, s.Iter(func(key string, value []byte) error {
+				kv[key] = string(value)
+				return nil
+			}))
+			assert.Equal(t, test.expectKV, kv)
+		})
+	}
+}
+
+func TestPartialKV_DeleteRange_Merge(t *testing.T) {
+	prev := newStore(map[string][]byte{
+		"a:1":     []byte("1"),
+		"a:2":     []byte("2"),
+		"owner:1": []byte("token:a"),
+		"owner:2": []byte("token:b"),
+		"token:a": []byte("A"),
+		"token:b": []byte("B"),
+		"token:c": []byte("C"),
+		"z":       []byte("z"),
+	}, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string")
+
+	partial := &PartialKV{
+		baseStore: newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil),
+		seen:      make(map[string]bool),
+	}
+	partial.SetBytes(1, "owner:3", []byte("token:c"))
+	partial.DeleteRange(2, "a:", "a;")
+	partial.DeleteRangePointers(3, "owner:", "owner;", ",")
+	partial.DeleteRange(4, "a:", "a;")
+	partial.SetBytes(5, "a:3", []byte("3"))
+
+	assert.Equal(t, []*marshaller.DeleteRange{
+		{LowKey: "a:", HighKey: "a;"},
+		{LowKey: "owner:", HighKey: "owner;", PointerSeparator: ","},
+		{LowKey: "token:c", HighKey: "token:c\x00"},
+	}, partial.DeletedRanges)
+
+	require.NoError(t, prev.Merge(partial))
+
+	kv := map[string]string{}
+	for k, v := range prev.kv.(memoryKV) {
+		kv[k] = string(v)
+	}
+	assert.Equal(t, map[string]string{
+		"a:3": "3",
+		"z":   "z",
+	}, kv)
+}
+
+func TestBaseStore_DeleteRange_VisitsRangeOnly(t *testing.T) {
+	backends := map[string]func(t *testing.T) KVBackend{
+		"memory": func(t *testing.T) KVBackend { return memoryKV{} },
+		"disk":   func(t *testing.T) KVBackend { return NewDiskKVBackendFactory(t.TempDir())("test") },
+	}
+
+	for name, newBackend := range backends {
+		t.Run(name, func(t *testing.T) {
+			s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+			kv := &visitingKV{KVBackend: newBackend(t)}
+			s.replaceKV(kv)
+			defer s.Close()
+			for _, key := range []string{"a", "b:1", "b:2", "c", "d"} {
+				require.NoError(t, kv.Set(key, []byte(key)))
+			}
+
+			s.DeleteRange(1, "b", "c")
+			assert.Equal(t, []string{"b:1", "b:2", "c"}, kv.visited, "iteration stops at the first key out of the range")
+			assert.Len(t, s.GetDeltas(), 2)
+			require.NoError(t, s.Err())
+		})
+	}
+}
+
+// visitingKV records the keys visited by the iterations over its backend.
+type visitingKV struct {
+	KVBackend
+	visited []string
+}
+
+func (v *visitingKV) Iter(f func(key string, value []byte) error) error {
+	return v.KVBackend.Iter(v.visit(f))
+}
+
+func (v *visitingKV) IterFrom(startKey string, f func(key string, value []byte) error) error {
+	return v.KVBackend.IterFrom(startKey, v.visit(f))
+}
+
+func (v *visitingKV) visit(f func(key string, value []byte) error) func(key string, value []byte) error {
+	return func(key string, value []byte) error {
+		v.visited = append(v.visited, key)
+		return f(key, value)
+	}
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+)
+
+// KeyRange selects the keys lexicographically between `Low` (inclusive) and
+// `High` (exclusive), an empty `High` meaning no upper bound.
+type KeyRange struct {
+	Low  string
+	High string
+}
+
+// PrefixRange returns the range of all the keys starting with `prefix`.
+func PrefixRange(prefix string) KeyRange {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			return KeyRange{Low: prefix, High: prefix[:i] + string([]byte{prefix[i] + 1})}
+		}
+	}
+	return KeyRange{Low: prefix}
+}
+
+func (r KeyRange) Contains(key string) bool {
+	return key >= r.Low && (r.High == "" || key < r.High)
+}
+
+type KeyValue struct {
+	Key   string
+	Value []byte
+}
+
+// ScanFirst returns, in ascending key order, up to `limit` entries of the
+// range as they were before any of the block's deltas, `more` being true
+// when entries were left out. A `limit` of 0 returns all entries.
+//
+// The next page is obtained by scanning again from the last key returned
+// followed by a zero byte.
+func (b *baseStore) ScanFirst(keyRange KeyRange, limit int) (entries []*KeyValue, more bool) {
+	return b.scan(keyRange, limit, func(*pbssinternal.StoreDelta) bool { return true })
+}
+
+// ScanLast is like ScanFirst, with the state including all of the block's deltas.
+func (b *baseStore) ScanLast(keyRange KeyRange, limit int) (entries []*KeyValue, more bool) {
+	return b.scan(keyRange, limit, func(*pbssinternal.StoreDelta) bool { return false })
+}
+
+// ScanAt is like ScanFirst, with the state that includes the processing of `ord`.
+func (b *baseStore) ScanAt(ord uint64, keyRange KeyRange, limit int) (entries []*KeyValue, more bool) {
+	return b.scan(keyRange, limit, func(delta *pbssinternal.StoreDelta) bool { return delta.Ordinal > ord })
+}
+
+// scan reverts, from the current state, the trailing deltas for which `revert` returns true.
+// It seeks to the start of the range, merging in key order the values the keys of the
+// reverted deltas had before them, and stops at the end of the range or after `limit`
+// entries, the next one only telling there are more.
+func (b *baseStore) scan(keyRange KeyRange, limit int, revert func(delta *pbssinternal.StoreDelta) bool) (entries []*KeyValue, more bool) {
+	// reverted maps the keys of the range to their first reverted delta, holding their value before
+	reverted := make(map[string]*pbssinternal.StoreDelta)
+	for i := len(b.deltas) - 1; i >= 0; i-- {
+		delta := b.deltas[i]
+		if !revert(delta) {
+			break
+		}
+		if !keyRange.Contains(delta.Key) {
+			continue
+		}
+
+		switch delta.Operation {
+		case pbssinternal.StoreDelta_DELETE, pbssinternal.StoreDelta_UPDATE, pbssinternal.StoreDelta_CREATE:
+			reverted[delta.Key] = delta
+		default:
+			panic(fmt.Sprintf("invalid value %q for pbssinternal.StoreDelta::Op for key %q", delta.Operation, delta.Key))
+		}
+	}
+	revertedKeys := make([]string, 0, len(reverted))
+	for key := range reverted {
+		revertedKeys = append(revertedKeys, key)
+	}
+	sort.Strings(revertedKeys)
+
+	// add appends an entry, returning false once the page is full, the entry being left out
+	entries = []*KeyValue{}
+	add := func(key string, value []byte) bool {
+		if limit > 0 && len(entries) == limit {
+			more = true
+			return false
+		}
+		entries = append(entries, &KeyValue{Key: key, Value: value})
+		return true
+	}
+	addReverted := func(key string) bool {
+		delta := reverted[key]
+		if delta.Operation == pbssinternal.StoreDelta_CREATE {
+			return true
+		}
+		return add(key, delta.OldValue)
+	}
+
+	_ = b.kvIterFrom(keyRange.Low, func(key string, value []byte) error {
+		if !keyRange.Contains(key) {
+			return errStopIter
+		}
+		for len(revertedKeys) > 0 && revertedKeys[0] <= key {
+			revertedKey := revertedKeys[0]
+			revertedKeys = revertedKeys[1:]
+			if !addReverted(revertedKey) {
+				return errStopIter
+			}
+			if revertedKey == key {
+				return nil
+			}
+		}
+		if !add(key, value) {
+			return errStopIter
+		}
+		return nil
+	})
+	if more {
+		return entries, more
+	}
+
+	for _, key := range revertedKeys {
+		if !addReverted(key) {
+			break
+		}
+	}
+	return entries, more
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestPrefixRange(t *testing.T) {
+	assert.Equal(t, KeyRange{Low: "abc", High: "abd"}, PrefixRange("abc"))
+	assert.Equal(t, KeyRange{Low: "ab\xff", High: "ac"}, PrefixRange("ab\xff"))
+	assert.Equal(t, KeyRange{Low: "\xff\xff"}, PrefixRange("\xff\xff"))
+	assert.Equal(t, KeyRange{}, PrefixRange(""))
+
+	assert.True(t, PrefixRange("ab").Contains("ab"))
+	assert.True(t, PrefixRange("ab").Contains("ab\xff\xff"))
+	assert.False(t, PrefixRange("ab").Contains("ac"))
+	assert.False(t, PrefixRange("ab").Contains("a"))
+}
+
+func TestBaseStore_Scan(t *testing.T) {
+	s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	s.kv.Set("pos:1", []byte("a"))
+	s.kv.Set("pos:2", []byte("b"))
+	s.kv.Set("pos:4", []byte("d"))
+	s.kv.Set("other", []byte("x"))
+
+	s.Set(10, "pos:3", "c")
+	s.Set(20, "pos:1", "a2")
+	s.DeletePrefix(30, "pos:2")
+
+	keyValues := func(entries []*KeyValue) (out []string) {
+		for _, entry := range entries {
+			out = append(out, entry.Key+"="+string(entry.Value))
+		}
+		return out
+	}
+
+	entries, more := s.ScanFirst(PrefixRange("pos:"), 0)
+	assert.Equal(t, []string{"pos:1=a", "pos:2=b", "pos:4=d"}, keyValues(entries))
+	assert.False(t, more)
+
+	entries, more = s.ScanLast(PrefixRange("pos:"), 0)
+	assert.Equal(t, []string{"pos:1=a2", "pos:3=c", "pos:4=d"}, keyValues(entries))
+	assert.False(t, more)
+
+	entries, _ = s.ScanAt(10, PrefixRange("pos:"), 0)
+	assert.Equal(t, []string{"pos:1=a", "pos:2=b", "pos:3=c", "pos:4=d"}, keyValues(entries))
+
+	entries, _ = s.ScanAt(20, PrefixRange("pos:"), 0)
+	assert.Equal(t, []string{"pos:1=a2", "pos:2=b", "pos:3=c", "pos:4=d"}, keyValues(entries))
+
+	entries, _ = s.ScanAt(20, KeyRange{Low: "pos:2", High: "pos:4"}, 0)
+	assert.Equal(t, []string{"pos:2=b", "pos:3=c"}, keyValues(entries))
+
+	// Paging through the range, each page starting right after the previous one's last key
+	var pages [][]string
+	keyRange := KeyRange{Low: "a"}
+	for {
+		entries, more := s.ScanLast(keyRange, 2)
+		pages = append(pages, keyValues(entries))
+		if !more {
+			break
+		}
+		keyRange.Low = entries[len(entries)-1].Key + "\x00"
+	}
+	assert.Equal(t, [][]string{
+		{"other=x", "pos:1=a2"},
+		{"pos:3=c", "pos:4=d"},
+	}, pages)
+
+	// Paging one entry at a time through reverted deltas
+	var keys []string
+	keyRange = PrefixRange("pos:")
+	for {
+		entries, more := s.ScanAt(10, keyRange, 1)
+		keys = append(keys, keyValues(entries)...)
+		if !more {
+			break
+		}
+		keyRange.Low = entries[len(entries)-1].Key + "\x00"
+	}
+	assert.Equal(t, []string{"pos:1=a", "pos:2=b", "pos:3=c", "pos:4=d"}, keys)
+
+	// Reverted creations are left out of the page
+	entries, more = s.ScanFirst(KeyRange{Low: "pos:2\x00", High: "pos;"}, 1)
+	assert.Equal(t, []string{"pos:4=d"}, keyValues(entries))
+	assert.False(t, more)
+}
+
+func TestBaseStore_Scan_VisitsRangeOnly(t *testing.T) {
+	backends := map[string]func(t *testing.T) KVBackend{
+		"memory": func(t *testing.T) KVBackend { return memoryKV{} },
+		"disk":   func(t *testing.T) KVBackend { return NewDiskKVBackendFactory(t.TempDir())("test") },
+	}
+
+	for name, newBackend := range backends {
+		t.Run(name, func(t *testing.T) {
+			s := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+			kv := &visitingKV{KVBackend: newBackend(t)}
+			s.replaceKV(kv)
+			defer s.Close()
+			for _, key := range []string{"a", "pos:1", "pos:2", "pos:3", "pos:4", "z"} {
+				require.NoError(t, kv.Set(key, []byte(key)))
+			}
+
+			entries, more := s.ScanLast(PrefixRange("pos:"), 2)
+			assert.Len(t, entries, 2)
+			assert.True(t, more)
+			assert.Equal(t, []string{"pos:1", "pos:2", "pos:3"}, kv.visited, "page stops after the entry telling there are more")
+
+			kv.visited = nil
+			entries, more = s.ScanLast(KeyRange{Low: "pos:3\x00", High: "pos;"}, 2)
+			assert.Len(t, entries, 1)
+			assert.False(t, more)
+			assert.Equal(t, []string{"pos:4", "z"}, kv.visited, "page stops at the first key out of the range")
+			require.NoError(t, s.Err())
+		})
+	}
+}
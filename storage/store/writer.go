@@ -2,6 +2,11 @@ package store
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/streamingfast/derr"
 	"github.com/streamingfast/dstore"
 )
 
@@ -9,8 +14,22 @@ type fileWriter struct {
 	store    dstore.Store
 	filename string
 	content  []byte
+
+	// contentFile, when set, holds the content instead of `content`. It is
+	// an unlinked temporary file, closed once written.
+	contentFile *os.File
 }
 
 func (f *fileWriter) Write(ctx context.Context) error {
-	return saveStore(ctx, f.store, f.filename, f.content)
+	if f.contentFile == nil {
+		return saveStore(ctx, f.store, f.filename, f.content)
+	}
+
+	defer f.contentFile.Close()
+	return derr.RetryContext(ctx, 5, func(ctx context.Context) error {
+		if _, err := f.contentFile.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("rewinding content file: %w", err)
+		}
+		return f.store.WriteObject(ctx, f.filename, f.contentFile)
+	})
 }
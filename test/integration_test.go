@@ -16,6 +16,7 @@ import (
 
 	"github.com/streamingfast/substreams/orchestrator/work"
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/storage/store"
 	_ "github.com/streamingfast/substreams/wasm/wasmtime"
 	_ "github.com/streamingfast/substreams/wasm/wazero"
@@ -474,3 +475,58 @@ func partialPreWork(t *testing.T, start, end uint64, module string, run *testRun
 	require.NoError(t, result.Error)
 	require.Equal(t, store.PartialFiles(fmt.Sprintf("%d-%d", start, end), store.TraceIDParam(traceID)), result.PartialFilesWritten)
 }
+
+func TestModuleLimits(t *testing.T) {
+	run := newTestRun(t, 30, 41, 41, "assert_test_store_delete_prefix")
+	for _, mod := range run.Package.Modules.Modules {
+		if mod.Name == "test_store_delete_prefix" {
+			mod.Limits = &pbsubstreams.Module_Limits{MaxStoreItemSize: 1}
+		}
+	}
+
+	err := run.Run(t, "test_store_delete_prefix")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeding the store item size limit of 1 bytes")
+}
+
+func TestModuleFuelLimitNotMetered(t *testing.T) {
+	t.Setenv("SUBSTREAMS_WASM_RUNTIME", "wazero")
+	run := newTestRun(t, 30, 41, 41, "assert_test_store_delete_prefix")
+	for _, mod := range run.Package.Modules.Modules {
+		if mod.Name == "test_store_delete_prefix" {
+			mod.Limits = &pbsubstreams.Module_Limits{MaxFuelPerBlock: 1_000_000}
+		}
+	}
+
+	err := run.Run(t, "test_store_delete_prefix")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `module "test_store_delete_prefix": limit maxFuelPerBlock is not enforced by the "wazero" wasm runtime`)
+}
+
+func TestInstancePooling(t *testing.T) {
+	for _, runtime := range []string{"wazero", "wasmtime"} {
+		t.Run(runtime, func(t *testing.T) {
+			t.Setenv("SUBSTREAMS_WASM_RUNTIME", runtime)
+
+			run := func(pooling bool) (outputs string, files map[string][]byte) {
+				t.Setenv("SUBSTREAMS_WASM_INSTANCE_POOLING_ENABLED", fmt.Sprintf("%t", pooling))
+				run := newTestRun(t, 1, 31, 31, "assert_all_test")
+				require.NoError(t, run.Run(t, "assert_all_test"))
+
+				files = map[string][]byte{}
+				for _, file := range listFiles(t, run.TempDir) {
+					parts := strings.Split(file, string(os.PathSeparator))
+					cnt, err := os.ReadFile(filepath.Join(run.TempDir, file))
+					require.NoError(t, err)
+					files[filepath.Join(parts[3:]...)] = cnt
+				}
+				return run.MapOutput("assert_all_test") + run.MapOutput("assert_test_store_add_i64"), files
+			}
+
+			expectedOutputs, expectedFiles := run(false)
+			outputs, files := run(true)
+			assert.Equal(t, expectedOutputs, outputs)
+			assert.Equal(t, expectedFiles, files)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	"github.com/streamingfast/substreams/reqctx"
+	"github.com/streamingfast/substreams/service"
+)
+
+func TestLocalService(t *testing.T) {
+	tests := []struct {
+		name                  string
+		startBlock            int64
+		stopBlock             uint64
+		production            bool
+		expectedResponseCount int
+		expectFiles           []string
+	}{
+		{
+			name:                  "dev_mode",
+			startBlock:            25,
+			stopBlock:             29,
+			expectedResponseCount: 4,
+			expectFiles: []string{
+				"states/0000000010-0000000001.kv",
+				"states/0000000020-0000000001.kv",
+			},
+		},
+		{
+			name:                  "production_mode",
+			startBlock:            25,
+			stopBlock:             38,
+			production:            true,
+			expectedResponseCount: 13,
+			expectFiles: []string{
+				"states/0000000010-0000000001.kv",
+				"states/0000000020-0000000001.kv",
+				"states/0000000030-0000000001.kv",
+				"outputs/0000000020-0000000030.output",
+				"outputs/0000000030-0000000038.output",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			mergedBlocksStore := writeMergedBlocks(t, filepath.Join(tempDir, "merged-blocks"), 0, 99)
+
+			stateStore, err := dstore.NewStore(filepath.Join(tempDir, "test.store"), "", "none", true)
+			require.NoError(t, err)
+
+			svc := service.NewLocal(stateStore, mergedBlocksStore, "sf.substreams.v1.test.Block", 5, 10, service.WithCacheSaveInterval(10))
+
+			pkg := manifest.TestReadManifest(t, "./testdata/substreams-test-v0.1.0.spkg")
+			request := &pbsubstreamsrpc.Request{
+				StartBlockNum:  test.startBlock,
+				StopBlockNum:   test.stopBlock,
+				Modules:        pkg.Modules,
+				OutputModule:   "assert_test_store_add_i64",
+				ProductionMode: test.production,
+			}
+
+			run := &testRun{TempDir: tempDir}
+			ctx := reqctx.WithLogger(context.Background(), zlog)
+			require.NoError(t, svc.Blocks(ctx, request, func(resp substreams.ResponseFromAnyTier) error {
+				run.Responses = append(run.Responses, resp.(*pbsubstreamsrpc.Response))
+				return nil
+			}))
+
+			mapOutput := run.MapOutput("assert_test_store_add_i64")
+			assert.Contains(t, mapOutput, `assert_test_store_add_i64: 0801`)
+			assert.Equal(t, test.expectedResponseCount, strings.Count(mapOutput, "\n"))
+
+			var producedFiles []string
+			for _, f := range listFiles(t, filepath.Join(tempDir, "test.store")) {
+				parts := strings.Split(f, "/")
+				producedFiles = append(producedFiles, filepath.Join(parts[2:]...))
+			}
+			for _, f := range test.expectFiles {
+				assert.Contains(t, producedFiles, f)
+			}
+		})
+	}
+}
+
+func TestLocalServiceRuntimeVerification(t *testing.T) {
+	tests := []struct {
+		runtime             string
+		verificationRuntime string
+		expectError         string
+	}{
+		{runtime: "wazero", verificationRuntime: "wasmtime"},
+		{runtime: "wasmtime", verificationRuntime: "wazero"},
+		{runtime: "wazero", verificationRuntime: "wazero", expectError: `wasm runtime "wazero" is already the runtime in use`},
+		{runtime: "wazero", verificationRuntime: "unknown", expectError: `unknown wasm runtime "unknown"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.runtime+"_"+test.verificationRuntime, func(t *testing.T) {
+			t.Setenv("SUBSTREAMS_WASM_RUNTIME", test.runtime)
+
+			tempDir := t.TempDir()
+			mergedBlocksStore := writeMergedBlocks(t, filepath.Join(tempDir, "merged-blocks"), 0, 99)
+
+			stateStore, err := dstore.NewStore(filepath.Join(tempDir, "test.store"), "", "none", true)
+			require.NoError(t, err)
+
+			svc := service.NewLocal(stateStore, mergedBlocksStore, "sf.substreams.v1.test.Block", 1, 10, service.WithCacheSaveInterval(10), service.WithWASMRuntimeVerification(test.verificationRuntime))
+
+			pkg := manifest.TestReadManifest(t, "./testdata/substreams-test-v0.1.0.spkg")
+			request := &pbsubstreamsrpc.Request{
+				StartBlockNum: 25,
+				StopBlockNum:  29,
+				Modules:       pkg.Modules,
+				OutputModule:  "assert_test_store_add_i64",
+			}
+
+			run := &testRun{TempDir: tempDir}
+			ctx := reqctx.WithLogger(context.Background(), zlog)
+			err = svc.Blocks(ctx, request, func(resp substreams.ResponseFromAnyTier) error {
+				run.Responses = append(run.Responses, resp.(*pbsubstreamsrpc.Response))
+				return nil
+			})
+			if test.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 4, strings.Count(run.MapOutput("assert_test_store_add_i64"), "\n"))
+		})
+	}
+}
+
+// writeMergedBlocks writes a merged blocks file for the blocks `start` to `inclusiveStop`, all within the same bundle.
+func writeMergedBlocks(t *testing.T, dir string, start, inclusiveStop uint64) dstore.Store {
+	t.Helper()
+
+	mergedBlocksStore, err := dstore.NewDBinStore(dir)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	writer, err := bstream.NewDBinBlockWriter(buf, "TST", 1)
+	require.NoError(t, err)
+	for _, generated := range (&LinearBlockGenerator{startBlock: start, inclusiveStopBlock: inclusiveStop}).Generate() {
+		require.NoError(t, writer.Write(generated.block))
+	}
+
+	require.NoError(t, mergedBlocksStore.WriteObject(context.Background(), "0000000000", buf))
+	return mergedBlocksStore
+}
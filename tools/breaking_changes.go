@@ -0,0 +1,356 @@
+package tools
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+var breakingChangesCmd = &cobra.Command{
+	Use:   "breaking-changes <old_package> <new_package>",
+	Short: "Compare two versions of a package, listing the modules to re-process and the breaking changes",
+	Long: cli.Dedent(`
+		Loads two versions of a package (manifests, local or remote '.spkg' files) and compares their modules:
+
+		- modules whose hash changed, their cached outputs and stores being invalidated and re-processed;
+		- breaking changes for the consumers of the package: removed modules, changed kinds, output types or store
+		  update policies, and wire-incompatible changes to the protobuf messages of outputs and params (removed
+		  fields, renumbered fields, incompatible field types);
+		- other changes, like changed inputs or initial blocks, or renamed fields which only break JSON decoding.
+
+		The command exits with an error when breaking changes are found, or when modules need re-processing
+		with '--fail-on-reprocessing', to be used in CI.
+	`),
+	Example: string(cli.ExamplePrefixed("substreams tools breaking-changes", `
+		https://github.com/streamingfast/substreams-uniswap-v3/releases/download/v0.2.7/substreams.spkg ./substreams.yaml
+		uniswap-v3-v0.2.7.spkg uniswap-v3-v0.2.8.spkg --fail-on-reprocessing
+	`)),
+	RunE:         runBreakingChangesE,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+}
+
+func init() {
+	breakingChangesCmd.Flags().Bool("fail-on-reprocessing", false, "Also exit with an error when modules need re-processing")
+
+	Cmd.AddCommand(breakingChangesCmd)
+}
+
+func runBreakingChangesE(cmd *cobra.Command, args []string) error {
+	readPackage := func(input string) (*pbsubstreams.Package, error) {
+		manifestReader, err := manifest.NewReader(input)
+		if err != nil {
+			return nil, fmt.Errorf("manifest reader: %w", err)
+		}
+		pkg, err := manifestReader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read manifest %q: %w", input, err)
+		}
+		return pkg, nil
+	}
+
+	oldPkg, err := readPackage(args[0])
+	if err != nil {
+		return err
+	}
+	newPkg, err := readPackage(args[1])
+	if err != nil {
+		return err
+	}
+
+	report, err := comparePackages(oldPkg, newPkg)
+	if err != nil {
+		return err
+	}
+	report.print(cmd.OutOrStdout())
+
+	if len(report.breaking) != 0 {
+		return fmt.Errorf("%d breaking changes found", len(report.breaking))
+	}
+	if mustGetBool(cmd, "fail-on-reprocessing") && len(report.reprocessed) != 0 {
+		return fmt.Errorf("%d modules need re-processing", len(report.reprocessed))
+	}
+	return nil
+}
+
+type packageChanges struct {
+	reprocessed []string
+	breaking    []string
+	other       []string
+}
+
+func (c *packageChanges) print(w io.Writer) {
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s:\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+		fmt.Fprintln(w)
+	}
+
+	section("Modules to re-process", c.reprocessed)
+	section("Breaking changes", c.breaking)
+	section("Other changes", c.other)
+	fmt.Fprintf(w, "%d modules to re-process, %d breaking changes, %d other changes\n", len(c.reprocessed), len(c.breaking), len(c.other))
+}
+
+// comparePackages lists the changes between the modules of `oldPkg` and `newPkg`, in
+// the order of the modules of `oldPkg`, followed by the protobuf messages they use.
+func comparePackages(oldPkg, newPkg *pbsubstreams.Package) (*packageChanges, error) {
+	oldHashes, err := moduleHashes(oldPkg)
+	if err != nil {
+		return nil, fmt.Errorf("hashing modules of old package: %w", err)
+	}
+	newHashes, err := moduleHashes(newPkg)
+	if err != nil {
+		return nil, fmt.Errorf("hashing modules of new package: %w", err)
+	}
+
+	oldFiles, err := desc.CreateFileDescriptors(oldPkg.ProtoFiles)
+	if err != nil {
+		return nil, fmt.Errorf("old package protobuf definitions: %w", err)
+	}
+	newFiles, err := desc.CreateFileDescriptors(newPkg.ProtoFiles)
+	if err != nil {
+		return nil, fmt.Errorf("new package protobuf definitions: %w", err)
+	}
+
+	newModules := map[string]*pbsubstreams.Module{}
+	for _, mod := range newPkg.Modules.Modules {
+		newModules[mod.Name] = mod
+	}
+
+	changes := &packageChanges{}
+	messages := &messageComparator{oldFiles: oldFiles, newFiles: newFiles, changes: changes, seen: map[string]bool{}}
+	var messageRoots []string
+
+	for _, oldMod := range oldPkg.Modules.Modules {
+		newMod := newModules[oldMod.Name]
+		if newMod == nil {
+			changes.breaking = append(changes.breaking, fmt.Sprintf("module %q: removed", oldMod.Name))
+			continue
+		}
+
+		if oldHashes[oldMod.Name] != newHashes[newMod.Name] {
+			changes.reprocessed = append(changes.reprocessed, fmt.Sprintf("%s (%s => %s)", oldMod.Name, oldHashes[oldMod.Name], newHashes[newMod.Name]))
+		}
+
+		oldKind, newKind := moduleKind(oldMod), moduleKind(newMod)
+		if oldKind != newKind {
+			changes.breaking = append(changes.breaking, fmt.Sprintf("module %q: kind changed from %s to %s", oldMod.Name, oldKind, newKind))
+			continue
+		}
+
+		oldType, newType := manifest.ModuleOutputType(oldMod), manifest.ModuleOutputType(newMod)
+		if oldType != newType {
+			changes.breaking = append(changes.breaking, fmt.Sprintf("module %q: output type changed from %q to %q", oldMod.Name, oldType, newType))
+		} else if strings.HasPrefix(oldType, "proto:") {
+			messageRoots = append(messageRoots, strings.TrimPrefix(oldType, "proto:"))
+		}
+
+		if oldStore, newStore := oldMod.GetKindStore(), newMod.GetKindStore(); oldStore != nil && oldStore.UpdatePolicy != newStore.UpdatePolicy {
+			changes.breaking = append(changes.breaking, fmt.Sprintf("module %q: update policy changed from %s to %s", oldMod.Name, oldStore.UpdatePolicy, newStore.UpdatePolicy))
+		}
+
+		oldParams, newParams := moduleParamsType(oldMod), moduleParamsType(newMod)
+		if oldParams != newParams {
+			changes.breaking = append(changes.breaking, fmt.Sprintf("module %q: params type changed from %q to %q", oldMod.Name, oldParams, newParams))
+		} else if strings.HasPrefix(oldParams, "proto:") {
+			messageRoots = append(messageRoots, strings.TrimPrefix(oldParams, "proto:"))
+		}
+
+		oldInputs, newInputs := moduleInputs(oldMod), moduleInputs(newMod)
+		if oldInputs != newInputs {
+			changes.other = append(changes.other, fmt.Sprintf("module %q: inputs changed from [%s] to [%s]", oldMod.Name, oldInputs, newInputs))
+		}
+		if oldMod.InitialBlock != newMod.InitialBlock {
+			changes.other = append(changes.other, fmt.Sprintf("module %q: initial block changed from %d to %d", oldMod.Name, oldMod.InitialBlock, newMod.InitialBlock))
+		}
+	}
+
+	for _, root := range messageRoots {
+		messages.compare(root)
+	}
+
+	return changes, nil
+}
+
+func moduleHashes(pkg *pbsubstreams.Package) (map[string]string, error) {
+	graph, err := manifest.NewModuleGraph(pkg.Modules.Modules)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := manifest.NewModuleHashes()
+	out := map[string]string{}
+	for _, mod := range pkg.Modules.Modules {
+		hash, err := hashes.HashModule(pkg.Modules, mod, graph)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", mod.Name, err)
+		}
+		out[mod.Name] = hex.EncodeToString(hash)
+	}
+	return out, nil
+}
+
+func moduleKind(mod *pbsubstreams.Module) string {
+	switch mod.Kind.(type) {
+	case *pbsubstreams.Module_KindMap_:
+		return "map"
+	case *pbsubstreams.Module_KindStore_:
+		return "store"
+	case *pbsubstreams.Module_KindBlockIndex_:
+		return "blockIndex"
+	}
+	return "unknown"
+}
+
+func moduleParamsType(mod *pbsubstreams.Module) string {
+	if len(mod.Inputs) == 0 || mod.Inputs[0].GetParams() == nil {
+		return ""
+	}
+	if paramsType := mod.Inputs[0].GetParams().Type; paramsType != "" {
+		return paramsType
+	}
+	return "string"
+}
+
+func moduleInputs(mod *pbsubstreams.Module) string {
+	var inputs []string
+	for _, input := range mod.Inputs {
+		switch in := input.Input.(type) {
+		case *pbsubstreams.Module_Input_Source_:
+			inputs = append(inputs, "source: "+in.Source.Type)
+		case *pbsubstreams.Module_Input_Map_:
+			inputs = append(inputs, "map: "+in.Map.ModuleName)
+		case *pbsubstreams.Module_Input_Store_:
+			inputs = append(inputs, fmt.Sprintf("store: %s (%s)", in.Store.ModuleName, strings.ToLower(in.Store.Mode.String())))
+		case *pbsubstreams.Module_Input_Params_:
+			inputs = append(inputs, "params")
+		}
+	}
+	return strings.Join(inputs, ", ")
+}
+
+// messageComparator compares the versions of protobuf messages found in the old and
+// new package, recursing in the message fields whose type did not change.
+type messageComparator struct {
+	oldFiles map[string]*desc.FileDescriptor
+	newFiles map[string]*desc.FileDescriptor
+	changes  *packageChanges
+	seen     map[string]bool
+}
+
+func (c *messageComparator) compare(messageType string) {
+	if c.seen[messageType] {
+		return
+	}
+	c.seen[messageType] = true
+
+	oldMsg, newMsg := manifest.FindMessageDescriptor(c.oldFiles, messageType), manifest.FindMessageDescriptor(c.newFiles, messageType)
+	if oldMsg == nil {
+		// Undefined in the old package, nothing to compare against
+		return
+	}
+	if newMsg == nil {
+		c.changes.breaking = append(c.changes.breaking, fmt.Sprintf("message %q: removed", messageType))
+		return
+	}
+
+	newFieldsByName := map[string]*desc.FieldDescriptor{}
+	for _, field := range newMsg.GetFields() {
+		newFieldsByName[field.GetName()] = field
+	}
+
+	for _, oldField := range oldMsg.GetFields() {
+		newField := newMsg.FindFieldByNumber(oldField.GetNumber())
+		if renumbered := newFieldsByName[oldField.GetName()]; renumbered != nil && renumbered.GetNumber() != oldField.GetNumber() {
+			c.changes.breaking = append(c.changes.breaking, fmt.Sprintf("message %q: field %q renumbered from %d to %d", messageType, oldField.GetName(), oldField.GetNumber(), renumbered.GetNumber()))
+			if newField == nil {
+				continue
+			}
+		}
+		if newField == nil {
+			c.changes.breaking = append(c.changes.breaking, fmt.Sprintf("message %q: field %d %q removed", messageType, oldField.GetNumber(), oldField.GetName()))
+			continue
+		}
+
+		if newField.GetName() != oldField.GetName() {
+			c.changes.other = append(c.changes.other, fmt.Sprintf("message %q: field %d renamed from %q to %q, breaking JSON decoding only", messageType, oldField.GetNumber(), oldField.GetName(), newField.GetName()))
+		}
+
+		oldType, newType := fieldTypeName(oldField), fieldTypeName(newField)
+		if oldType == newType {
+			if oldField.GetMessageType() != nil {
+				c.compare(oldField.GetMessageType().GetFullyQualifiedName())
+			}
+			continue
+		}
+
+		change := fmt.Sprintf("message %q: field %d %q type changed from %s to %s", messageType, oldField.GetNumber(), oldField.GetName(), oldType, newType)
+		if wireCompatibleFields(oldField, newField) {
+			c.changes.other = append(c.changes.other, change+", wire-compatible")
+		} else {
+			c.changes.breaking = append(c.changes.breaking, change)
+		}
+	}
+}
+
+func fieldTypeName(field *desc.FieldDescriptor) string {
+	var typeName string
+	switch {
+	case field.GetMessageType() != nil:
+		typeName = field.GetMessageType().GetFullyQualifiedName()
+	case field.GetEnumType() != nil:
+		typeName = field.GetEnumType().GetFullyQualifiedName()
+	default:
+		typeName = strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+	}
+
+	if field.IsRepeated() && !field.IsMap() {
+		return "repeated " + typeName
+	}
+	return typeName
+}
+
+// wireCompatibleFields returns whether values encoded as `oldField` decode as `newField`,
+// following the protobuf rules on updating message types.
+func wireCompatibleFields(oldField, newField *desc.FieldDescriptor) bool {
+	if oldField.IsRepeated() != newField.IsRepeated() || oldField.IsMap() || newField.IsMap() {
+		return false
+	}
+
+	group := func(t descriptorpb.FieldDescriptorProto_Type) int {
+		switch t {
+		case descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64,
+			descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+			descriptorpb.FieldDescriptorProto_TYPE_BOOL, descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+			return 1
+		case descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SINT64:
+			return 2
+		case descriptorpb.FieldDescriptorProto_TYPE_FIXED32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+			return 3
+		case descriptorpb.FieldDescriptorProto_TYPE_FIXED64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+			return 4
+		case descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+			return 5
+		}
+		// Messages of different types are never considered compatible
+		return 0
+	}
+
+	oldGroup := group(oldField.GetType())
+	return oldGroup != 0 && oldGroup == group(newField.GetType())
+}
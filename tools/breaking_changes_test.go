@@ -0,0 +1,380 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestComparePackages(t *testing.T) {
+	tests := []struct {
+		name              string
+		change            func(pkg *pbsubstreams.Package)
+		expectReprocessed []string
+		expectBreaking    []string
+		expectOther       []string
+	}{
+		{
+			name:   "identical",
+			change: func(pkg *pbsubstreams.Package) {},
+		},
+		{
+			name: "field removed",
+			change: func(pkg *pbsubstreams.Package) {
+				removeField(testMessage(pkg, "Output"), "name")
+			},
+			expectBreaking: []string{`message "test.v1.Output": field 1 "name" removed`},
+		},
+		{
+			name: "field renumbered",
+			change: func(pkg *pbsubstreams.Package) {
+				testField(testMessage(pkg, "Output"), "name").Number = proto.Int32(10)
+			},
+			expectBreaking: []string{`message "test.v1.Output": field "name" renumbered from 1 to 10`},
+		},
+		{
+			name: "field renumbered, another field taking its number",
+			change: func(pkg *pbsubstreams.Package) {
+				output := testMessage(pkg, "Output")
+				testField(output, "name").Number = proto.Int32(10)
+				output.Field = append(output.Field, scalarField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING))
+			},
+			expectBreaking: []string{`message "test.v1.Output": field "name" renumbered from 1 to 10`},
+			expectOther:    []string{`message "test.v1.Output": field 1 renamed from "name" to "title", breaking JSON decoding only`},
+		},
+		{
+			name: "field renumbered, another field of another type taking its number",
+			change: func(pkg *pbsubstreams.Package) {
+				output := testMessage(pkg, "Output")
+				testField(output, "name").Number = proto.Int32(10)
+				output.Field = append(output.Field, scalarField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64))
+			},
+			expectBreaking: []string{
+				`message "test.v1.Output": field "name" renumbered from 1 to 10`,
+				`message "test.v1.Output": field 1 "name" type changed from string to uint64`,
+			},
+			expectOther: []string{`message "test.v1.Output": field 1 renamed from "name" to "id", breaking JSON decoding only`},
+		},
+		{
+			name: "wire-compatible type change",
+			change: func(pkg *pbsubstreams.Package) {
+				testField(testMessage(pkg, "Output"), "count").Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+			},
+			expectOther: []string{`message "test.v1.Output": field 3 "count" type changed from int32 to int64, wire-compatible`},
+		},
+		{
+			name: "wire-compatible string to bytes",
+			change: func(pkg *pbsubstreams.Package) {
+				testField(testMessage(pkg, "Output"), "name").Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
+			},
+			expectOther: []string{`message "test.v1.Output": field 1 "name" type changed from string to bytes, wire-compatible`},
+		},
+		{
+			name: "wire-incompatible type change",
+			change: func(pkg *pbsubstreams.Package) {
+				testField(testMessage(pkg, "Output"), "count").Type = descriptorpb.FieldDescriptorProto_TYPE_SINT32.Enum()
+			},
+			expectBreaking: []string{`message "test.v1.Output": field 3 "count" type changed from int32 to sint32`},
+		},
+		{
+			name: "singular to repeated",
+			change: func(pkg *pbsubstreams.Package) {
+				testField(testMessage(pkg, "Output"), "count").Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			},
+			expectBreaking: []string{`message "test.v1.Output": field 3 "count" type changed from int32 to repeated int32`},
+		},
+		{
+			name: "nested message field changed",
+			change: func(pkg *pbsubstreams.Package) {
+				nested := testMessage(pkg, "Nested")
+				testField(nested, "value").Type = descriptorpb.FieldDescriptorProto_TYPE_FIXED64.Enum()
+				removeField(nested, "label")
+			},
+			expectBreaking: []string{
+				`message "test.v1.Nested": field 1 "value" type changed from uint64 to fixed64`,
+				`message "test.v1.Nested": field 2 "label" removed`,
+			},
+		},
+		{
+			name: "nested message type changed",
+			change: func(pkg *pbsubstreams.Package) {
+				testField(testMessage(pkg, "Output"), "nested").TypeName = proto.String(".test.v1.Output")
+			},
+			expectBreaking: []string{`message "test.v1.Output": field 2 "nested" type changed from test.v1.Nested to test.v1.Output`},
+		},
+		{
+			name: "params message field changed",
+			change: func(pkg *pbsubstreams.Package) {
+				testField(testMessage(pkg, "Params"), "threshold").Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+			},
+			expectBreaking: []string{`message "test.v1.Params": field 1 "threshold" type changed from uint64 to string`},
+		},
+		{
+			name: "params value changed, re-processing dependent modules",
+			change: func(pkg *pbsubstreams.Package) {
+				pkg.Modules.Modules[0].Inputs[0].GetParams().TypedValue = []byte{0x08, 0x02}
+			},
+			expectReprocessed: []string{"map_output", "store_totals"},
+		},
+		{
+			name: "output message removed",
+			change: func(pkg *pbsubstreams.Package) {
+				file := pkg.ProtoFiles[0]
+				file.MessageType = file.MessageType[1:]
+			},
+			expectBreaking: []string{`message "test.v1.Output": removed`},
+		},
+		{
+			name: "initial block changed, re-processing dependent modules",
+			change: func(pkg *pbsubstreams.Package) {
+				pkg.Modules.Modules[0].InitialBlock = 100
+			},
+			expectReprocessed: []string{"map_output", "store_totals"},
+			expectOther:       []string{`module "map_output": initial block changed from 10 to 100`},
+		},
+		{
+			name: "binary changed",
+			change: func(pkg *pbsubstreams.Package) {
+				pkg.Modules.Binaries[0].Content = []byte("new code")
+			},
+			expectReprocessed: []string{"map_output", "store_totals"},
+		},
+		{
+			name: "store update policy changed",
+			change: func(pkg *pbsubstreams.Package) {
+				pkg.Modules.Modules[1].GetKindStore().UpdatePolicy = pbsubstreams.Module_KindStore_UPDATE_POLICY_MAX
+			},
+			expectBreaking: []string{`module "store_totals": update policy changed from UPDATE_POLICY_ADD to UPDATE_POLICY_MAX`},
+		},
+		{
+			name: "output type changed",
+			change: func(pkg *pbsubstreams.Package) {
+				pkg.Modules.Modules[0].GetKindMap().OutputType = "proto:test.v1.Nested"
+			},
+			expectBreaking: []string{`module "map_output": output type changed from "proto:test.v1.Output" to "proto:test.v1.Nested"`},
+		},
+		{
+			name: "kind changed",
+			change: func(pkg *pbsubstreams.Package) {
+				pkg.Modules.Modules[1].Kind = &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "int64"}}
+			},
+			expectReprocessed: []string{"store_totals"},
+			expectBreaking:    []string{`module "store_totals": kind changed from store to map`},
+		},
+		{
+			name: "module removed",
+			change: func(pkg *pbsubstreams.Package) {
+				pkg.Modules.Modules = pkg.Modules.Modules[:1]
+			},
+			expectBreaking: []string{`module "store_totals": removed`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldPkg := newBreakingChangesTestPackage()
+			newPkg := newBreakingChangesTestPackage()
+			test.change(newPkg)
+
+			changes, err := comparePackages(oldPkg, newPkg)
+			require.NoError(t, err)
+
+			oldHashes, err := moduleHashes(oldPkg)
+			require.NoError(t, err)
+			newHashes, err := moduleHashes(newPkg)
+			require.NoError(t, err)
+			var expectReprocessed []string
+			for _, name := range test.expectReprocessed {
+				expectReprocessed = append(expectReprocessed, fmt.Sprintf("%s (%s => %s)", name, oldHashes[name], newHashes[name]))
+			}
+
+			assert.Equal(t, expectReprocessed, changes.reprocessed, "reprocessed")
+			assert.Equal(t, test.expectBreaking, changes.breaking, "breaking")
+			assert.Equal(t, test.expectOther, changes.other, "other")
+		})
+	}
+}
+
+func TestWireCompatibleFields(t *testing.T) {
+	mapEntry := &descriptorpb.DescriptorProto{
+		Name:    proto.String("EntriesEntry"),
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		Field: []*descriptorpb.FieldDescriptorProto{
+			scalarField("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			scalarField("value", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		},
+	}
+	entries := messageField("entries", 100, ".test.v1.Types.EntriesEntry")
+	entries.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	repeatedInt32 := scalarField("repeated_int32", 101, descriptorpb.FieldDescriptorProto_TYPE_INT32)
+	repeatedInt32.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	enum := scalarField("enum", 102, descriptorpb.FieldDescriptorProto_TYPE_ENUM)
+	enum.TypeName = proto.String(".test.v1.Kind")
+
+	fields := []*descriptorpb.FieldDescriptorProto{entries, repeatedInt32, enum, messageField("message", 103, ".test.v1.Types"), messageField("other_message", 104, ".test.v1.Other")}
+	for i, fieldType := range []descriptorpb.FieldDescriptorProto_Type{
+		descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	} {
+		name := strings.ToLower(strings.TrimPrefix(fieldType.String(), "TYPE_"))
+		fields = append(fields, scalarField(name, int32(i+1), fieldType))
+	}
+
+	files, err := desc.CreateFileDescriptors([]*descriptorpb.FileDescriptorProto{{
+		Name:     proto.String("types.proto"),
+		Package:  proto.String("test.v1"),
+		Syntax:   proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{Name: proto.String("Kind"), Value: []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String("KIND_UNSET"), Number: proto.Int32(0)}}}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Types"), Field: fields, NestedType: []*descriptorpb.DescriptorProto{mapEntry}},
+			{Name: proto.String("Other")},
+		},
+	}})
+	require.NoError(t, err)
+	types := files["types.proto"].FindMessage("test.v1.Types")
+
+	tests := []struct {
+		oldField   string
+		newField   string
+		compatible bool
+	}{
+		{"int32", "uint64", true},
+		{"int32", "bool", true},
+		{"enum", "int32", true},
+		{"sint32", "sint64", true},
+		{"fixed32", "sfixed32", true},
+		{"fixed64", "sfixed64", true},
+		{"string", "bytes", true},
+		{"int32", "sint32", false},
+		{"fixed32", "fixed64", false},
+		{"double", "fixed64", false},
+		{"int32", "repeated_int32", false},
+		{"entries", "repeated_int32", false},
+		{"string", "message", false},
+		{"message", "other_message", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.oldField+" to "+test.newField, func(t *testing.T) {
+			oldField, newField := types.FindFieldByName(test.oldField), types.FindFieldByName(test.newField)
+			require.NotNil(t, oldField)
+			require.NotNil(t, newField)
+			assert.Equal(t, test.compatible, wireCompatibleFields(oldField, newField))
+		})
+	}
+}
+
+// newBreakingChangesTestPackage returns a package with a `map_output` module
+// outputting `test.v1.Output` with `test.v1.Params` params, and a
+// `store_totals` module consuming it.
+func newBreakingChangesTestPackage() *pbsubstreams.Package {
+	return &pbsubstreams.Package{
+		ProtoFiles: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("test.proto"),
+			Package: proto.String("test.v1"),
+			Syntax:  proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{
+				{
+					Name: proto.String("Output"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						scalarField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						messageField("nested", 2, ".test.v1.Nested"),
+						scalarField("count", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+					},
+				},
+				{
+					Name: proto.String("Nested"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						scalarField("value", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64),
+						scalarField("label", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					},
+				},
+				{
+					Name: proto.String("Params"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						scalarField("threshold", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT64),
+					},
+				},
+			},
+		}},
+		Modules: &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			Modules: []*pbsubstreams.Module{
+				{
+					Name:         "map_output",
+					InitialBlock: 10,
+					Kind:         &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:test.v1.Output"}},
+					Inputs: []*pbsubstreams.Module_Input{
+						{Input: &pbsubstreams.Module_Input_Params_{Params: &pbsubstreams.Module_Input_Params{Type: "proto:test.v1.Params", TypedValue: []byte{0x08, 0x01}}}},
+						{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.test.Block"}}},
+					},
+					Output: &pbsubstreams.Module_Output{Type: "proto:test.v1.Output"},
+				},
+				{
+					Name:         "store_totals",
+					InitialBlock: 10,
+					Kind:         &pbsubstreams.Module_KindStore_{KindStore: &pbsubstreams.Module_KindStore{UpdatePolicy: pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, ValueType: "int64"}},
+					Inputs: []*pbsubstreams.Module_Input{
+						{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "map_output"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func scalarField(name string, number int32, fieldType descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     fieldType.Enum(),
+	}
+}
+
+func messageField(name string, number int32, typeName string) *descriptorpb.FieldDescriptorProto {
+	field := scalarField(name, number, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	field.TypeName = proto.String(typeName)
+	return field
+}
+
+func testMessage(pkg *pbsubstreams.Package, name string) *descriptorpb.DescriptorProto {
+	for _, msg := range pkg.ProtoFiles[0].MessageType {
+		if msg.GetName() == name {
+			return msg
+		}
+	}
+	panic(fmt.Sprintf("message %q not found", name))
+}
+
+func testField(msg *descriptorpb.DescriptorProto, name string) *descriptorpb.FieldDescriptorProto {
+	for _, field := range msg.Field {
+		if field.GetName() == name {
+			return field
+		}
+	}
+	panic(fmt.Sprintf("field %q not found in message %q", name, msg.GetName()))
+}
+
+func removeField(msg *descriptorpb.DescriptorProto, name string) {
+	var fields []*descriptorpb.FieldDescriptorProto
+	for _, field := range msg.Field {
+		if field.GetName() != name {
+			fields = append(fields, field)
+		}
+	}
+	msg.Field = fields
+}
@@ -71,6 +71,8 @@ func moduleRunE(cmd *cobra.Command, args []string) error {
 	kind := "STORE"
 	if module.GetKindMap() != nil {
 		kind = "MAP"
+	} else if module.GetKindBlockIndex() != nil {
+		kind = "BLOCK_INDEX"
 	}
 
 	moduleHashes := manifest.NewModuleHashes()
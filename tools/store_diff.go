@@ -0,0 +1,326 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+)
+
+var storeDiffCmd = &cobra.Command{
+	Use:   "store-diff <snapshot_a_url> <snapshot_b_url>",
+	Short: "Compare two store snapshots, printing the added, removed and changed keys",
+	Long: cli.Dedent(`
+		Loads two complete store snapshots ('.kv' files) and prints the keys present only in the second one (added),
+		only in the first one (removed), or in both but with different values (changed), in ascending key order.
+
+		Snapshots are referenced by their full file URL, local paths or any dstore URL (gs://, s3://, az://), and
+		must be laid out like the ones written by the engine, under '<module_hash>/states/'. Files ending with '.zst'
+		are decompressed.
+
+		When '--manifest' and '--module' are provided, values are decoded according to the module's 'valueType',
+		'proto:' types being rendered as JSON using the package's protobuf definitions.
+	`),
+	Example: string(cli.ExamplePrefixed("substreams tools store-diff", `
+		./localdata/a1b2c3/states/0000020000-0000012000.kv.zst ./localdata/d4e5f6/states/0000020000-0000012000.kv.zst
+		gs://bucket/states-v1/a1b2c3/states/0000020000-0000012000.kv.zst gs://bucket/states-v2/d4e5f6/states/0000020000-0000012000.kv.zst --manifest uniswap-v3.spkg --module store_pools -o json
+	`)),
+	RunE:         runStoreDiffE,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+}
+
+func init() {
+	storeDiffCmd.Flags().String("manifest", "", "Manifest or package of the store module, used to decode values")
+	storeDiffCmd.Flags().String("module", "", "Name of the store module in '--manifest', used to decode values")
+	storeDiffCmd.Flags().StringP("output", "o", "text", "Output format, one of 'text' or 'json' (one JSON object per changed key)")
+
+	Cmd.AddCommand(storeDiffCmd)
+}
+
+func runStoreDiffE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	manifestPath := mustGetString(cmd, "manifest")
+	moduleName := mustGetString(cmd, "module")
+	output := mustGetString(cmd, "output")
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output format %q, expecting 'text' or 'json'", output)
+	}
+	if (manifestPath == "") != (moduleName == "") {
+		return fmt.Errorf("flags '--manifest' and '--module' must be provided together")
+	}
+
+	var module *pbsubstreams.Module
+	decoder := newStoreValueDecoder("", nil)
+	if manifestPath != "" {
+		manifestReader, err := manifest.NewReader(manifestPath)
+		if err != nil {
+			return fmt.Errorf("manifest reader: %w", err)
+		}
+
+		pkg, err := manifestReader.Read()
+		if err != nil {
+			return fmt.Errorf("read manifest %q: %w", manifestPath, err)
+		}
+
+		for _, mod := range pkg.Modules.Modules {
+			if mod.Name == moduleName {
+				module = mod
+			}
+		}
+		if module == nil {
+			return fmt.Errorf("module %q not found", moduleName)
+		}
+		if module.GetKindStore() == nil {
+			return fmt.Errorf("module %q is not a store", moduleName)
+		}
+
+		fileDescriptors, err := desc.CreateFileDescriptors(pkg.ProtoFiles)
+		if err != nil {
+			return fmt.Errorf("unable to find file descriptors: %w", err)
+		}
+
+		decoder = newStoreValueDecoder(module.GetKindStore().ValueType, fileDescriptors)
+		if decoder == nil {
+			return fmt.Errorf("protobuf definition %q of module %q not found in the package", module.GetKindStore().ValueType, moduleName)
+		}
+	}
+
+	loadSnapshot := func(fileURL string) (store.Store, error) {
+		baseURL, moduleHash, filename, err := splitSnapshotURL(fileURL)
+		if err != nil {
+			return nil, err
+		}
+
+		compression := ""
+		if strings.HasSuffix(filename, ".zst") {
+			compression = "zstd"
+		}
+		objStore, err := dstore.NewStore(baseURL, "", compression, false)
+		if err != nil {
+			return nil, fmt.Errorf("initializing dstore for %q: %w", baseURL, err)
+		}
+
+		name, initialBlock := moduleHash, uint64(0)
+		updatePolicy, valueType := pbsubstreams.Module_KindStore_UPDATE_POLICY_UNSET, ""
+		if module != nil {
+			name, initialBlock = module.Name, module.InitialBlock
+			updatePolicy, valueType = module.GetKindStore().UpdatePolicy, module.GetKindStore().ValueType
+		}
+
+		config, err := store.NewConfig(name, initialBlock, moduleHash, updatePolicy, valueType, objStore, "")
+		if err != nil {
+			return nil, fmt.Errorf("initializing store config: %w", err)
+		}
+
+		zlog.Debug("loading snapshot", zap.String("base_url", baseURL), zap.String("module_hash", moduleHash), zap.String("filename", filename))
+		s := config.NewFullKV(zlog)
+		if err := s.Load(ctx, &store.FileInfo{Filename: filename}); err != nil {
+			return nil, fmt.Errorf("loading snapshot %q: %w", fileURL, err)
+		}
+		return s, nil
+	}
+
+	before, err := loadSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+	after, err := loadSnapshot(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff, err := diffStores(before, after)
+	if err != nil {
+		return fmt.Errorf("comparing snapshots: %w", err)
+	}
+
+	if output == "json" {
+		return printStoreDiffJSON(cmd.OutOrStdout(), diff, decoder)
+	}
+	return printStoreDiffText(cmd.OutOrStdout(), diff, decoder)
+}
+
+// splitSnapshotURL breaks `<base>/<module_hash>/states/<filename>` into its parts, `base`
+// being the URL that `store.NewConfig` expects.
+func splitSnapshotURL(fileURL string) (baseURL, moduleHash, filename string, err error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("parse snapshot url %q: %w", fileURL, err)
+	}
+
+	statesDir := path.Dir(u.Path)
+	hashDir := path.Dir(statesDir)
+	if path.Base(statesDir) != "states" || hashDir == "." || hashDir == "/" {
+		return "", "", "", fmt.Errorf("snapshot %q is not under a '<module_hash>/states/' directory", fileURL)
+	}
+
+	filename = path.Base(u.Path)
+	moduleHash = path.Base(hashDir)
+	u.Path = strings.TrimSuffix(path.Dir(hashDir), "/")
+	return u.String(), moduleHash, filename, nil
+}
+
+type storeDiffChange string
+
+const (
+	storeDiffAdded   storeDiffChange = "added"
+	storeDiffRemoved storeDiffChange = "removed"
+	storeDiffChanged storeDiffChange = "changed"
+)
+
+type storeDiffEntry struct {
+	Key      string
+	Change   storeDiffChange
+	OldValue []byte
+	NewValue []byte
+}
+
+// diffStores lists the keys differing between `before` and `after`, in ascending key order.
+func diffStores(before, after store.Store) (out []*storeDiffEntry, err error) {
+	err = before.Iter(func(key string, value []byte) error {
+		newValue, found := after.GetLast(key)
+		if !found {
+			out = append(out, &storeDiffEntry{Key: key, Change: storeDiffRemoved, OldValue: value})
+		} else if !bytes.Equal(value, newValue) {
+			out = append(out, &storeDiffEntry{Key: key, Change: storeDiffChanged, OldValue: value, NewValue: newValue})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = after.Iter(func(key string, value []byte) error {
+		if _, found := before.GetLast(key); !found {
+			out = append(out, &storeDiffEntry{Key: key, Change: storeDiffAdded, NewValue: value})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// storeValueDecoder renders store values, `json` being true when the output is a JSON document.
+type storeValueDecoder struct {
+	decode func(value []byte) (string, error)
+	json   bool
+}
+
+// newStoreValueDecoder returns a decoder for values of `valueType`, nil if it's a
+// `proto:` type not found in `fileDescriptors`. Non-proto types are all stored as
+// strings, except for `bytes` which are rendered in hex.
+func newStoreValueDecoder(valueType string, fileDescriptors map[string]*desc.FileDescriptor) *storeValueDecoder {
+	if strings.HasPrefix(valueType, "proto:") {
+		for _, file := range fileDescriptors {
+			msgDesc := file.FindMessage(strings.TrimPrefix(valueType, "proto:"))
+			if msgDesc == nil {
+				continue
+			}
+			return &storeValueDecoder{
+				json: true,
+				decode: func(value []byte) (string, error) {
+					return unmarshalData(value, dynamic.NewMessageFactoryWithDefaults().NewDynamicMessage(msgDesc))
+				},
+			}
+		}
+		return nil
+	}
+
+	if valueType == "bytes" {
+		return &storeValueDecoder{decode: func(value []byte) (string, error) { return hex.EncodeToString(value), nil }}
+	}
+	return &storeValueDecoder{decode: func(value []byte) (string, error) { return string(value), nil }}
+}
+
+func printStoreDiffText(w io.Writer, diff []*storeDiffEntry, decoder *storeValueDecoder) error {
+	counts := map[storeDiffChange]int{}
+	for _, entry := range diff {
+		counts[entry.Change]++
+
+		var err error
+		var oldValue, newValue string
+		if entry.Change != storeDiffAdded {
+			if oldValue, err = decoder.decode(entry.OldValue); err != nil {
+				return fmt.Errorf("decoding old value of key %q: %w", entry.Key, err)
+			}
+		}
+		if entry.Change != storeDiffRemoved {
+			if newValue, err = decoder.decode(entry.NewValue); err != nil {
+				return fmt.Errorf("decoding new value of key %q: %w", entry.Key, err)
+			}
+		}
+
+		switch entry.Change {
+		case storeDiffAdded:
+			fmt.Fprintf(w, "+ %s: %s\n", entry.Key, newValue)
+		case storeDiffRemoved:
+			fmt.Fprintf(w, "- %s: %s\n", entry.Key, oldValue)
+		case storeDiffChanged:
+			fmt.Fprintf(w, "~ %s: %s => %s\n", entry.Key, oldValue, newValue)
+		}
+	}
+
+	fmt.Fprintf(w, "%d added, %d removed, %d changed\n", counts[storeDiffAdded], counts[storeDiffRemoved], counts[storeDiffChanged])
+	return nil
+}
+
+func printStoreDiffJSON(w io.Writer, diff []*storeDiffEntry, decoder *storeValueDecoder) error {
+	toJSON := func(value []byte, present bool) (json.RawMessage, error) {
+		if !present {
+			return nil, nil
+		}
+		decoded, err := decoder.decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if decoder.json {
+			return json.RawMessage(decoded), nil
+		}
+		return json.Marshal(decoded)
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, entry := range diff {
+		oldValue, err := toJSON(entry.OldValue, entry.Change != storeDiffAdded)
+		if err != nil {
+			return fmt.Errorf("decoding old value of key %q: %w", entry.Key, err)
+		}
+		newValue, err := toJSON(entry.NewValue, entry.Change != storeDiffRemoved)
+		if err != nil {
+			return fmt.Errorf("decoding new value of key %q: %w", entry.Key, err)
+		}
+
+		err = encoder.Encode(struct {
+			Key      string          `json:"key"`
+			Change   storeDiffChange `json:"change"`
+			OldValue json.RawMessage `json:"old_value,omitempty"`
+			NewValue json.RawMessage `json:"new_value,omitempty"`
+		}{entry.Key, entry.Change, oldValue, newValue})
+		if err != nil {
+			return fmt.Errorf("encoding diff entry: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+)
+
+func TestSplitSnapshotURL(t *testing.T) {
+	tests := []struct {
+		url                string
+		expectBaseURL      string
+		expectModuleHash   string
+		expectFilename     string
+		expectErrorContain string
+	}{
+		{
+			url:              "gs://bucket/prefix/abcdef/states/0000002000-0000001000.kv",
+			expectBaseURL:    "gs://bucket/prefix",
+			expectModuleHash: "abcdef",
+			expectFilename:   "0000002000-0000001000.kv",
+		},
+		{
+			url:              "/data/state/abcdef/states/0000002000-0000001000.kv",
+			expectBaseURL:    "/data/state",
+			expectModuleHash: "abcdef",
+			expectFilename:   "0000002000-0000001000.kv",
+		},
+		{
+			url:              "state/abcdef/states/0000002000-0000001000.partial",
+			expectBaseURL:    "state",
+			expectModuleHash: "abcdef",
+			expectFilename:   "0000002000-0000001000.partial",
+		},
+		{
+			url:                "gs://bucket/prefix/abcdef/0000002000-0000001000.kv",
+			expectErrorContain: "is not under a '<module_hash>/states/' directory",
+		},
+		{
+			url:                "states/0000002000-0000001000.kv",
+			expectErrorContain: "is not under a '<module_hash>/states/' directory",
+		},
+		{
+			url:                "/states/0000002000-0000001000.kv",
+			expectErrorContain: "is not under a '<module_hash>/states/' directory",
+		},
+		{
+			url:                "gs://bucket/%zz/states/file.kv",
+			expectErrorContain: "parse snapshot url",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			baseURL, moduleHash, filename, err := splitSnapshotURL(test.url)
+			if test.expectErrorContain != "" {
+				require.ErrorContains(t, err, test.expectErrorContain)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectBaseURL, baseURL)
+			assert.Equal(t, test.expectModuleHash, moduleHash)
+			assert.Equal(t, test.expectFilename, filename)
+		})
+	}
+}
+
+func TestDiffStores(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]string
+		after  map[string]string
+		expect []*storeDiffEntry
+	}{
+		{
+			name:   "identical",
+			before: map[string]string{"a": "1", "b": "2"},
+			after:  map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name:   "added",
+			before: map[string]string{"a": "1"},
+			after:  map[string]string{"a": "1", "b": "2"},
+			expect: []*storeDiffEntry{{Key: "b", Change: storeDiffAdded, NewValue: []byte("2")}},
+		},
+		{
+			name:   "removed",
+			before: map[string]string{"a": "1", "b": "2"},
+			after:  map[string]string{"b": "2"},
+			expect: []*storeDiffEntry{{Key: "a", Change: storeDiffRemoved, OldValue: []byte("1")}},
+		},
+		{
+			name:   "changed",
+			before: map[string]string{"a": "1"},
+			after:  map[string]string{"a": "10"},
+			expect: []*storeDiffEntry{{Key: "a", Change: storeDiffChanged, OldValue: []byte("1"), NewValue: []byte("10")}},
+		},
+		{
+			name:   "mixed in key order",
+			before: map[string]string{"b": "removed", "c": "old", "d": "same"},
+			after:  map[string]string{"a": "added", "c": "new", "d": "same", "e": "added"},
+			expect: []*storeDiffEntry{
+				{Key: "a", Change: storeDiffAdded, NewValue: []byte("added")},
+				{Key: "b", Change: storeDiffRemoved, OldValue: []byte("removed")},
+				{Key: "c", Change: storeDiffChanged, OldValue: []byte("old"), NewValue: []byte("new")},
+				{Key: "e", Change: storeDiffAdded, NewValue: []byte("added")},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff, err := diffStores(newTestStore(t, test.before), newTestStore(t, test.after))
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, diff)
+		})
+	}
+}
+
+func newTestStore(t *testing.T, entries map[string]string) store.Store {
+	t.Helper()
+
+	config, err := store.NewConfig("test", 0, "abcdef", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil), "")
+	require.NoError(t, err)
+
+	s := config.NewFullKV(zap.NewNop())
+	for key, value := range entries {
+		s.Set(0, key, value)
+	}
+	return s
+}
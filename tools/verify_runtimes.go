@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
+
+	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	"github.com/streamingfast/substreams/reqctx"
+	"github.com/streamingfast/substreams/service"
+)
+
+var verifyRuntimesCmd = &cobra.Command{
+	Use:   "verify-runtimes <manifest_url> <output_module> <start_block> <stop_block>",
+	Short: "Executes a module and its dependencies in two WASM runtimes over local merged blocks, reporting the first block and module they diverge on",
+	Long: cli.Dedent(`
+		Executes <output_module> from <start_block> up to <stop_block>, exclusively, and its dependencies
+		from their initial block, against the merged blocks files of '--blocks-store'. Each module call is
+		executed both in the runtime selected by the SUBSTREAMS_WASM_RUNTIME environment variable, wazero
+		by default, and in '--verification-runtime', comparing their outputs, store deltas and logs. The
+		command fails on the first block and module whose results differ.
+
+		Nothing is cached, the stores being rebuilt in a temporary directory so that every module call is
+		verified.
+	`),
+	Example: ExamplePrefixed("substreams tools verify-runtimes", `
+		./substreams.yaml map_events 12000000 12001000
+		uniswap-v3.spkg store_pools 12369621 12370000 --blocks-store gs://[bucket-url-path] --verification-runtime wasmtime
+	`),
+	Args: cobra.ExactArgs(4),
+	RunE: verifyRuntimesE,
+}
+
+func init() {
+	verifyRuntimesCmd.Flags().String("blocks-store", "./merged-blocks", "Store URL of the merged blocks files to read blocks from")
+	verifyRuntimesCmd.Flags().String("block-type", "", "Protobuf type of the blocks, defaults to the one consumed by the package's modules")
+	verifyRuntimesCmd.Flags().Uint64("job-size", 10000, "Number of blocks processed by each back-processing job")
+	verifyRuntimesCmd.Flags().String("verification-runtime", "wasmtime", "WASM runtime compared with the one selected by SUBSTREAMS_WASM_RUNTIME")
+	verifyRuntimesCmd.Flags().StringArrayP("params", "p", nil, "Set a params for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY, or for typed params -p module3.field=value -p module4=@params.yaml")
+
+	Cmd.AddCommand(verifyRuntimesCmd)
+}
+
+func verifyRuntimesE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	manifestPath := args[0]
+	outputModule := args[1]
+	verificationRuntime := mustGetString(cmd, "verification-runtime")
+
+	startBlock, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid start block %q: %w", args[2], err)
+	}
+	stopBlock, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid stop block %q: %w", args[3], err)
+	}
+	if stopBlock <= startBlock {
+		return fmt.Errorf("stop block %d must be above start block %d", stopBlock, startBlock)
+	}
+
+	manifestReader, err := manifest.NewReader(manifestPath)
+	if err != nil {
+		return fmt.Errorf("manifest reader: %w", err)
+	}
+
+	pkg, err := manifestReader.Read()
+	if err != nil {
+		return fmt.Errorf("read manifest %q: %w", manifestPath, err)
+	}
+
+	if err := manifest.ApplyParams(mustGetStringArray(cmd, "params"), pkg); err != nil {
+		return fmt.Errorf("apply params: %w", err)
+	}
+
+	blockType := mustGetString(cmd, "block-type")
+	if blockType == "" {
+		if blockType, err = manifest.PackageBlockType(pkg); err != nil {
+			return fmt.Errorf("%w, set --block-type", err)
+		}
+	}
+
+	mergedBlocksStore, err := dstore.NewDBinStore(mustGetString(cmd, "blocks-store"))
+	if err != nil {
+		return fmt.Errorf("setting up merged blocks store: %w", err)
+	}
+
+	stateDir, err := os.MkdirTemp("", "substreams-verify-runtimes-")
+	if err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	defer os.RemoveAll(stateDir)
+
+	stateStore, err := dstore.NewStore(stateDir, "zst", "zstd", true)
+	if err != nil {
+		return fmt.Errorf("setting up state store: %w", err)
+	}
+
+	// a single job at a time, for the first divergence to be the one of the lowest block
+	svc := service.NewLocal(
+		stateStore,
+		mergedBlocksStore,
+		blockType,
+		1,
+		mustGetUint64(cmd, "job-size"),
+		service.WithWASMRuntimeVerification(verificationRuntime),
+	)
+
+	req := &pbsubstreamsrpc.Request{
+		StartBlockNum: int64(startBlock),
+		StopBlockNum:  stopBlock,
+		Modules:       pkg.Modules,
+		OutputModule:  outputModule,
+	}
+
+	blockCount := 0
+	ctx = reqctx.WithLogger(ctx, zlog)
+	err = svc.Blocks(ctx, req, func(respAny substreams.ResponseFromAnyTier) error {
+		if _, ok := respAny.(*pbsubstreamsrpc.Response).Message.(*pbsubstreamsrpc.Response_BlockScopedData); ok {
+			blockCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("verifying runtimes: %w", err)
+	}
+
+	fmt.Printf("Runtime %q agreed with the selected runtime on %d blocks of module %q and its dependencies, from block %d to %d\n", verificationRuntime, blockCount, outputModule, startBlock, stopBlock)
+	return nil
+}
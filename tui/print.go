@@ -28,10 +28,8 @@ func (ui *TUI) decoratedBlockScopedData(
 		if _, ok := ui.msgTypes[out.Name]; !ok {
 			continue
 		}
-		if out.DebugInfo != nil {
-			for _, log := range out.DebugInfo.Logs {
-				s = append(s, fmt.Sprintf("%s: log: %s\n", out.Name, log))
-			}
+		for _, log := range out.DebugInfo.FilteredLogs(ui.logLevel) {
+			s = append(s, fmt.Sprintf("%s: log: %s\n", out.Name, log))
 		}
 
 		if len(out.MapOutput.Value) != 0 {
@@ -50,7 +48,7 @@ func (ui *TUI) decoratedBlockScopedData(
 		if _, ok := ui.msgTypes[out.Name]; !ok {
 			continue
 		}
-		for _, log := range out.DebugInfo.Logs {
+		for _, log := range out.DebugInfo.FilteredLogs(ui.logLevel) {
 			s = append(s, fmt.Sprintf("%s: log: %s\n", out.Name, log))
 		}
 
@@ -235,6 +233,12 @@ func (ui *TUI) formatPostDataProgress(msg *pbsubstreamsrpc.Response_Progress) {
 					fmt.Printf("%s: <logs truncated>\n", mod.Name)
 				}
 			}
+			if len(failure.StackTrace) != 0 {
+				fmt.Printf("%s: wasm stack trace:\n", mod.Name)
+				for _, frame := range failure.StackTrace {
+					fmt.Printf("%s:   %s\n", mod.Name, frame)
+				}
+			}
 		}
 	}
 	if displayedFailure {
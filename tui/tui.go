@@ -32,6 +32,7 @@ type TUI struct {
 	isTerminal        bool
 	outputMode        OutputMode
 	prettyPrintOutput bool
+	logLevel          pbsubstreamsrpc.LogMetadata_Level
 
 	prog          *tea.Program
 	seenFirstData bool
@@ -47,6 +48,7 @@ func New(req *pbsubstreamsrpc.Request, pkg *pbsubstreams.Package, outputStreamNa
 		req:               req,
 		pkg:               pkg,
 		outputStreamNames: outputStreamNames,
+		logLevel:          pbsubstreamsrpc.LogMetadata_DEBUG,
 		decodeMsgTypes:    map[string]func(in []byte) string{},
 		msgTypes:          map[string]string{},
 		msgDescs:          map[string]*desc.MessageDescriptor{},
@@ -55,6 +57,11 @@ func New(req *pbsubstreamsrpc.Request, pkg *pbsubstreams.Package, outputStreamNa
 	return ui
 }
 
+// SetLogLevel sets the minimum level of the module logs printed.
+func (ui *TUI) SetLogLevel(level pbsubstreamsrpc.LogMetadata_Level) {
+	ui.logLevel = level
+}
+
 func (ui *TUI) Init(outputMode string) error {
 	if err := ui.configureOutputMode(outputMode); err != nil {
 		return err
@@ -78,6 +85,8 @@ func (ui *TUI) Init(outputMode string) error {
 					msgType = modKind.KindStore.ValueType
 				case *pbsubstreams.Module_KindMap_:
 					msgType = modKind.KindMap.OutputType
+				case *pbsubstreams.Module_KindBlockIndex_:
+					msgType = modKind.KindBlockIndex.OutputType
 				}
 				msgType = strings.TrimPrefix(msgType, "proto:")
 
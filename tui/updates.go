@@ -84,7 +84,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case *pbsubstreamsrpc.ModuleProgress_Failed_:
 			m.Failures += 1
 			if progMsg.Failed.Reason != "" {
-				m.Reason = fmt.Sprintf("Reason: %s, logs: %s, truncated: %v", progMsg.Failed.Reason, progMsg.Failed.Logs, progMsg.Failed.LogsTruncated)
+				m.Reason = fmt.Sprintf("Reason: %s, logs: %s, truncated: %v, stack trace: %s", progMsg.Failed.Reason, progMsg.Failed.Logs, progMsg.Failed.LogsTruncated, progMsg.Failed.StackTrace)
 			}
 			m.LastFailure = progMsg.Failed
 			m.ui.Cancel()
@@ -51,6 +51,7 @@ type Output struct {
 	//moduleSearchView
 	outputModule string
 	logsEnabled  bool
+	logLevel     pbsubstreamsrpc.LogMetadata_Level
 
 	searchEnabled                   bool
 	searchCtx                       *search.Search
@@ -89,6 +90,7 @@ func New(c common.Common, manifestPath string, outputModule string, config *requ
 		moduleSearchView:    modsearch.New(c),
 		outputModule:        outputModule,
 		logsEnabled:         true,
+		logLevel:            config.LogLevel,
 		moduleNavigator:     nav,
 		firstBlockSeen:      true,
 	}
@@ -225,6 +227,9 @@ func (o *Output) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "L":
 			o.logsEnabled = !o.logsEnabled
 			o.setOutputViewContent(true)
+		case "V":
+			o.logLevel = o.logLevel%pbsubstreamsrpc.LogMetadata_ERROR + 1
+			o.setOutputViewContent(true)
 		case "m":
 			o.moduleSearchEnabled = true
 			o.setOutputViewContent(true)
@@ -76,7 +76,8 @@ func (o *Output) renderedOutput(in *pbsubstreamsrpc.AnyModuleOutput, withStyle b
 	if o.logsEnabled {
 		if debugInfo := in.DebugInfo(); debugInfo != nil {
 			var plainLogs []string
-			for _, log := range debugInfo.Logs {
+			logs := debugInfo.FilteredLogs(o.logLevel)
+			for _, log := range logs {
 				plainLogs = append(plainLogs, fmt.Sprintf("log: %s", log))
 				if withStyle {
 					out.styledLogs.WriteString(Styles.LogLabel.Render("log: "))
@@ -84,7 +85,7 @@ func (o *Output) renderedOutput(in *pbsubstreamsrpc.AnyModuleOutput, withStyle b
 					out.styledLogs.WriteString("\n")
 				}
 			}
-			if withStyle && len(debugInfo.Logs) != 0 {
+			if withStyle && len(logs) != 0 {
 				out.styledLogs.WriteString("\n")
 			}
 			out.plainLogs = strings.Join(plainLogs, "\n")
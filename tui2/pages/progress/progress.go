@@ -38,6 +38,8 @@ type Progress struct {
 
 	bars   *ranges.Bars
 	curErr string
+	// failure is the failure of a module sent before the error, with its WASM stack trace
+	failure string
 }
 
 func New(c common.Common) *Progress {
@@ -78,6 +80,7 @@ func (p *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		p.maxParallelWorkers = sessionInit.MaxParallelWorkers
 		p.bars = ranges.NewBars(p.Common, linearHandoff)
 		p.bars.Init()
+		p.failure = ""
 	case *pbsubstreamsrpc.BlockScopedData:
 		p.dataPayloads += 1
 	case *pbsubstreamsrpc.ModulesProgress:
@@ -93,6 +96,11 @@ func (p *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			p.blocksThisSecond = p.bars.TotalBlocks
 		}
 		p.updatesThisSecond += 1
+		for _, mod := range msg.(*pbsubstreamsrpc.ModulesProgress).Modules {
+			if failed := mod.GetFailed(); failed != nil {
+				p.failure = formatFailure(mod.Name, failed)
+			}
+		}
 		p.bars.Update(msg)
 		p.progressView.SetContent(p.bars.View())
 	case stream.StreamErrorMsg:
@@ -159,6 +167,17 @@ func wrapString(input string, screenWidth int) (string, int) {
 	return wrappedString.String(), lineCount
 }
 
+func formatFailure(moduleName string, failed *pbsubstreamsrpc.ModuleProgress_Failed) string {
+	lines := []string{fmt.Sprintf("%s: failed: %s", moduleName, failed.Reason)}
+	if len(failed.StackTrace) != 0 {
+		lines = append(lines, "wasm stack trace:")
+		for _, frame := range failed.StackTrace {
+			lines = append(lines, "  "+frame)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (p *Progress) View() string {
 	blocksPerSecondPerModule := ""
 	maxWorkers := ""
@@ -177,6 +196,10 @@ func (p *Progress) View() string {
 
 	if p.state == "Error" {
 		errorStringWrapped, lineCount := wrapString(p.curErr, p.Width)
+		failure := lipgloss.NewStyle().Width(p.Width).Render(p.failure)
+		if p.failure != "" {
+			lineCount += lipgloss.Height(failure)
+		}
 
 		return lipgloss.JoinVertical(0,
 			lipgloss.NewStyle().Margin(0, 2).Render(lipgloss.JoinHorizontal(0,
@@ -184,6 +207,7 @@ func (p *Progress) View() string {
 				lipgloss.JoinVertical(0, infos...),
 			)),
 			lipgloss.NewStyle().Background(lipgloss.Color("9")).Width(p.Width).Render(errorStringWrapped),
+			failure,
 			lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true).Width(p.Width-5).Height(p.progressView.Height-lineCount).Render(p.progressView.View()),
 		)
 	}
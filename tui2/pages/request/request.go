@@ -42,6 +42,7 @@ type RequestConfig struct {
 	HomeDir                     string
 	Vcr                         bool
 	Cursor                      string
+	LogLevel                    pbsubstreamsrpc.LogMetadata_Level
 }
 
 type RequestInstance struct {
@@ -261,6 +262,12 @@ func (c *RequestConfig) NewInstance() (*RequestInstance, error) {
 		ProductionMode:                      c.ProdMode,
 		DebugInitialStoreSnapshotForModules: c.DebugModulesInitialSnapshot,
 	}
+	if len(pkg.Signatures) != 0 {
+		req.PackageSignatures = pkg.Signatures
+		if req.PackageMetadataDigest, err = manifest.PackageMetadataDigest(pkg); err != nil {
+			return nil, err
+		}
+	}
 
 	stream := streamui.New(req, ssClient, callOpts)
 
@@ -1,6 +1,7 @@
 package wasm
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 
@@ -9,6 +10,7 @@ import (
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/storage/store/marshaller"
 )
 
 type Call struct {
@@ -25,16 +27,20 @@ type Call struct {
 	returnValue []byte
 	panicError  *PanicError
 
-	Logs           []string
+	Logs           []Log
 	LogsByteCount  uint64
 	ExecutionStack []string
+
+	maxFuel         uint64
+	maxLogByteCount uint64
 }
 
 func NewCall(clock *pbsubstreams.Clock, moduleName string, entrypoint string, arguments []Argument) *Call {
 	call := &Call{
-		Clock:      clock,
-		ModuleName: moduleName,
-		Entrypoint: entrypoint,
+		Clock:           clock,
+		ModuleName:      moduleName,
+		Entrypoint:      entrypoint,
+		maxLogByteCount: MaxLogByteCount,
 	}
 
 	for _, input := range arguments {
@@ -90,14 +96,42 @@ func (c *Call) SetPanicError(message string, filename string, lineNo int, colNo
 	c.panicError = NewPanicError(message, filename, lineNo, colNo)
 }
 
+// SetLimits sets the fuel the call can consume, 0 meaning no limit, and the
+// bytes of logs it keeps, 0 meaning MaxLogByteCount.
+func (c *Call) SetLimits(maxFuel uint64, maxLogByteCount uint64) {
+	c.maxFuel = maxFuel
+	c.maxLogByteCount = MaxLogByteCount
+	if maxLogByteCount != 0 {
+		c.maxLogByteCount = maxLogByteCount
+	}
+}
+
+// MaxFuel returns the fuel the call can consume, enforced by the runtimes
+// metering fuel, 0 meaning no limit.
+func (c *Call) MaxFuel() uint64 {
+	return c.maxFuel
+}
+
+func (c *Call) MaxLogByteCount() uint64 {
+	return c.maxLogByteCount
+}
+
+// AppendLog appends a log of level info without fields, as logged by `println`.
 func (c *Call) AppendLog(message string) {
+	c.AppendLeveledLog(LogLevelInfo, message, nil)
+}
+
+func (c *Call) AppendLeveledLog(level LogLevel, message string, fields []LogField) {
+	log := Log{Level: level, Message: message, Fields: fields}
+
 	// len(<string>) in Go count number of bytes and not characters, so we are good here
-	if len(message) > MaxLogByteCount {
-		panic(fmt.Errorf("message to log is too big, size is %s, max is %s", humanize.IBytes(uint64(len(message))), humanize.IBytes(uint64(MaxLogByteCount))))
+	byteCount := log.byteCount()
+	if byteCount > c.maxLogByteCount {
+		panic(fmt.Errorf("message to log is too big, size is %s, max is %s", humanize.IBytes(byteCount), humanize.IBytes(c.maxLogByteCount)))
 	}
-	c.LogsByteCount += uint64(len(message))
+	c.LogsByteCount += byteCount
 	if !c.ReachedLogsMaxByteCount() {
-		c.Logs = append(c.Logs, message)
+		c.Logs = append(c.Logs, log)
 		c.ExecutionStack = append(c.ExecutionStack, fmt.Sprintf("log: %s", message))
 	}
 }
@@ -109,7 +143,7 @@ func (c *Call) SetOutputStore(store store.Store) {
 const MaxLogByteCount = 128 * 1024 // 128 KiB
 
 func (c *Call) ReachedLogsMaxByteCount() bool {
-	return c.LogsByteCount >= MaxLogByteCount
+	return c.LogsByteCount >= c.maxLogByteCount
 }
 
 func (c *Call) DoSet(ord uint64, key string, value []byte) {
@@ -130,6 +164,14 @@ func (c *Call) DoDeletePrefix(ord uint64, prefix string) {
 	c.traceStateWrites("delete_prefix", prefix)
 	c.outputStore.DeletePrefix(ord, prefix)
 }
+func (c *Call) DoDeleteRange(ord uint64, lowKey, highKey string) {
+	c.traceStateWrites("delete_range", lowKey)
+	c.outputStore.DeleteRange(ord, lowKey, highKey)
+}
+func (c *Call) DoDeleteRangePointers(ord uint64, lowKey, highKey, pointerSeparator string) {
+	c.traceStateWrites("delete_range_pointers", lowKey)
+	c.outputStore.DeleteRangePointers(ord, lowKey, highKey, pointerSeparator)
+}
 func (c *Call) DoAddBigInt(ord uint64, key string, value string) {
 	c.validateWithValueType("add_bigint", pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "bigint", key)
 
@@ -239,6 +281,70 @@ func (c *Call) DoHasLast(storeIndex int, key string) (found bool) {
 	return readStore.HasLast(key)
 }
 
+// Results of the `scan_*` state functions.
+const (
+	ScanEmpty    int32 = 0 // no entries in the range, nothing written to the output pointer
+	ScanComplete int32 = 1 // the entries are written to the output pointer, none left in the range
+	ScanPartial  int32 = 2 // the entries are written to the output pointer, more are left: scan again starting right after the last key (the last key followed by a zero byte)
+)
+
+func (c *Call) DoScanFirst(storeIndex int, keyRange store.KeyRange, limit int) (page []byte, result int32) {
+	c.validateStoreIndex(storeIndex, "scan_first")
+	readStore := c.inputStores[storeIndex]
+	entries, more := readStore.ScanFirst(keyRange, limit)
+	c.traceStateReads("scan_first", storeIndex, len(entries) != 0, keyRange.Low)
+	return encodeScanPage(entries, more)
+}
+
+func (c *Call) DoScanLast(storeIndex int, keyRange store.KeyRange, limit int) (page []byte, result int32) {
+	c.validateStoreIndex(storeIndex, "scan_last")
+	readStore := c.inputStores[storeIndex]
+	entries, more := readStore.ScanLast(keyRange, limit)
+	c.traceStateReads("scan_last", storeIndex, len(entries) != 0, keyRange.Low)
+	return encodeScanPage(entries, more)
+}
+
+func (c *Call) DoScanAt(storeIndex int, ord uint64, keyRange store.KeyRange, limit int) (page []byte, result int32) {
+	c.validateStoreIndex(storeIndex, "scan_at")
+	readStore := c.inputStores[storeIndex]
+	entries, more := readStore.ScanAt(ord, keyRange, limit)
+	c.traceStateReads("scan_at", storeIndex, len(entries) != 0, keyRange.Low)
+	return encodeScanPage(entries, more)
+}
+
+// PrefixScanRange is the range scanned by the `scan_prefix_*` state functions:
+// the keys starting with `prefix`, from `startKey` if it sorts after `prefix`.
+func PrefixScanRange(prefix, startKey string) store.KeyRange {
+	keyRange := store.PrefixRange(prefix)
+	if startKey > keyRange.Low {
+		keyRange.Low = startKey
+	}
+	return keyRange
+}
+
+// encodeScanPage writes the entries in order, each one a length-delimited
+// field 1 holding the key as field 1 and the value as field 2: the page
+// decodes as the protobuf message `message Page { repeated KV entries = 1; }`
+// where `message KV { string key = 1; bytes value = 2; }`.
+func encodeScanPage(entries []*store.KeyValue, more bool) ([]byte, int32) {
+	if len(entries) == 0 {
+		return nil, ScanEmpty
+	}
+
+	buf := bytes.NewBuffer(nil)
+	w := marshaller.NewStoreDataWriter(buf)
+	for _, entry := range entries {
+		// Writing to a bytes.Buffer never fails
+		_ = w.WriteKV(entry.Key, entry.Value)
+	}
+	_ = w.Flush()
+
+	if more {
+		return buf.Bytes(), ScanPartial
+	}
+	return buf.Bytes(), ScanComplete
+}
+
 func (c *Call) validateStoreIndex(storeIndex int, stateFunc string) {
 	if storeIndex+1 > len(c.inputStores) {
 		c.ReturnError(fmt.Errorf("%q failed: invalid store index %d, %d stores declared", stateFunc, storeIndex, len(c.inputStores)))
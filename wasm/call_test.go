@@ -1,14 +1,18 @@
 package wasm
 
 import (
+	"bufio"
+	"bytes"
 	"testing"
 
 	"github.com/streamingfast/dstore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/storage/store/marshaller"
 )
 
 func Test_CallStoreOps(t *testing.T) {
@@ -479,6 +483,80 @@ func Test_CallStoreOps(t *testing.T) {
 	}
 }
 
+func Test_CallScan(t *testing.T) {
+	inputStore := newTestCall(pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string").outputStore
+	inputStore.SetBytes(0, "pos:a:1", []byte("1"))
+	inputStore.SetBytes(1, "pos:a:2", []byte("2"))
+	inputStore.SetBytes(2, "pos:a:3", []byte("3"))
+	inputStore.SetBytes(3, "pos:b:1", []byte("4"))
+	call := &Call{inputStores: []store.Reader{inputStore}}
+
+	decode := func(page []byte) (out []string) {
+		err := marshaller.ReadStoreData(bufio.NewReader(bytes.NewReader(page)), func(key string, value []byte) {
+			out = append(out, key+"="+string(value))
+		}, nil, nil)
+		require.NoError(t, err)
+		return out
+	}
+
+	page, result := call.DoScanLast(0, PrefixScanRange("pos:a:", ""), 2)
+	assert.Equal(t, ScanPartial, result)
+	assert.Equal(t, []string{"pos:a:1=1", "pos:a:2=2"}, decode(page))
+
+	page, result = call.DoScanLast(0, PrefixScanRange("pos:a:", "pos:a:2\x00"), 2)
+	assert.Equal(t, ScanComplete, result)
+	assert.Equal(t, []string{"pos:a:3=3"}, decode(page))
+
+	page, result = call.DoScanAt(0, 0, store.KeyRange{Low: "pos:", High: "pos;"}, 0)
+	assert.Equal(t, ScanComplete, result)
+	assert.Equal(t, []string{"pos:a:1=1"}, decode(page))
+
+	page, result = call.DoScanFirst(0, store.PrefixRange("pos:"), 10)
+	assert.Equal(t, ScanEmpty, result)
+	assert.Nil(t, page)
+
+	assert.Panics(t, func() { call.DoScanLast(1, store.PrefixRange("pos:"), 10) })
+}
+
+func Test_CallLimits(t *testing.T) {
+	call := NewCall(&pbsubstreams.Clock{Number: 1}, "test", "test", nil)
+	assert.Equal(t, uint64(MaxLogByteCount), call.MaxLogByteCount())
+
+	call.SetLimits(1000, 9)
+	assert.Equal(t, uint64(1000), call.MaxFuel())
+
+	call.AppendLog("1234")
+	call.AppendLog("5678")
+	call.AppendLog("90")
+	assert.Equal(t, []Log{{LogLevelInfo, "1234", nil}, {LogLevelInfo, "5678", nil}}, call.Logs)
+	assert.True(t, call.ReachedLogsMaxByteCount())
+	assert.PanicsWithError(t, "message to log is too big, size is 10 B, max is 9 B", func() { call.AppendLog("1234567890") })
+
+	call.SetLimits(0, 0)
+	assert.Equal(t, uint64(MaxLogByteCount), call.MaxLogByteCount())
+}
+
+func Test_CallLeveledLogs(t *testing.T) {
+	encoded := []byte{3, 0, 0, 0, 'k', 'e', 'y', 5, 0, 0, 0, 'v', 'a', 'l', 'u', 'e', 1, 0, 0, 0, 'n', 0, 0, 0, 0}
+	fields, err := DecodeLogFields(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, []LogField{{"key", "value"}, {"n", ""}}, fields)
+
+	_, err = DecodeLogFields(encoded[:len(encoded)-2])
+	assert.EqualError(t, err, "invalid log fields, expecting a length")
+	_, err = DecodeLogFields(encoded[:6])
+	assert.EqualError(t, err, "invalid log fields, expecting 3 bytes but got 2")
+
+	call := NewCall(&pbsubstreams.Clock{Number: 1}, "test", "test", nil)
+	call.SetLimits(0, 20)
+	call.AppendLeveledLog(LogLevelWarn, "message", fields)
+	assert.Equal(t, uint64(16), call.LogsByteCount)
+	assert.Equal(t, []Log{{LogLevelWarn, "message", fields}}, call.Logs)
+	assert.PanicsWithError(t, "message to log is too big, size is 21 B, max is 20 B", func() {
+		call.AppendLeveledLog(LogLevelError, "1234567890", []LogField{{"123", "45678901"}})
+	})
+}
+
 func newTestCall(updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy, valueType string) *Call {
 	myStore := dstore.NewMockStore(nil)
 	storeConf, err := store.NewConfig("test", 0, "", updatePolicy, valueType, myStore, "test")
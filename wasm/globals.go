@@ -0,0 +1,244 @@
+package wasm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// GlobalExportPrefix prefixes the names under which ExportMutableGlobals exports
+// the mutable globals of a WASM code.
+const GlobalExportPrefix = "__substreams_global_"
+
+const (
+	sectionCustom    = 0
+	sectionImport    = 2
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionCode      = 10
+	exportKindGlobal = 3
+)
+
+// ExportMutableGlobals returns `code` with its mutable globals exported, the
+// runtimes only giving access to exported globals, which instance pooling needs
+// to restore them all, compilers like Rust's not exporting their stack pointer.
+// It returns the names of the added exports.
+func ExportMutableGlobals(code []byte) (out []byte, names []string, err error) {
+	if len(code) < 8 || !bytes.Equal(code[:4], []byte("\x00asm")) {
+		return nil, nil, fmt.Errorf("invalid wasm code")
+	}
+
+	type section struct {
+		id      byte
+		content []byte
+	}
+	var sections []section
+	r := &wasmReader{data: code, offset: 8}
+	for !r.done() {
+		id := r.byte()
+		size := r.u32()
+		content := r.bytes(int(size))
+		if r.err != nil {
+			return nil, nil, fmt.Errorf("reading section %d: %w", id, r.err)
+		}
+		sections = append(sections, section{id, content})
+	}
+
+	var importedGlobals uint32
+	var mutableGlobals []uint32
+	for _, s := range sections {
+		switch s.id {
+		case sectionImport:
+			importedGlobals, err = countImportedGlobals(s.content)
+		case sectionGlobal:
+			mutableGlobals, err = listMutableGlobals(s.content)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(mutableGlobals) == 0 {
+		return code, nil, nil
+	}
+
+	exports := &bytes.Buffer{}
+	for _, index := range mutableGlobals {
+		name := fmt.Sprintf("%s%d", GlobalExportPrefix, index)
+		names = append(names, name)
+		exports.Write(binary.AppendUvarint(nil, uint64(len(name))))
+		exports.WriteString(name)
+		exports.WriteByte(exportKindGlobal)
+		exports.Write(binary.AppendUvarint(nil, uint64(importedGlobals+index)))
+	}
+
+	buf := bytes.NewBuffer(append([]byte{}, code[:8]...))
+	writeSection := func(id byte, content []byte) {
+		buf.WriteByte(id)
+		buf.Write(binary.AppendUvarint(nil, uint64(len(content))))
+		buf.Write(content)
+	}
+	exported := false
+	for _, s := range sections {
+		if !exported && s.id == sectionExport {
+			r := &wasmReader{data: s.content}
+			count := r.u32()
+			if r.err != nil {
+				return nil, nil, fmt.Errorf("reading export section: %w", r.err)
+			}
+			content := binary.AppendUvarint(nil, uint64(count)+uint64(len(names)))
+			content = append(content, s.content[r.offset:]...)
+			writeSection(sectionExport, append(content, exports.Bytes()...))
+			exported = true
+			continue
+		}
+		if !exported && s.id != sectionCustom && sectionOrder(s.id) > sectionOrder(sectionExport) {
+			writeSection(sectionExport, append(binary.AppendUvarint(nil, uint64(len(names))), exports.Bytes()...))
+			exported = true
+		}
+		writeSection(s.id, s.content)
+	}
+	if !exported {
+		writeSection(sectionExport, append(binary.AppendUvarint(nil, uint64(len(names))), exports.Bytes()...))
+	}
+	return buf.Bytes(), names, nil
+}
+
+// sectionOrder returns the position of the section `id` in a module, the data
+// count section (12) coming before the code section (10), and the tag section
+// (13) before the global section.
+func sectionOrder(id byte) float64 {
+	switch id {
+	case 12:
+		return 9.5
+	case 13:
+		return 5.5
+	}
+	return float64(id)
+}
+
+func countImportedGlobals(content []byte) (uint32, error) {
+	r := &wasmReader{data: content}
+	var globals uint32
+	for count := r.u32(); count > 0 && r.err == nil; count-- {
+		r.bytes(int(r.u32())) // module
+		r.bytes(int(r.u32())) // name
+		switch kind := r.byte(); kind {
+		case 0: // function
+			r.u32()
+		case 1: // table
+			r.byte()
+			r.limits()
+		case 2: // memory
+			r.limits()
+		case 3: // global
+			r.bytes(2)
+			globals++
+		case 4: // tag
+			r.byte()
+			r.u32()
+		default:
+			return 0, fmt.Errorf("unknown import kind %d", kind)
+		}
+	}
+	if r.err != nil {
+		return 0, fmt.Errorf("reading import section: %w", r.err)
+	}
+	return globals, nil
+}
+
+func listMutableGlobals(content []byte) (out []uint32, err error) {
+	r := &wasmReader{data: content}
+	count := r.u32()
+	for index := uint32(0); index < count && r.err == nil; index++ {
+		r.byte() // type
+		if r.byte() == 1 {
+			out = append(out, index)
+		}
+		if err := r.skipConstExpr(); err != nil {
+			return nil, fmt.Errorf("reading global %d: %w", index, err)
+		}
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("reading global section: %w", r.err)
+	}
+	return out, nil
+}
+
+type wasmReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *wasmReader) done() bool {
+	return r.err != nil || r.offset >= len(r.data)
+}
+
+func (r *wasmReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.offset+n > len(r.data) {
+		r.err = fmt.Errorf("unexpected end of data at offset %d", r.offset)
+		return nil
+	}
+	out := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return out
+}
+
+func (r *wasmReader) byte() byte {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+// u32 reads an unsigned LEB128 integer, skipping a signed one as well.
+func (r *wasmReader) u32() uint32 {
+	if r.err != nil {
+		return 0
+	}
+	value, n := binary.Uvarint(r.data[r.offset:])
+	if n <= 0 {
+		r.err = fmt.Errorf("invalid integer at offset %d", r.offset)
+		return 0
+	}
+	r.offset += n
+	return uint32(value)
+}
+
+func (r *wasmReader) limits() {
+	flags := r.byte()
+	r.u32()
+	if flags&1 != 0 {
+		r.u32()
+	}
+}
+
+// skipConstExpr skips the constant expression initializing a global, up to its `end`.
+func (r *wasmReader) skipConstExpr() error {
+	for r.err == nil {
+		switch opcode := r.byte(); opcode {
+		case 0x0b: // end
+			return nil
+		case 0x41, 0x42, 0x23, 0xd2: // i32.const, i64.const, global.get, ref.func
+			r.u32()
+		case 0x43: // f32.const
+			r.bytes(4)
+		case 0x44: // f64.const
+			r.bytes(8)
+		case 0xd0: // ref.null
+			r.byte()
+		case 0x6a, 0x6b, 0x6c, 0x7c, 0x7d, 0x7e: // extended constant arithmetic
+		case 0xfd: // v128.const
+			if r.u32() != 12 {
+				return fmt.Errorf("unsupported vector instruction in constant expression")
+			}
+			r.bytes(16)
+		default:
+			return fmt.Errorf("unsupported opcode 0x%x in constant expression", opcode)
+		}
+	}
+	return r.err
+}
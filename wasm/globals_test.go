@@ -0,0 +1,60 @@
+package wasm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+)
+
+func TestExportMutableGlobals(t *testing.T) {
+	header := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	types := []byte{0x01, 0x04, 0x01, 0x60, 0x00, 0x00}
+	functions := []byte{0x03, 0x02, 0x01, 0x00}
+	// an immutable global of 1, then a mutable one of 11, encoded like `end`
+	globals := []byte{0x06, 0x0b, 0x02, 0x7f, 0x00, 0x41, 0x01, 0x0b, 0x7f, 0x01, 0x41, 0x0b, 0x0b}
+	exports := []byte{0x07, 0x07, 0x01, 0x03, 'r', 'u', 'n', 0x00, 0x00}
+	code := []byte{0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b}
+
+	concat := func(sections ...[]byte) (out []byte) {
+		for _, section := range sections {
+			out = append(out, section...)
+		}
+		return
+	}
+
+	tests := []struct {
+		name           string
+		code           []byte
+		expectFunction bool
+	}{
+		{"with exports", concat(header, types, functions, globals, exports, code), true},
+		{"without exports", concat(header, types, functions, globals, code), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			out, names, err := ExportMutableGlobals(test.code)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"__substreams_global_1"}, names)
+
+			runtime := wazero.NewRuntime(ctx)
+			defer runtime.Close(ctx)
+			mod, err := runtime.Instantiate(ctx, out)
+			require.NoError(t, err)
+
+			assert.Equal(t, uint64(11), mod.ExportedGlobal("__substreams_global_1").Get())
+			assert.Equal(t, test.expectFunction, mod.ExportedFunction("run") != nil)
+		})
+	}
+
+	out, names, err := ExportMutableGlobals(concat(header, types, functions, code))
+	require.NoError(t, err)
+	assert.Empty(t, names)
+	assert.Equal(t, concat(header, types, functions, code), out)
+
+	_, _, err = ExportMutableGlobals(concat(header, types, functions, globals[:8]))
+	assert.Error(t, err)
+}
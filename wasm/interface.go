@@ -2,6 +2,7 @@ package wasm
 
 import (
 	"context"
+	"fmt"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
@@ -60,8 +61,48 @@ type Instance interface {
 	Close(ctx context.Context) error
 }
 
+// A RestorableInstance is an Instance able to restore the memory and globals
+// it had right after instantiation, so that it can be reused by the next call
+// with the same result as a new instance, see Registry.InstancePoolingEnabled.
+type RestorableInstance interface {
+	Instance
+
+	// Restore is called after each call. It returns false when the instance
+	// can't be restored, its memory having grown for example, in which case it
+	// is closed and the next call gets a new instance.
+	Restore(ctx context.Context) (restored bool, err error)
+}
+
 var runtimes = map[string]ModuleFactory{}
 
 func RegisterModuleFactory(name string, factory ModuleFactory) {
 	runtimes[name] = factory
 }
+
+var fuelMeteringRuntimes = map[string]bool{}
+
+// RegisterFuelMeteringRuntime declares that the modules of the runtime `name`
+// enforce the fuel limit of their calls, see `Call.MaxFuel`.
+func RegisterFuelMeteringRuntime(name string) {
+	fuelMeteringRuntimes[name] = true
+}
+
+// WASMBinaryType is the type of the binaries executed by the WASM runtime selected
+// in the Registry, any other type needs a factory registered through RegisterBinaryType.
+const WASMBinaryType = "wasm/rust-v1"
+
+var binaryTypes = map[string]ModuleFactory{}
+
+// RegisterBinaryType registers the factory creating the Modules of binaries of
+// type `binaryType`, instead of running them on the WASM runtime.
+func RegisterBinaryType(binaryType string, factory ModuleFactory) {
+	if binaryType == WASMBinaryType {
+		panic(fmt.Sprintf("binary type %q is reserved to the wasm runtimes", binaryType))
+	}
+	binaryTypes[binaryType] = factory
+}
+
+// IsBinaryTypeSupported returns whether modules of binaries of type `binaryType` can be created.
+func IsBinaryTypeSupported(binaryType string) bool {
+	return binaryType == WASMBinaryType || binaryTypes[binaryType] != nil
+}
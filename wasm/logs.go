@@ -0,0 +1,84 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// LogLevel is the level of a log of a module, its values being the ones of the
+// `LogMetadata.Level` protobuf enums.
+type LogLevel int32
+
+const (
+	LogLevelDebug LogLevel = iota + 1
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+var logLevelNames = map[LogLevel]string{
+	LogLevelDebug: "debug",
+	LogLevelInfo:  "info",
+	LogLevelWarn:  "warn",
+	LogLevelError: "error",
+}
+
+func (l LogLevel) String() string {
+	if name, found := logLevelNames[l]; found {
+		return name
+	}
+	return fmt.Sprintf("LogLevel(%d)", int32(l))
+}
+
+// A Log is logged by a module through the `logger` host functions, `println`
+// logging messages of level info without fields.
+type Log struct {
+	Level   LogLevel
+	Message string
+	Fields  []LogField
+}
+
+type LogField struct {
+	Key   string
+	Value string
+}
+
+// byteCount returns the bytes of the log counted against the log limit of the call.
+func (l Log) byteCount() uint64 {
+	count := len(l.Message)
+	for _, field := range l.Fields {
+		count += len(field.Key) + len(field.Value)
+	}
+	return uint64(count)
+}
+
+// DecodeLogFields decodes the fields passed to the leveled `logger` host functions,
+// a sequence of keys and values each prefixed by its length as a little-endian uint32.
+func DecodeLogFields(data []byte) (out []LogField, err error) {
+	readString := func() (string, error) {
+		if len(data) < 4 {
+			return "", fmt.Errorf("invalid log fields, expecting a length")
+		}
+		length := binary.LittleEndian.Uint32(data)
+		data = data[4:]
+		if uint64(len(data)) < uint64(length) {
+			return "", fmt.Errorf("invalid log fields, expecting %d bytes but got %d", length, len(data))
+		}
+		value := string(data[:length])
+		data = data[length:]
+		return value, nil
+	}
+
+	for len(data) != 0 {
+		key, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		value, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, LogField{Key: key, Value: value})
+	}
+	return out, nil
+}
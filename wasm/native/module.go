@@ -0,0 +1,117 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/streamingfast/substreams/wasm"
+)
+
+// BinaryType is the type of the manifest binaries whose modules are Go functions
+// compiled in the server, the 'native' field of the binary naming the module
+// and version registered through Register, as `<name>@<version>`.
+const BinaryType = "native/go"
+
+// Func implements a module entrypoint in Go. It receives the same arguments as
+// a WASM module, the values of the inputs being available through
+// `wasm.ValueArgument`, and reads and writes stores through `call` (`DoGetAt`,
+// `DoSet`...), using the index of the `*wasm.StoreReaderInput` among the store
+// arguments. The output of map modules is set with `call.SetReturnValue`.
+//
+// A returned error fails the module deterministically, like a panic in a WASM
+// module would.
+type Func func(ctx context.Context, call *wasm.Call, arguments []wasm.Argument) error
+
+var (
+	modulesLock sync.RWMutex
+	modules     = map[string]map[string]Func{}
+)
+
+func init() {
+	wasm.RegisterBinaryType(BinaryType, wasm.ModuleFactoryFunc(newModule))
+}
+
+// Register makes the functions of `entrypoints`, keyed by entrypoint name,
+// available to the binaries of type "native/go" whose 'native' field is
+// `<name>@<version>`.
+//
+// The 'native' field is the only content of the binary, so the module hashes
+// only change with it: the version must be bumped whenever the outputs of the
+// functions change, or the caches of the previous version would be reused.
+func Register(name, version string, entrypoints map[string]Func) {
+	if name == "" || strings.Contains(name, "@") {
+		panic(fmt.Sprintf("invalid native module name %q, it must be set and not contain '@'", name))
+	}
+	if version == "" {
+		panic(fmt.Sprintf("native module %q requires a version", name))
+	}
+	ref := name + "@" + version
+
+	modulesLock.Lock()
+	defer modulesLock.Unlock()
+
+	if modules[ref] != nil {
+		panic(fmt.Sprintf("native module %q already registered", ref))
+	}
+	modules[ref] = entrypoints
+}
+
+// Module executes the entrypoints of a registered native module, it holds no
+// state between calls.
+type Module struct {
+	name        string
+	entrypoints map[string]Func
+}
+
+func newModule(ctx context.Context, code []byte, registry *wasm.Registry) (wasm.Module, error) {
+	name := string(code)
+	if _, version, _ := strings.Cut(name, "@"); version == "" {
+		return nil, fmt.Errorf("native module %q has no version, expected '<name>@<version>'", name)
+	}
+
+	modulesLock.RLock()
+	entrypoints, found := modules[name]
+	modulesLock.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("native module %q is not registered", name)
+	}
+
+	return &Module{
+		name:        name,
+		entrypoints: entrypoints,
+	}, nil
+}
+
+func (m *Module) Close(ctx context.Context) error { return nil }
+
+func (m *Module) ExecuteNewCall(ctx context.Context, call *wasm.Call, cachedInstance wasm.Instance, arguments []wasm.Argument) (out wasm.Instance, err error) {
+	inst := instance{}
+
+	f := m.entrypoints[call.Entrypoint]
+	if f == nil {
+		return inst, fmt.Errorf("could not find entrypoint function %q in native module %q", call.Entrypoint, m.name)
+	}
+
+	// Invalid store operations panic, as they do from the WASM host functions.
+	defer func() {
+		if r := recover(); r != nil {
+			if panicErr, ok := r.(error); ok {
+				err = fmt.Errorf("call: %w", panicErr)
+			} else {
+				err = fmt.Errorf("call: %v", r)
+			}
+		}
+	}()
+
+	if err := f(wasm.WithContext(ctx, call), call, arguments); err != nil {
+		call.SetPanicError(err.Error(), m.name, 0, 0)
+	}
+	return inst, nil
+}
+
+type instance struct{}
+
+func (instance) Cleanup(ctx context.Context) error { return nil }
+func (instance) Close(ctx context.Context) error   { return nil }
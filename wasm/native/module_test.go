@@ -0,0 +1,153 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/wasm"
+)
+
+func init() {
+	Register("test_native", "v1", map[string]Func{
+		"map_echo": func(ctx context.Context, call *wasm.Call, arguments []wasm.Argument) error {
+			call.SetReturnValue(append([]byte("echo:"), arguments[0].(wasm.ValueArgument).Value()...))
+			return nil
+		},
+		"store_copy": func(ctx context.Context, call *wasm.Call, arguments []wasm.Argument) error {
+			value, found := call.DoGetLast(0, "key")
+			if !found {
+				return fmt.Errorf("key not found")
+			}
+			call.DoSet(0, "copied", value)
+			return nil
+		},
+		"store_invalid_op": func(ctx context.Context, call *wasm.Call, arguments []wasm.Argument) error {
+			call.DoAddInt64(0, "key", 1)
+			return nil
+		},
+	})
+}
+
+func TestModule_ExecuteNewCall(t *testing.T) {
+	ctx := context.Background()
+
+	module, err := wasm.NewRegistry(nil, 0).NewBinaryModule(ctx, BinaryType, []byte("test_native@v1"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		entrypoint      string
+		inputValue      string
+		inputStoreValue string
+		expectOutput    string
+		expectCopied    string
+		expectPanicErr  string
+		expectErr       string
+	}{
+		{
+			name:         "map output",
+			entrypoint:   "map_echo",
+			inputValue:   "block",
+			expectOutput: "echo:block",
+		},
+		{
+			name:            "store read and write",
+			entrypoint:      "store_copy",
+			inputValue:      "block",
+			inputStoreValue: "value",
+			expectCopied:    "value",
+		},
+		{
+			name:           "returned error fails deterministically",
+			entrypoint:     "store_copy",
+			inputValue:     "block",
+			expectPanicErr: `panic in the wasm: "key not found" at test_native@v1:0:0`,
+		},
+		{
+			name:       "invalid store operation",
+			entrypoint: "store_invalid_op",
+			inputValue: "block",
+			expectErr:  `call: module "test": invalid store operation "add_int64", only valid for stores with updatePolicy == "add" and valueType == "int64"`,
+		},
+		{
+			name:       "unknown entrypoint",
+			entrypoint: "unknown",
+			expectErr:  `could not find entrypoint function "unknown" in native module "test_native@v1"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inputStore := newTestStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET)
+			if test.inputStoreValue != "" {
+				inputStore.SetBytes(0, "key", []byte(test.inputStoreValue))
+			}
+			outputStore := newTestStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET)
+
+			input := wasm.NewMapInput("map_input")
+			input.SetValue([]byte(test.inputValue))
+			arguments := []wasm.Argument{
+				input,
+				wasm.NewStoreReaderInput("store_input", inputStore),
+				wasm.NewStoreWriterOutput("store_output", outputStore, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string"),
+			}
+
+			call := wasm.NewCall(&pbsubstreams.Clock{Number: 1}, "test", test.entrypoint, arguments)
+			inst, err := module.ExecuteNewCall(ctx, call, nil, arguments)
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, inst.Close(ctx))
+
+			if test.expectPanicErr != "" {
+				assert.EqualError(t, call.Err(), test.expectPanicErr)
+				return
+			}
+			require.NoError(t, call.Err())
+
+			assert.Equal(t, test.expectOutput, string(call.Output()))
+			if test.expectCopied != "" {
+				value, found := outputStore.GetLast("copied")
+				require.True(t, found)
+				assert.Equal(t, test.expectCopied, string(value))
+			}
+		})
+	}
+}
+
+func TestNewModule_NotRegistered(t *testing.T) {
+	tests := []struct {
+		code      string
+		expectErr string
+	}{
+		{"unknown@v1", `native module "unknown@v1" is not registered`},
+		{"test_native@v2", `native module "test_native@v2" is not registered`},
+		{"test_native", `native module "test_native" has no version, expected '<name>@<version>'`},
+		{"test_native@", `native module "test_native@" has no version, expected '<name>@<version>'`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			_, err := wasm.NewRegistry(nil, 0).NewBinaryModule(context.Background(), BinaryType, []byte(test.code))
+			assert.EqualError(t, err, test.expectErr)
+		})
+	}
+}
+
+func newTestStore(t *testing.T, updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy) *store.FullKV {
+	t.Helper()
+
+	storeConf, err := store.NewConfig("test", 0, "", updatePolicy, "string", dstore.NewMockStore(nil), "test")
+	require.NoError(t, err)
+	return storeConf.NewFullKV(zap.NewNop())
+}
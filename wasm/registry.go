@@ -14,8 +14,15 @@ import (
 type Registry struct {
 	Extensions           map[string]map[string]WASMExtension
 	maxFuel              uint64
+	fuelMetering         bool
 	runtimeStack         ModuleFactory
+	runtimeName          string
 	instanceCacheEnabled bool
+
+	instancePoolingEnabled bool
+
+	verificationRuntime     ModuleFactory
+	verificationRuntimeName string
 }
 
 func (r *Registry) registerWASMExtension(namespace string, importName string, ext WASMExtension) {
@@ -41,10 +48,81 @@ func (r *Registry) registerWASMExtension(namespace string, importName string, ex
 	r.Extensions[namespace][importName] = ext
 }
 func (r *Registry) MaxFuel() uint64            { return r.maxFuel }
+func (r *Registry) FuelMetering() bool         { return r.fuelMetering || r.maxFuel != 0 }
 func (r *Registry) InstanceCacheEnabled() bool { return r.instanceCacheEnabled }
 
+// InstancePoolingEnabled returns whether the instances are reused between calls
+// after restoring the snapshot of their memory and globals taken right after
+// instantiation, which unlike the instance cache keeps the output deterministic.
+func (r *Registry) InstancePoolingEnabled() bool { return r.instancePoolingEnabled }
+
+// RuntimeName returns the name of the WASM runtime executing the modules.
+func (r *Registry) RuntimeName() string { return r.runtimeName }
+
+// MetersFuel returns whether the modules of the binaries of `binaryType` enforce
+// the fuel limit of their calls, which only some WASM runtimes do, see
+// RegisterFuelMeteringRuntime.
+func (r *Registry) MetersFuel(binaryType string) bool {
+	return binaryType == WASMBinaryType && fuelMeteringRuntimes[r.runtimeName]
+}
+
+// EnableFuelMetering makes the modules created afterwards meter the fuel consumed
+// by their calls, see `Call.MaxFuel`, for the runtimes supporting it.
+func (r *Registry) EnableFuelMetering() {
+	r.fuelMetering = true
+}
+
+// EnableRuntimeVerification makes the modules created afterwards execute each
+// call in the `runtime` as well, comparing its output, store deltas and logs with
+// the ones of the selected runtime, the calls whose results differ failing with
+// a RuntimeDivergenceError. It is meant to validate the runtimes, doubling the
+// cost of the executions.
+func (r *Registry) EnableRuntimeVerification(runtime string) error {
+	factory := runtimes[runtime]
+	if factory == nil {
+		return fmt.Errorf("unknown wasm runtime %q", runtime)
+	}
+	if runtime == r.runtimeName {
+		return fmt.Errorf("wasm runtime %q is already the runtime in use, set `SUBSTREAMS_WASM_RUNTIME` to another runtime to verify it", runtime)
+	}
+	zlog.Info("verifying wasm runtime", zap.String("runtime", r.runtimeName), zap.String("verification_runtime", runtime))
+	r.verificationRuntime = factory
+	r.verificationRuntimeName = runtime
+	return nil
+}
+
 func (r *Registry) NewModule(ctx context.Context, wasmCode []byte) (Module, error) {
-	return r.runtimeStack.NewModule(ctx, wasmCode, r)
+	module, err := r.runtimeStack.NewModule(ctx, wasmCode, r)
+	if err != nil || r.verificationRuntime == nil {
+		return module, err
+	}
+
+	verification, err := r.verificationRuntime.NewModule(ctx, wasmCode, r)
+	if err != nil {
+		if closeErr := module.Close(ctx); closeErr != nil {
+			zlog.Warn("failed to close module", zap.Error(closeErr))
+		}
+		return nil, fmt.Errorf("verification runtime %q: %w", r.verificationRuntimeName, err)
+	}
+	return &verifyingModule{
+		Module:              module,
+		verification:        verification,
+		runtime:             r.runtimeName,
+		verificationRuntime: r.verificationRuntimeName,
+	}, nil
+}
+
+// NewBinaryModule creates the Module of a binary of any supported type, see RegisterBinaryType.
+func (r *Registry) NewBinaryModule(ctx context.Context, binaryType string, code []byte) (Module, error) {
+	if binaryType == WASMBinaryType {
+		return r.NewModule(ctx, code)
+	}
+
+	factory := binaryTypes[binaryType]
+	if factory == nil {
+		return nil, fmt.Errorf("unsupported binary type %q", binaryType)
+	}
+	return factory.NewModule(ctx, code, r)
 }
 
 func NewRegistry(extensions []WASMExtensioner, maxFuel uint64) *Registry {
@@ -63,7 +141,11 @@ func NewRegistry(extensions []WASMExtensioner, maxFuel uint64) *Registry {
 		zlog.Warn("Running with WASM cache because SUBSTREAMS_WASM_CACHE_ENABLED variable was set -- this will produce non-deterministic output and poison your cache. Never use the WASM cache in production.")
 		r.instanceCacheEnabled = true
 	}
+	if pooling := os.Getenv("SUBSTREAMS_WASM_INSTANCE_POOLING_ENABLED"); pooling == "true" {
+		r.instancePoolingEnabled = true
+	}
 	cacheField := zap.Bool("cache_enabled", r.instanceCacheEnabled)
+	poolingField := zap.Bool("instance_pooling_enabled", r.instancePoolingEnabled)
 
 	runtimeName := "wazero" // default
 	runtime := runtimes[runtimeName]
@@ -74,12 +156,13 @@ func NewRegistry(extensions []WASMExtensioner, maxFuel uint64) *Registry {
 		} else {
 			runtimeName = selectRuntime
 			runtime = selectedRuntime
-			zlog.Info("using wasm runtime specified by env var", zap.String("runtime", runtimeName), cacheField)
+			zlog.Info("using wasm runtime specified by env var", zap.String("runtime", runtimeName), cacheField, poolingField)
 		}
 	} else {
-		zlog.Info("using default wasm runtime", zap.String("runtime", runtimeName), cacheField)
+		zlog.Info("using default wasm runtime", zap.String("runtime", runtimeName), cacheField, poolingField)
 	}
 	r.runtimeStack = runtime
+	r.runtimeName = runtimeName
 
 	return r
 }
@@ -0,0 +1,200 @@
+package wasm
+
+import (
+	"bytes"
+	"debug/dwarf"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A TrapError is returned by the runtimes when the execution of a module traps,
+// on an `unreachable` instruction, an out of bounds memory access, etc., which
+// is deterministic.
+type TrapError struct {
+	Message string
+	// Frames of the WASM stack when the module trapped, innermost first.
+	Frames []StackFrame
+}
+
+func (e *TrapError) Error() string {
+	return fmt.Sprintf("wasm trap: %s", e.Message)
+}
+
+// StackTrace returns the frames formatted one per line.
+func (e *TrapError) StackTrace() (out []string) {
+	for _, frame := range e.Frames {
+		out = append(out, frame.String())
+	}
+	return out
+}
+
+type StackFrame struct {
+	Function string
+	// Location is the source location of the frame, as `file:line:column`,
+	// empty when the code has no DWARF debug information.
+	Location string
+}
+
+func (f StackFrame) String() string {
+	if f.Location == "" {
+		return f.Function
+	}
+	return fmt.Sprintf("%s at %s", f.Function, f.Location)
+}
+
+// Symbols resolves the function names and the source locations of a WASM code,
+// from its `name` custom section and its DWARF debug information when present.
+type Symbols struct {
+	functionNames map[uint32]string
+
+	// codeStart is the offset of the content of the code section in the code,
+	// the DWARF addresses being relative to it.
+	codeStart uint64
+	dwarf     *dwarf.Data
+
+	linesOnce sync.Once
+	lines     []sourceLine
+}
+
+type sourceLine struct {
+	address  uint64
+	location string
+}
+
+// NewSymbols decodes the symbols of `code`, ignoring the ones it fails to
+// decode, symbols being only used to describe traps.
+func NewSymbols(code []byte) *Symbols {
+	s := &Symbols{functionNames: map[uint32]string{}}
+	if len(code) < 8 || !bytes.Equal(code[:4], []byte("\x00asm")) {
+		return s
+	}
+
+	debugSections := map[string][]byte{}
+	r := &wasmReader{data: code, offset: 8}
+	for !r.done() {
+		id := r.byte()
+		size := r.u32()
+		start := r.offset
+		content := r.bytes(int(size))
+		if r.err != nil {
+			break
+		}
+		switch id {
+		case sectionCode:
+			s.codeStart = uint64(start)
+		case sectionCustom:
+			cr := &wasmReader{data: content}
+			name := string(cr.bytes(int(cr.u32())))
+			if cr.err != nil {
+				continue
+			}
+			if name == "name" {
+				s.decodeFunctionNames(content[cr.offset:])
+			} else if strings.HasPrefix(name, ".debug_") {
+				debugSections[name] = content[cr.offset:]
+			}
+		}
+	}
+
+	if info := debugSections[".debug_info"]; info != nil {
+		d, err := dwarf.New(debugSections[".debug_abbrev"], nil, nil, info, debugSections[".debug_line"], nil, debugSections[".debug_ranges"], debugSections[".debug_str"])
+		if err == nil {
+			for _, name := range []string{".debug_addr", ".debug_line_str", ".debug_str_offsets", ".debug_rnglists"} {
+				if section := debugSections[name]; section != nil {
+					if err := d.AddSection(name, section); err != nil {
+						d = nil
+						break
+					}
+				}
+			}
+			s.dwarf = d
+		}
+	}
+	return s
+}
+
+// decodeFunctionNames decodes the function names subsection of the `name` section.
+func (s *Symbols) decodeFunctionNames(content []byte) {
+	r := &wasmReader{data: content}
+	for !r.done() {
+		id := r.byte()
+		subsection := r.bytes(int(r.u32()))
+		if r.err != nil || id != 1 {
+			continue
+		}
+		sr := &wasmReader{data: subsection}
+		for count := sr.u32(); count > 0 && sr.err == nil; count-- {
+			index := sr.u32()
+			name := sr.bytes(int(sr.u32()))
+			if sr.err == nil {
+				s.functionNames[index] = string(name)
+			}
+		}
+	}
+}
+
+// FunctionName returns the name of the function at `index`, counting the imported
+// functions, or `$<index>` when it is unnamed.
+func (s *Symbols) FunctionName(index uint32) string {
+	if name, found := s.functionNames[index]; found {
+		return name
+	}
+	return fmt.Sprintf("$%d", index)
+}
+
+// Location returns the source location of the instruction at `moduleOffset` in
+// the code, or an empty string when it is unknown.
+func (s *Symbols) Location(moduleOffset uint64) string {
+	if s.dwarf == nil || moduleOffset < s.codeStart {
+		return ""
+	}
+	s.linesOnce.Do(s.readLines)
+
+	address := moduleOffset - s.codeStart
+	index := sort.Search(len(s.lines), func(i int) bool { return s.lines[i].address > address })
+	if index == 0 {
+		return ""
+	}
+	return s.lines[index-1].location
+}
+
+// readLines reads the line tables of all the compilation units, sorting them by
+// address as some compilers interleave them.
+func (s *Symbols) readLines() {
+	r := s.dwarf.Reader()
+	for {
+		entry, err := r.Next()
+		if err != nil || entry == nil {
+			break
+		}
+		r.SkipChildren()
+		if entry.Tag != dwarf.TagCompileUnit {
+			continue
+		}
+		lineReader, err := s.dwarf.LineReader(entry)
+		if err != nil || lineReader == nil {
+			continue
+		}
+		var line dwarf.LineEntry
+		for {
+			if err := lineReader.Next(&line); err != nil {
+				// io.EOF ends the table, other errors leaving it partially read
+				break
+			}
+			if line.EndSequence || line.File == nil {
+				continue
+			}
+			location := line.File.Name
+			if line.Line != 0 {
+				location = fmt.Sprintf("%s:%d", location, line.Line)
+				if line.Column != 0 {
+					location = fmt.Sprintf("%s:%d", location, line.Column)
+				}
+			}
+			s.lines = append(s.lines, sourceLine{address: line.Address, location: location})
+		}
+	}
+	sort.SliceStable(s.lines, func(i, j int) bool { return s.lines[i].address < s.lines[j].address })
+}
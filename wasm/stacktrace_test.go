@@ -0,0 +1,42 @@
+package wasm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trap.wasm exports `run`, calling `boom` which traps on `unreachable`, with the
+// function names in its `name` section and DWARF line information mapping
+// `boom` to src/lib.rs:12 and 14, and `run` to src/lib.rs:20.
+func TestSymbols(t *testing.T) {
+	code, err := os.ReadFile("testdata/trap.wasm")
+	require.NoError(t, err)
+	symbols := NewSymbols(code)
+
+	assert.Equal(t, "boom", symbols.FunctionName(0))
+	assert.Equal(t, "run", symbols.FunctionName(1))
+	assert.Equal(t, "$2", symbols.FunctionName(2))
+
+	codeStart := uint64(30)
+	assert.Equal(t, "", symbols.Location(codeStart-1))
+	assert.Equal(t, "src/lib.rs:12:5", symbols.Location(codeStart))
+	assert.Equal(t, "src/lib.rs:12:5", symbols.Location(codeStart+2))
+	assert.Equal(t, "src/lib.rs:14:5", symbols.Location(codeStart+3))
+	assert.Equal(t, "src/lib.rs:20:5", symbols.Location(codeStart+7))
+
+	withoutDebugInfo := NewSymbols(code[:codeStart+10])
+	assert.Equal(t, "$0", withoutDebugInfo.FunctionName(0))
+	assert.Equal(t, "", withoutDebugInfo.Location(codeStart+3))
+}
+
+func TestTrapError_StackTrace(t *testing.T) {
+	err := &TrapError{Message: "unreachable", Frames: []StackFrame{
+		{Function: "boom", Location: "src/lib.rs:14:5"},
+		{Function: "run"},
+	}}
+	assert.Equal(t, "wasm trap: unreachable", err.Error())
+	assert.Equal(t, []string{"boom at src/lib.rs:14:5", "run"}, err.StackTrace())
+}
@@ -0,0 +1,172 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	"github.com/streamingfast/substreams/storage/store"
+)
+
+// A RuntimeDivergenceError is returned by the calls whose results differ between
+// the runtime of the Registry and its verification runtime, see
+// Registry.EnableRuntimeVerification.
+type RuntimeDivergenceError struct {
+	BlockNumber         uint64
+	ModuleName          string
+	Runtime             string
+	VerificationRuntime string
+	Reason              string
+}
+
+func (e *RuntimeDivergenceError) Error() string {
+	return fmt.Sprintf("block %d: module %q: runtimes %q and %q diverged: %s", e.BlockNumber, e.ModuleName, e.Runtime, e.VerificationRuntime, e.Reason)
+}
+
+// A verifyingModule executes each call in the verification runtime, then in the
+// runtime of the Registry, comparing their results.
+type verifyingModule struct {
+	Module
+	verification Module
+
+	runtime             string
+	verificationRuntime string
+}
+
+// ExecuteNewCall executes the call in the verification runtime first, on a new
+// instance, reverting the changes it made to the output store, so that the call
+// of the runtime of the Registry is the one whose results are kept.
+func (m *verifyingModule) ExecuteNewCall(ctx context.Context, call *Call, cachedInstance Instance, arguments []Argument) (Instance, error) {
+	var restoreDeletions func()
+	if partialStore, ok := call.outputStore.(*store.PartialKV); ok {
+		restoreDeletions = partialStore.SnapshotDeletions()
+	}
+
+	verificationCall := NewCall(call.Clock, call.ModuleName, call.Entrypoint, arguments)
+	verificationCall.SetLimits(call.MaxFuel(), call.MaxLogByteCount())
+	verificationInstance, verificationErr := m.verification.ExecuteNewCall(ctx, verificationCall, nil, arguments)
+	if verificationInstance != nil {
+		if err := verificationInstance.Close(ctx); err != nil {
+			return nil, fmt.Errorf("closing verification instance: %w", err)
+		}
+	}
+	var verificationDeltas []*pbssinternal.StoreDelta
+	if outputStore := call.outputStore; outputStore != nil {
+		verificationDeltas = outputStore.GetDeltas()
+		outputStore.ApplyDeltasReverse(verificationDeltas)
+		outputStore.Reset()
+	}
+	if restoreDeletions != nil {
+		restoreDeletions()
+	}
+
+	instance, err := m.Module.ExecuteNewCall(ctx, call, cachedInstance, arguments)
+	if err != nil && !failedDeterministically(call, err) {
+		return instance, err
+	}
+	if verificationErr != nil && !failedDeterministically(verificationCall, verificationErr) {
+		return instance, fmt.Errorf("verification runtime %q: %w", m.verificationRuntime, verificationErr)
+	}
+
+	if reason := m.compareCalls(call, err, verificationCall, verificationErr, verificationDeltas); reason != "" {
+		divergence := &RuntimeDivergenceError{
+			ModuleName:          call.ModuleName,
+			Runtime:             m.runtime,
+			VerificationRuntime: m.verificationRuntime,
+			Reason:              reason,
+		}
+		if call.Clock != nil {
+			divergence.BlockNumber = call.Clock.Number
+		}
+		if instance != nil {
+			if err := instance.Close(ctx); err != nil {
+				return nil, fmt.Errorf("closing instance: %w", err)
+			}
+		}
+		return nil, divergence
+	}
+	return instance, err
+}
+
+func (m *verifyingModule) Close(ctx context.Context) error {
+	err := m.Module.Close(ctx)
+	if verificationErr := m.verification.Close(ctx); err == nil {
+		err = verificationErr
+	}
+	return err
+}
+
+// failedDeterministically returns whether the call failed on a panic or a trap,
+// which both runtimes must agree on, unlike the errors of the host functions.
+func failedDeterministically(call *Call, err error) bool {
+	var trap *TrapError
+	return call.Err() != nil || errors.As(err, &trap)
+}
+
+// compareCalls returns why the results of `call` and `verificationCall` differ,
+// an empty string when they don't.
+func (m *verifyingModule) compareCalls(call *Call, err error, verificationCall *Call, verificationErr error, verificationDeltas []*pbssinternal.StoreDelta) string {
+	switch {
+	case err != nil && verificationErr == nil:
+		return fmt.Sprintf("call failed in runtime %q only: %s", m.runtime, err)
+	case err == nil && verificationErr != nil:
+		return fmt.Sprintf("call failed in runtime %q only: %s", m.verificationRuntime, verificationErr)
+	case err != nil:
+		panicErr, verificationPanicErr := call.Err(), verificationCall.Err()
+		if (panicErr == nil) != (verificationPanicErr == nil) || (panicErr != nil && panicErr.Error() != verificationPanicErr.Error()) {
+			return fmt.Sprintf("call failed with %q and %q", err, verificationErr)
+		}
+	default:
+		if !bytes.Equal(call.Output(), verificationCall.Output()) {
+			return fmt.Sprintf("outputs differ, of %d and %d bytes", len(call.Output()), len(verificationCall.Output()))
+		}
+		if reason := compareDeltas(call.outputStore, verificationDeltas); reason != "" {
+			return reason
+		}
+	}
+
+	if len(call.Logs) != len(verificationCall.Logs) {
+		return fmt.Sprintf("logs differ, %d and %d logs", len(call.Logs), len(verificationCall.Logs))
+	}
+	for i, log := range call.Logs {
+		verificationLog := verificationCall.Logs[i]
+		if log.Level != verificationLog.Level || log.Message != verificationLog.Message || !equalLogFields(log.Fields, verificationLog.Fields) {
+			return fmt.Sprintf("log %d differs, %q and %q", i, log.Message, verificationLog.Message)
+		}
+	}
+	return ""
+}
+
+func compareDeltas(outputStore interface {
+	GetDeltas() []*pbssinternal.StoreDelta
+}, verificationDeltas []*pbssinternal.StoreDelta) string {
+	var deltas []*pbssinternal.StoreDelta
+	if outputStore != nil {
+		deltas = outputStore.GetDeltas()
+	}
+	if len(deltas) != len(verificationDeltas) {
+		return fmt.Sprintf("store deltas differ, %d and %d deltas", len(deltas), len(verificationDeltas))
+	}
+	for i, delta := range deltas {
+		if !proto.Equal(delta, verificationDeltas[i]) {
+			return fmt.Sprintf("store delta %d differs, %s of key %q and %s of key %q", i, delta.Operation, delta.Key, verificationDeltas[i].Operation, verificationDeltas[i].Key)
+		}
+	}
+	return ""
+}
+
+func equalLogFields(a, b []LogField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,229 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/storage/store/marshaller"
+)
+
+// fakeModule executes its calls by passing them to `execute`, without instances.
+type fakeModule struct {
+	execute func(call *Call) error
+}
+
+func (m *fakeModule) ExecuteNewCall(_ context.Context, call *Call, _ Instance, _ []Argument) (Instance, error) {
+	return nil, m.execute(call)
+}
+
+func (m *fakeModule) Close(context.Context) error { return nil }
+
+func TestVerifyingModule(t *testing.T) {
+	succeed := func(call *Call) error {
+		call.DoSet(1, "key", []byte("value"))
+		call.AppendLeveledLog(LogLevelWarn, "done", []LogField{{Key: "key", Value: "key"}})
+		call.SetReturnValue([]byte("output"))
+		return nil
+	}
+	trap := func(message string) func(call *Call) error {
+		return func(call *Call) error {
+			call.AppendLog("trapping")
+			return &TrapError{Message: message}
+		}
+	}
+
+	tests := []struct {
+		name               string
+		execute            func(call *Call) error
+		verify             func(call *Call) error
+		expectDivergence   string
+		expectError        string
+		expectStoreEntries map[string][]byte
+	}{
+		{
+			name:               "identical",
+			execute:            succeed,
+			verify:             succeed,
+			expectStoreEntries: map[string][]byte{"key": []byte("value")},
+		},
+		{
+			name:    "different output",
+			execute: succeed,
+			verify: func(call *Call) error {
+				_ = succeed(call)
+				call.SetReturnValue([]byte("other output"))
+				return nil
+			},
+			expectDivergence: "outputs differ, of 6 and 12 bytes",
+		},
+		{
+			name:    "different store deltas",
+			execute: succeed,
+			verify: func(call *Call) error {
+				_ = succeed(call)
+				call.DoSet(2, "other", []byte("value"))
+				return nil
+			},
+			expectDivergence: "store deltas differ, 1 and 2 deltas",
+		},
+		{
+			name:    "different store values",
+			execute: succeed,
+			verify: func(call *Call) error {
+				call.DoSet(1, "key", []byte("other value"))
+				call.AppendLeveledLog(LogLevelWarn, "done", []LogField{{Key: "key", Value: "key"}})
+				call.SetReturnValue([]byte("output"))
+				return nil
+			},
+			expectDivergence: `store delta 0 differs, CREATE of key "key" and CREATE of key "key"`,
+		},
+		{
+			name:    "different log fields",
+			execute: succeed,
+			verify: func(call *Call) error {
+				call.DoSet(1, "key", []byte("value"))
+				call.AppendLeveledLog(LogLevelWarn, "done", []LogField{{Key: "key", Value: "other"}})
+				call.SetReturnValue([]byte("output"))
+				return nil
+			},
+			expectDivergence: `log 0 differs, "done" and "done"`,
+		},
+		{
+			name:             "trap in one runtime only",
+			execute:          succeed,
+			verify:           trap("unreachable"),
+			expectDivergence: `call failed in runtime "verified" only: wasm trap: unreachable`,
+		},
+		{
+			name:        "trap in both runtimes",
+			execute:     trap("unreachable"),
+			verify:      trap("wasm `unreachable` instruction executed"),
+			expectError: "wasm trap: unreachable",
+		},
+		{
+			name: "panic in both runtimes",
+			execute: func(call *Call) error {
+				call.SetPanicError("boom", "lib.rs", 1, 1)
+				return trap("unreachable")(call)
+			},
+			verify: func(call *Call) error {
+				call.SetPanicError("other boom", "lib.rs", 1, 1)
+				return trap("unreachable")(call)
+			},
+			expectDivergence: `call failed with "wasm trap: unreachable" and "wasm trap: unreachable"`,
+		},
+		{
+			name:        "non-deterministic verification failure",
+			execute:     succeed,
+			verify:      func(call *Call) error { return errors.New("rpc unavailable") },
+			expectError: `verification runtime "verified": rpc unavailable`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storeConf, err := store.NewConfig("test", 0, "", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil), "test")
+			require.NoError(t, err)
+			outputStore := storeConf.NewFullKV(zap.NewNop())
+
+			module := &verifyingModule{
+				Module:              &fakeModule{execute: test.execute},
+				verification:        &fakeModule{execute: test.verify},
+				runtime:             "selected",
+				verificationRuntime: "verified",
+			}
+			arguments := []Argument{NewStoreWriterOutput("test", outputStore, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string")}
+			call := NewCall(&pbsubstreams.Clock{Number: 42}, "test", "map_test", arguments)
+
+			_, err = module.ExecuteNewCall(context.Background(), call, nil, arguments)
+			switch {
+			case test.expectDivergence != "":
+				var divergence *RuntimeDivergenceError
+				require.True(t, errors.As(err, &divergence), "expected a divergence, got %v", err)
+				assert.Equal(t, &RuntimeDivergenceError{
+					BlockNumber:         42,
+					ModuleName:          "test",
+					Runtime:             "selected",
+					VerificationRuntime: "verified",
+					Reason:              test.expectDivergence,
+				}, divergence)
+			case test.expectError != "":
+				require.EqualError(t, err, test.expectError)
+			default:
+				require.NoError(t, err)
+				for key, value := range test.expectStoreEntries {
+					actual, found := outputStore.GetLast(key)
+					require.True(t, found)
+					assert.Equal(t, value, actual)
+				}
+				assert.Len(t, outputStore.GetDeltas(), len(test.expectStoreEntries))
+			}
+		})
+	}
+}
+
+func TestVerifyingModule_PartialStoreDeletions(t *testing.T) {
+	deleteKeys := func(call *Call) error {
+		call.DoDeletePrefix(1, "old:")
+		call.DoDeletePrefix(2, "a:")
+		call.DoDeleteRange(3, "r1", "r2")
+		return nil
+	}
+
+	tests := []struct {
+		name                  string
+		execute               func(call *Call) error
+		verify                func(call *Call) error
+		expectDeletedPrefixes []string
+		expectDeletedRanges   []*marshaller.DeleteRange
+	}{
+		{
+			name:                  "identical",
+			execute:               deleteKeys,
+			verify:                deleteKeys,
+			expectDeletedPrefixes: []string{"old:", "a:"},
+			expectDeletedRanges:   []*marshaller.DeleteRange{{LowKey: "r0", HighKey: "r1"}, {LowKey: "r1", HighKey: "r2"}},
+		},
+		{
+			name:    "trap in both runtimes after deleting in verification only",
+			execute: func(call *Call) error { return &TrapError{Message: "unreachable"} },
+			verify: func(call *Call) error {
+				_ = deleteKeys(call)
+				return &TrapError{Message: "unreachable"}
+			},
+			expectDeletedPrefixes: []string{"old:"},
+			expectDeletedRanges:   []*marshaller.DeleteRange{{LowKey: "r0", HighKey: "r1"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storeConf, err := store.NewConfig("test", 0, "", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", dstore.NewMockStore(nil), "test")
+			require.NoError(t, err)
+			outputStore := storeConf.NewPartialKV(0, zap.NewNop())
+			outputStore.DeletePrefix(0, "old:")
+			outputStore.DeleteRange(0, "r0", "r1")
+
+			module := &verifyingModule{
+				Module:              &fakeModule{execute: test.execute},
+				verification:        &fakeModule{execute: test.verify},
+				runtime:             "selected",
+				verificationRuntime: "verified",
+			}
+			arguments := []Argument{NewStoreWriterOutput("test", outputStore, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string")}
+			call := NewCall(&pbsubstreams.Clock{Number: 42}, "test", "store_test", arguments)
+
+			_, _ = module.ExecuteNewCall(context.Background(), call, nil, arguments)
+			assert.Equal(t, test.expectDeletedPrefixes, outputStore.DeletedPrefixes)
+			assert.Equal(t, test.expectDeletedRanges, outputStore.DeletedRanges)
+		})
+	}
+}
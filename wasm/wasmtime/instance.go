@@ -20,6 +20,15 @@ type instance struct {
 	wasmLinker   *wasmtime.Linker
 	Heap         *Heap
 	isClosed     bool
+	snapshot     *snapshot
+}
+
+// A snapshot is the state of the memory and mutable globals of an instance
+// right after its instantiation.
+type snapshot struct {
+	memory      []byte
+	globalNames []string
+	globals     []wasmtime.Val
 }
 
 func (i *instance) Close(ctx context.Context) error {
@@ -39,6 +48,26 @@ func (i *instance) Cleanup(ctx context.Context) error {
 	return nil
 }
 
+// Restore restores the snapshot of the instance, if any, and if its memory
+// didn't grow, wasmtime not being able to shrink it.
+func (i *instance) Restore(ctx context.Context) (bool, error) {
+	if i.snapshot == nil {
+		return false, nil
+	}
+	memory := i.Heap.memory.UnsafeData(i.wasmStore)
+	if len(memory) != len(i.snapshot.memory) {
+		return false, nil
+	}
+	copy(memory, i.snapshot.memory)
+	for j, name := range i.snapshot.globalNames {
+		if err := i.wasmInstance.GetExport(i.wasmStore, name).Global().Set(i.wasmStore, i.snapshot.globals[j]); err != nil {
+			return false, fmt.Errorf("restoring global %q: %w", name, err)
+		}
+	}
+	i.Heap.allocations = nil
+	return true, nil
+}
+
 func (i *instance) newExtensionFunction(ctx context.Context, namespace, name string, f wasm.WASMExtension) interface{} {
 	return func(ptr, length, outputPtr int32) {
 		data := i.Heap.ReadBytes(ptr, length)
@@ -108,6 +137,27 @@ func (i *instance) registerLoggerImports(linker *wasmtime.Linker) error {
 	); err != nil {
 		return fmt.Errorf("registering println import: %w", err)
 	}
+
+	for name, level := range map[string]wasm.LogLevel{
+		"debug": wasm.LogLevelDebug,
+		"info":  wasm.LogLevelInfo,
+		"warn":  wasm.LogLevelWarn,
+		"error": wasm.LogLevelError,
+	} {
+		name, level := name, level
+		if err := linker.FuncWrap("logger", name,
+			func(ptr, length, fieldsPtr, fieldsLength int32) {
+				message := i.Heap.ReadString(ptr, length)
+				fields, err := wasm.DecodeLogFields(i.Heap.ReadBytes(fieldsPtr, fieldsLength))
+				if err != nil {
+					panic(fmt.Errorf("logging %s message: %w", name, err))
+				}
+				i.CurrentCall.AppendLeveledLog(level, message, fields)
+			},
+		); err != nil {
+			return fmt.Errorf("registering %s import: %w", name, err)
+		}
+	}
 	return nil
 }
 
@@ -117,6 +167,8 @@ func (i *instance) registerStateImports(linker *wasmtime.Linker) error {
 	functions["set_if_not_exists"] = i.setIfNotExists
 	functions["append"] = i.append
 	functions["delete_prefix"] = i.deletePrefix
+	functions["delete_range"] = i.deleteRange
+	functions["delete_range_pointers"] = i.deleteRangePointers
 	functions["add_bigint"] = i.addBigInt
 	functions["add_bigdecimal"] = i.addBigDecimal
 	functions["add_bigfloat"] = i.addBigDecimal
@@ -138,6 +190,12 @@ func (i *instance) registerStateImports(linker *wasmtime.Linker) error {
 	functions["has_at"] = i.hasAt
 	functions["has_first"] = i.hasFirst
 	functions["has_last"] = i.hasLast
+	functions["scan_range_first"] = i.scanRangeFirst
+	functions["scan_range_last"] = i.scanRangeLast
+	functions["scan_range_at"] = i.scanRangeAt
+	functions["scan_prefix_first"] = i.scanPrefixFirst
+	functions["scan_prefix_last"] = i.scanPrefixLast
+	functions["scan_prefix_at"] = i.scanPrefixAt
 
 	for n, f := range functions {
 		if err := linker.FuncWrap("state", n, f); err != nil {
@@ -3,8 +3,11 @@ package wasmtime
 import (
 	"context"
 	"fmt"
+	"math"
+	"sync"
 
 	wasmtime "github.com/bytecodealliance/wasmtime-go/v4"
+	"go.uber.org/zap"
 
 	"github.com/streamingfast/substreams/wasm"
 )
@@ -13,20 +16,41 @@ type Module struct {
 	module   *wasmtime.Module
 	engine   *wasmtime.Engine
 	registry *wasm.Registry
+	symbols  *wasm.Symbols
+
+	// globals are the exports of the mutable globals of the module, which
+	// can be pooled when they are all known
+	globals      []string
+	poolable     bool
+	snapshotLock sync.Mutex
+	snapshot     *snapshot
 }
 
 func init() {
 	wasm.RegisterModuleFactory("wasmtime", wasm.ModuleFactoryFunc(newModule))
+	wasm.RegisterFuelMeteringRuntime("wasmtime")
 }
 
 func newModule(ctx context.Context, wasmCode []byte, registry *wasm.Registry) (wasm.Module, error) {
 	cfg := wasmtime.NewConfig()
-	if registry.MaxFuel() != 0 {
+	if registry.FuelMetering() {
 		cfg.SetConsumeFuel(true)
 	}
 	engine := wasmtime.NewEngineWithConfig(cfg)
 
-	module, err := wasmtime.NewModule(engine, wasmCode)
+	code := wasmCode
+	var globals []string
+	var poolable bool
+	if registry.InstancePoolingEnabled() {
+		exportedCode, exportedGlobals, err := wasm.ExportMutableGlobals(wasmCode)
+		if err != nil {
+			zlog.Info("instances of wasm module can't be pooled, its globals being unknown", zap.Error(err))
+		} else {
+			code, globals, poolable = exportedCode, exportedGlobals, true
+		}
+	}
+
+	module, err := wasmtime.NewModule(engine, code)
 	if err != nil {
 		return nil, fmt.Errorf("creating new module: %w", err)
 	}
@@ -38,6 +62,9 @@ func newModule(ctx context.Context, wasmCode []byte, registry *wasm.Registry) (w
 		module:   module,
 		engine:   engine,
 		registry: registry,
+		symbols:  wasm.NewSymbols(code),
+		globals:  globals,
+		poolable: poolable,
 	}, nil
 }
 
@@ -69,12 +96,16 @@ func (m *Module) ExecuteNewCall(ctx context.Context, call *wasm.Call, cachedInst
 		return nil, fmt.Errorf("failed to get exported function %q", entrypoint)
 	}
 
-	maxFuel := m.registry.MaxFuel()
-	if maxFuel != 0 {
-		if remaining, _ := inst.wasmStore.ConsumeFuel(maxFuel); remaining != 0 {
+	maxFuel := call.MaxFuel()
+	if m.registry.FuelMetering() {
+		fuel := maxFuel
+		if fuel == 0 {
+			fuel = math.MaxInt64 // metered for the other modules only
+		}
+		if remaining, _ := inst.wasmStore.ConsumeFuel(0); remaining != 0 {
 			inst.wasmStore.ConsumeFuel(remaining) // don't accumulate fuel from previous executions
 		}
-		inst.wasmStore.AddFuel(maxFuel)
+		inst.wasmStore.AddFuel(fuel)
 	}
 
 	var args []interface{}
@@ -102,7 +133,10 @@ func (m *Module) ExecuteNewCall(ctx context.Context, call *wasm.Call, cachedInst
 	inst.CurrentCall = call
 	_, err = entrypoint.Call(inst.wasmStore, args...)
 	if err != nil {
-		return inst, fmt.Errorf("call: %w", err)
+		if remaining, _ := inst.wasmStore.ConsumeFuel(0); maxFuel != 0 && remaining == 0 {
+			return inst, fmt.Errorf("call: fuel limit of %d per block exceeded: %w", maxFuel, err)
+		}
+		return inst, fmt.Errorf("call: %w", toTrapError(err, m.symbols))
 	}
 
 	return inst, nil
@@ -143,5 +177,37 @@ func (m *Module) newInstance(ctx context.Context) (*instance, error) {
 	heap := NewHeap(memory, alloc, dealloc, i.wasmStore)
 	i.Heap = heap
 	i.wasmInstance = instance
+	if m.registry.InstancePoolingEnabled() {
+		i.snapshot = m.snapshotOf(i, memory)
+	}
 	return i, nil
 }
+
+// snapshotOf returns the snapshot of `inst`, freshly instantiated, taken once for all
+// the instances of the module, or nil if they can't be pooled.
+func (m *Module) snapshotOf(inst *instance, memory *wasmtime.Memory) *snapshot {
+	m.snapshotLock.Lock()
+	defer m.snapshotLock.Unlock()
+
+	if m.snapshot != nil || !m.poolable {
+		return m.snapshot
+	}
+
+	s := &snapshot{
+		memory:      append([]byte{}, memory.UnsafeData(inst.wasmStore)...),
+		globalNames: m.globals,
+	}
+	for _, name := range m.globals {
+		value := inst.wasmInstance.GetExport(inst.wasmStore, name).Global().Get(inst.wasmStore)
+		switch value.Kind() {
+		case wasmtime.KindI32, wasmtime.KindI64, wasmtime.KindF32, wasmtime.KindF64:
+		default:
+			zlog.Info("instances of wasm module can't be pooled, having a reference global", zap.String("global", name))
+			m.poolable = false
+			return nil
+		}
+		s.globals = append(s.globals, value)
+	}
+	m.snapshot = s
+	return s
+}
@@ -2,6 +2,9 @@ package wasmtime
 
 import (
 	"fmt"
+
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/wasm"
 )
 
 func (i *instance) set(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
@@ -27,6 +30,19 @@ func (i *instance) deletePrefix(ord int64, keyPtr, keyLength int32) {
 	i.CurrentCall.DoDeletePrefix(uint64(ord), prefix)
 }
 
+func (i *instance) deleteRange(ord int64, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength int32) {
+	lowKey := i.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := i.Heap.ReadString(highKeyPtr, highKeyLength)
+	i.CurrentCall.DoDeleteRange(uint64(ord), lowKey, highKey)
+}
+
+func (i *instance) deleteRangePointers(ord int64, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength, separatorPtr, separatorLength int32) {
+	lowKey := i.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := i.Heap.ReadString(highKeyPtr, highKeyLength)
+	separator := i.Heap.ReadString(separatorPtr, separatorLength)
+	i.CurrentCall.DoDeleteRangePointers(uint64(ord), lowKey, highKey, separator)
+}
+
 func (i *instance) addBigInt(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
 	key := i.Heap.ReadString(keyPtr, keyLength)
 	value := i.Heap.ReadString(valPtr, valLength)
@@ -129,6 +145,63 @@ func (i *instance) hasLast(storeIndex int32, keyPtr, keyLength int32) int32 {
 	return returnIfFound(found)
 }
 
+func (i *instance) scanRangeFirst(storeIndex int32, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength, limit, outputPtr int32) int32 {
+	lowKey := i.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := i.Heap.ReadString(highKeyPtr, highKeyLength)
+	keyRange := store.KeyRange{Low: lowKey, High: highKey}
+	page, result := i.CurrentCall.DoScanFirst(int(storeIndex), keyRange, int(uint32(limit)))
+	return writeScanOutput(i, outputPtr, page, result)
+}
+
+func (i *instance) scanRangeLast(storeIndex int32, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength, limit, outputPtr int32) int32 {
+	lowKey := i.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := i.Heap.ReadString(highKeyPtr, highKeyLength)
+	keyRange := store.KeyRange{Low: lowKey, High: highKey}
+	page, result := i.CurrentCall.DoScanLast(int(storeIndex), keyRange, int(uint32(limit)))
+	return writeScanOutput(i, outputPtr, page, result)
+}
+
+func (i *instance) scanRangeAt(storeIndex int32, ord int64, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength, limit, outputPtr int32) int32 {
+	lowKey := i.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := i.Heap.ReadString(highKeyPtr, highKeyLength)
+	keyRange := store.KeyRange{Low: lowKey, High: highKey}
+	page, result := i.CurrentCall.DoScanAt(int(storeIndex), uint64(ord), keyRange, int(uint32(limit)))
+	return writeScanOutput(i, outputPtr, page, result)
+}
+
+func (i *instance) scanPrefixFirst(storeIndex int32, prefixPtr, prefixLength, startKeyPtr, startKeyLength, limit, outputPtr int32) int32 {
+	prefix := i.Heap.ReadString(prefixPtr, prefixLength)
+	startKey := i.Heap.ReadString(startKeyPtr, startKeyLength)
+	keyRange := wasm.PrefixScanRange(prefix, startKey)
+	page, result := i.CurrentCall.DoScanFirst(int(storeIndex), keyRange, int(uint32(limit)))
+	return writeScanOutput(i, outputPtr, page, result)
+}
+
+func (i *instance) scanPrefixLast(storeIndex int32, prefixPtr, prefixLength, startKeyPtr, startKeyLength, limit, outputPtr int32) int32 {
+	prefix := i.Heap.ReadString(prefixPtr, prefixLength)
+	startKey := i.Heap.ReadString(startKeyPtr, startKeyLength)
+	keyRange := wasm.PrefixScanRange(prefix, startKey)
+	page, result := i.CurrentCall.DoScanLast(int(storeIndex), keyRange, int(uint32(limit)))
+	return writeScanOutput(i, outputPtr, page, result)
+}
+
+func (i *instance) scanPrefixAt(storeIndex int32, ord int64, prefixPtr, prefixLength, startKeyPtr, startKeyLength, limit, outputPtr int32) int32 {
+	prefix := i.Heap.ReadString(prefixPtr, prefixLength)
+	startKey := i.Heap.ReadString(startKeyPtr, startKeyLength)
+	keyRange := wasm.PrefixScanRange(prefix, startKey)
+	page, result := i.CurrentCall.DoScanAt(int(storeIndex), uint64(ord), keyRange, int(uint32(limit)))
+	return writeScanOutput(i, outputPtr, page, result)
+}
+
+func writeScanOutput(i *instance, outputPtr int32, page []byte, result int32) int32 {
+	if result != wasm.ScanEmpty {
+		if err := writeOutputToHeap(i, outputPtr, page); err != nil {
+			i.CurrentCall.ReturnError(fmt.Errorf("writing output to heap: %w", err))
+		}
+	}
+	return result
+}
+
 func writeToHeapIfFound(i *instance, outputPtr int32, value []byte, found bool) int32 {
 	if !found {
 		return 0
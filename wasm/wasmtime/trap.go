@@ -0,0 +1,40 @@
+package wasmtime
+
+import (
+	"errors"
+	"strings"
+
+	wasmtime "github.com/bytecodealliance/wasmtime-go/v4"
+
+	"github.com/streamingfast/substreams/wasm"
+)
+
+// toTrapError turns the error of a call trapping on an instruction into a
+// wasm.TrapError, its frames symbolicated from the `name` section and the DWARF
+// debug information of the code. Other errors, like the ones of host functions
+// or interruptions, are returned as is.
+func toTrapError(err error, symbols *wasm.Symbols) error {
+	var trap *wasmtime.Trap
+	if !errors.As(err, &trap) {
+		return err
+	}
+	code := trap.Code()
+	if code == nil || *code > wasmtime.UnreachableCodeReached {
+		return err
+	}
+
+	// the message has the backtrace formatted by wasmtime, followed by its cause
+	message := trap.Message()
+	if _, cause, found := strings.Cut(message, "Caused by:"); found {
+		message = cause
+	}
+	message, _, _ = strings.Cut(strings.TrimSpace(message), "\n")
+	out := &wasm.TrapError{Message: strings.TrimPrefix(message, "wasm trap: ")}
+	for _, frame := range trap.Frames() {
+		out.Frames = append(out.Frames, wasm.StackFrame{
+			Function: symbols.FunctionName(frame.FuncIndex()),
+			Location: symbols.Location(uint64(frame.ModuleOffset())),
+		})
+	}
+	return out
+}
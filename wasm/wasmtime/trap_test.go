@@ -0,0 +1,39 @@
+package wasmtime
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	wasmtime "github.com/bytecodealliance/wasmtime-go/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams/wasm"
+)
+
+func TestToTrapError(t *testing.T) {
+	code, err := os.ReadFile("../testdata/trap.wasm")
+	require.NoError(t, err)
+
+	engine := wasmtime.NewEngine()
+	module, err := wasmtime.NewModule(engine, code)
+	require.NoError(t, err)
+	store := wasmtime.NewStore(engine)
+	instance, err := wasmtime.NewInstance(store, module, nil)
+	require.NoError(t, err)
+
+	_, err = instance.GetFunc(store, "run").Call(store)
+	require.Error(t, err)
+
+	var trap *wasm.TrapError
+	require.True(t, errors.As(toTrapError(err, wasm.NewSymbols(code)), &trap), err.Error())
+	assert.Equal(t, "wasm `unreachable` instruction executed", trap.Message)
+	assert.Equal(t, []wasm.StackFrame{
+		{Function: "boom", Location: "src/lib.rs:14:5"},
+		{Function: "run", Location: "src/lib.rs:20:5"},
+	}, trap.Frames)
+
+	hostErr := wasmtime.NewTrap("running wasm extension")
+	assert.Same(t, hostErr, toTrapError(hostErr, wasm.NewSymbols(code)))
+}
@@ -0,0 +1,403 @@
+package wazero
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams/metrics"
+	"github.com/streamingfast/substreams/wasm"
+)
+
+const (
+	defaultCompilationCacheSize    = 256 * 1024 * 1024
+	defaultCompilationCacheDirSize = 1024 * 1024 * 1024
+)
+
+var (
+	sharedCompilationCache     *compilationCache
+	sharedCompilationCacheOnce sync.Once
+)
+
+// getCompilationCache returns the process-wide compilation cache, shared by the
+// registries of all the tiers, configured from the environment on first use:
+//
+//   - SUBSTREAMS_WASM_COMPILATION_CACHE_SIZE bounds the size of the WASM code of the
+//     cached modules, the memory of their compiled code being proportional, "0"
+//     disabling the cache (default "256MiB");
+//   - SUBSTREAMS_WASM_COMPILATION_CACHE_DIR keeps the compiled code on disk too, so
+//     evicted modules and restarted processes don't compile them again;
+//   - SUBSTREAMS_WASM_COMPILATION_CACHE_DIR_SIZE bounds the size of that directory,
+//     the oldest files being removed first (default "1GiB").
+func getCompilationCache() *compilationCache {
+	sharedCompilationCacheOnce.Do(func() {
+		maxSize := parseSizeEnv("SUBSTREAMS_WASM_COMPILATION_CACHE_SIZE", defaultCompilationCacheSize)
+		dir := os.Getenv("SUBSTREAMS_WASM_COMPILATION_CACHE_DIR")
+		maxDirSize := parseSizeEnv("SUBSTREAMS_WASM_COMPILATION_CACHE_DIR_SIZE", defaultCompilationCacheDirSize)
+
+		zlog.Info("wasm compilation cache",
+			zap.String("max_size", humanize.IBytes(maxSize)),
+			zap.String("dir", dir),
+			zap.String("max_dir_size", humanize.IBytes(maxDirSize)),
+		)
+		sharedCompilationCache = newCompilationCache(maxSize, dir, maxDirSize)
+	})
+	return sharedCompilationCache
+}
+
+func parseSizeEnv(name string, defaultValue uint64) uint64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	size, err := humanize.ParseBytes(value)
+	if err != nil {
+		panic(fmt.Errorf("invalid size %q in `%s` env var: %w", value, name, err))
+	}
+	return size
+}
+
+// compilationCache keeps the modules compiled for a WASM code and a set of host
+// modules, so that the requests running the same code share them instead of
+// compiling it again. Modules are evicted, least recently used first, when the
+// size of their WASM code exceeds `maxSize`, and closed once no request uses them.
+type compilationCache struct {
+	mu      sync.Mutex
+	maxSize uint64
+	size    uint64
+	entries map[string]*list.Element
+	lru     *list.List
+
+	dir        string
+	maxDirSize uint64
+	dirMu      sync.Mutex
+}
+
+func newCompilationCache(maxSize uint64, dir string, maxDirSize uint64) *compilationCache {
+	return &compilationCache{
+		maxSize:    maxSize,
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+		dir:        dir,
+		maxDirSize: maxDirSize,
+	}
+}
+
+// A compiledModule is a runtime with the host modules and the user module
+// compiled, shared by the Modules of the requests running the same code.
+type compiledModule struct {
+	sync.Mutex
+	key  string
+	size uint64
+
+	refs    int
+	evicted bool
+
+	wazRuntime      wazero.Runtime
+	wazCache        wazero.CompilationCache
+	wazModuleConfig wazero.ModuleConfig
+	hostModules     []wazero.CompiledModule
+	userModule      wazero.CompiledModule
+
+	// globals are the exports of the mutable globals of the user module, which
+	// can be pooled when they are all known
+	globals  []string
+	poolable bool
+	snapshot *snapshot
+}
+
+// compilationCacheKey identifies the modules compiled for `wasmCode` with the host
+// modules of `registry`, its extensions being the only ones differing between registries,
+// and whether its instances are pooled, the code being rewritten only then.
+func compilationCacheKey(wasmCode []byte, registry *wasm.Registry) string {
+	var imports []string
+	for namespace, functions := range registry.Extensions {
+		for importName := range functions {
+			imports = append(imports, namespace+"."+importName)
+		}
+	}
+	sort.Strings(imports)
+
+	h := sha256.New()
+	codeHash := sha256.Sum256(wasmCode)
+	h.Write(codeHash[:])
+	for _, imp := range imports {
+		h.Write([]byte("\n" + imp))
+	}
+	if registry.InstancePoolingEnabled() {
+		h.Write([]byte("\npooling"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// acquire returns the modules compiled for `wasmCode` and `registry`, compiling them
+// on a miss. They must be given back through `release`.
+func (c *compilationCache) acquire(ctx context.Context, wasmCode []byte, registry *wasm.Registry) (*compiledModule, error) {
+	key := compilationCacheKey(wasmCode, registry)
+
+	if compiled := c.get(key); compiled != nil {
+		metrics.WASMCompilationCacheHits.Inc()
+		zlog.Debug("wasm compilation cache hit", zap.String("key", key))
+		return compiled, nil
+	}
+	metrics.WASMCompilationCacheMisses.Inc()
+	zlog.Debug("wasm compilation cache miss", zap.String("key", key))
+
+	compiled, err := c.compile(ctx, key, wasmCode, registry)
+	if err != nil {
+		return nil, err
+	}
+	if c.dir != "" {
+		c.pruneDir()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, found := c.entries[key]; found {
+		// Compiled concurrently by another request, ours is dropped
+		compiled.evicted = true
+		c.closeCompiled(ctx, compiled)
+
+		compiled = elem.Value.(*compiledModule)
+		compiled.refs++
+		c.lru.MoveToFront(elem)
+		return compiled, nil
+	}
+
+	compiled.refs = 1
+	if compiled.size > c.maxSize {
+		compiled.evicted = true
+		return compiled, nil
+	}
+	c.entries[key] = c.lru.PushFront(compiled)
+	c.size += compiled.size
+	for c.size > c.maxSize {
+		c.evict(ctx, c.lru.Back())
+	}
+	metrics.WASMCompilationCacheSize.SetUint64(c.size)
+	return compiled, nil
+}
+
+func (c *compilationCache) get(key string) *compiledModule {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, found := c.entries[key]
+	if !found {
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	compiled := elem.Value.(*compiledModule)
+	compiled.refs++
+	return compiled
+}
+
+// release gives back modules obtained from `acquire`, closing them if they
+// were evicted and no other request uses them.
+func (c *compilationCache) release(ctx context.Context, compiled *compiledModule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	compiled.refs--
+	if compiled.refs == 0 && compiled.evicted {
+		c.closeCompiled(ctx, compiled)
+	}
+}
+
+// evict must be called with `c.mu` held.
+func (c *compilationCache) evict(ctx context.Context, elem *list.Element) {
+	compiled := c.lru.Remove(elem).(*compiledModule)
+	delete(c.entries, compiled.key)
+	c.size -= compiled.size
+	compiled.evicted = true
+	metrics.WASMCompilationCacheEvictions.Inc()
+	zlog.Debug("wasm compilation cache eviction", zap.String("key", compiled.key), zap.Int("refs", compiled.refs))
+
+	if compiled.refs == 0 {
+		c.closeCompiled(ctx, compiled)
+	}
+}
+
+func (c *compilationCache) closeCompiled(ctx context.Context, compiled *compiledModule) {
+	if err := compiled.Close(ctx); err != nil {
+		zlog.Warn("closing compiled wasm module", zap.String("key", compiled.key), zap.Error(err))
+	}
+}
+
+func (c *compilationCache) compile(ctx context.Context, key string, wasmCode []byte, registry *wasm.Registry) (*compiledModule, error) {
+	runtimeConfig := wazero.NewRuntimeConfigCompiler()
+
+	// Each runtime gets its own in-memory cache, over the shared directory, so that
+	// closing evicted modules doesn't remove the compiled code of the others.
+	var wazCache wazero.CompilationCache
+	if c.dir != "" {
+		var err error
+		wazCache, err = wazero.NewCompilationCacheWithDir(c.dir)
+		if err != nil {
+			return nil, fmt.Errorf("creating compilation cache in %q: %w", c.dir, err)
+		}
+		runtimeConfig = runtimeConfig.WithCompilationCache(wazCache)
+	}
+
+	// The runtime outlives the request creating it, so it must not be tied to its context.
+	runtime := wazero.NewRuntimeWithConfig(context.Background(), runtimeConfig)
+	compiled := &compiledModule{
+		key:             key,
+		size:            uint64(len(wasmCode)),
+		wazRuntime:      runtime,
+		wazCache:        wazCache,
+		wazModuleConfig: wazero.NewModuleConfig(),
+	}
+
+	if err := compiled.compile(ctx, wasmCode, registry); err != nil {
+		compiled.Close(ctx)
+		return nil, err
+	}
+	return compiled, nil
+}
+
+func (m *compiledModule) compile(ctx context.Context, wasmCode []byte, registry *wasm.Registry) error {
+	hostModules, err := addExtensionFunctions(ctx, m.wazRuntime, registry)
+	if err != nil {
+		return err
+	}
+	envModule, err := addHostFunctions(ctx, m.wazRuntime, "env", envFuncs)
+	if err != nil {
+		return err
+	}
+	stateModule, err := addHostFunctions(ctx, m.wazRuntime, "state", stateFuncs)
+	if err != nil {
+		return err
+	}
+	loggerModule, err := addHostFunctions(ctx, m.wazRuntime, "logger", loggerFuncs)
+	if err != nil {
+		return err
+	}
+	m.hostModules = append(hostModules, envModule, stateModule, loggerModule)
+
+	code := wasmCode
+	if registry.InstancePoolingEnabled() {
+		exportedCode, globals, err := wasm.ExportMutableGlobals(wasmCode)
+		if err != nil {
+			zlog.Info("instances of wasm module can't be pooled, its globals being unknown", zap.Error(err))
+		} else {
+			code = exportedCode
+			m.globals = globals
+			m.poolable = true
+		}
+	}
+
+	mod, err := m.wazRuntime.CompileModule(ctx, code)
+	if err != nil {
+		return fmt.Errorf("creating new module: %w", err)
+	}
+	m.userModule = mod
+
+	funcs := mod.ExportedFunctions()
+	if funcs["alloc"] == nil {
+		return fmt.Errorf("missing required functions: alloc")
+	}
+	if funcs["dealloc"] == nil {
+		return fmt.Errorf("missing required functions: dealloc")
+	}
+	return nil
+}
+
+func (m *compiledModule) Close(ctx context.Context) error {
+	closeFuncs := []func(context.Context) error{
+		m.wazRuntime.Close,
+	}
+	if m.userModule != nil {
+		closeFuncs = append(closeFuncs, m.userModule.Close)
+	}
+	for _, hostMod := range m.hostModules {
+		closeFuncs = append(closeFuncs, hostMod.Close)
+	}
+	if m.wazCache != nil {
+		closeFuncs = append(closeFuncs, m.wazCache.Close)
+	}
+	for _, f := range closeFuncs {
+		if err := f(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotOf returns the snapshot of `mod`, freshly instantiated, taken once for all
+// the instances of the module, or nil if they can't be pooled.
+func (m *compiledModule) snapshotOf(mod api.Module) *snapshot {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.snapshot != nil || !m.poolable || mod.Memory() == nil {
+		return m.snapshot
+	}
+
+	memory, _ := mod.Memory().Read(0, mod.Memory().Size())
+	s := &snapshot{
+		memory:      append([]byte{}, memory...),
+		globalNames: m.globals,
+	}
+	for _, name := range m.globals {
+		s.globals = append(s.globals, mod.ExportedGlobal(name).Get())
+	}
+	m.snapshot = s
+	return s
+}
+
+// pruneDir removes the oldest files of the cache directory until its size is
+// below `maxDirSize`.
+func (c *compilationCache) pruneDir() {
+	c.dirMu.Lock()
+	defer c.dirMu.Unlock()
+
+	type cacheFile struct {
+		path string
+		info fs.FileInfo
+	}
+	var files []cacheFile
+	var size uint64
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, cacheFile{path, info})
+		size += uint64(info.Size())
+		return nil
+	})
+	if err != nil {
+		zlog.Warn("listing wasm compilation cache directory", zap.String("dir", c.dir), zap.Error(err))
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].info.ModTime().Before(files[j].info.ModTime())
+	})
+	for _, file := range files {
+		if size <= c.maxDirSize {
+			break
+		}
+		if err := os.Remove(file.path); err != nil {
+			zlog.Warn("removing wasm compilation cache file", zap.String("path", file.path), zap.Error(err))
+			continue
+		}
+		size -= uint64(file.info.Size())
+	}
+}
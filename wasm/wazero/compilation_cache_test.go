@@ -0,0 +1,131 @@
+package wazero
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams/wasm"
+)
+
+// testWASMCode returns a module exporting `alloc` and `dealloc`, with a custom
+// section named `name` so that different names give different codes.
+func testWASMCode(name string) []byte {
+	code := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		0x01, 0x0b, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x00,
+		0x03, 0x03, 0x02, 0x00, 0x01,
+		0x07, 0x13, 0x02,
+		0x05, 'a', 'l', 'l', 'o', 'c', 0x00, 0x00,
+		0x07, 'd', 'e', 'a', 'l', 'l', 'o', 'c', 0x00, 0x01,
+		0x0a, 0x09, 0x02, 0x04, 0x00, 0x20, 0x00, 0x0b, 0x02, 0x00, 0x0b,
+	}
+	code = append(code, 0x00, byte(len(name)+2), byte(len(name)))
+	return append(append(code, name...), 0x00)
+}
+
+func TestCompilationCache(t *testing.T) {
+	ctx := context.Background()
+	registry := &wasm.Registry{}
+	extendedRegistry := &wasm.Registry{Extensions: map[string]map[string]wasm.WASMExtension{
+		"eth": {"call": nil},
+	}}
+
+	codeA, codeB := testWASMCode("a"), testWASMCode("b")
+	cache := newCompilationCache(uint64(len(codeA)+len(codeB)-1), "", 0)
+
+	a1, err := cache.acquire(ctx, codeA, registry)
+	require.NoError(t, err)
+	a2, err := cache.acquire(ctx, codeA, registry)
+	require.NoError(t, err)
+	assert.Same(t, a1, a2, "same code and host modules share the compiled module")
+	assert.Equal(t, 2, a1.refs)
+
+	aExtended, err := cache.acquire(ctx, codeA, extendedRegistry)
+	require.NoError(t, err)
+	assert.NotSame(t, a1, aExtended, "other host modules compile again")
+	assert.True(t, a1.evicted, "least recently used is evicted when over size")
+	assert.Len(t, cache.entries, 1)
+
+	_, err = a1.instantiateModule(ctx)
+	assert.NoError(t, err, "evicted modules stay usable until released")
+	cache.release(ctx, a1)
+	_, err = a2.instantiateModule(ctx)
+	assert.NoError(t, err)
+	cache.release(ctx, a2)
+	_, err = a2.instantiateModule(ctx)
+	assert.Error(t, err, "evicted modules are closed once released")
+
+	cache.release(ctx, aExtended)
+	b, err := cache.acquire(ctx, codeB, registry)
+	require.NoError(t, err)
+	cache.release(ctx, b)
+	assert.False(t, b.evicted)
+	assert.True(t, aExtended.evicted)
+	assert.Equal(t, uint64(len(codeB)), cache.size)
+
+	_, err = cache.acquire(ctx, []byte("invalid"), registry)
+	assert.Error(t, err)
+}
+
+func TestCompilationCache_Disabled(t *testing.T) {
+	ctx := context.Background()
+	cache := newCompilationCache(0, "", 0)
+
+	compiled, err := cache.acquire(ctx, testWASMCode("a"), &wasm.Registry{})
+	require.NoError(t, err)
+	assert.True(t, compiled.evicted)
+	assert.Empty(t, cache.entries)
+
+	cache.release(ctx, compiled)
+	_, err = compiled.instantiateModule(ctx)
+	assert.Error(t, err)
+}
+
+func TestCompilationCache_Dir(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cache := newCompilationCache(0, dir, 0)
+
+	compiled, err := cache.acquire(ctx, testWASMCode("a"), &wasm.Registry{})
+	require.NoError(t, err)
+	cache.release(ctx, compiled)
+
+	old := filepath.Join(dir, "old")
+	recent := filepath.Join(dir, "recent")
+	require.NoError(t, os.WriteFile(old, make([]byte, 10), 0644))
+	require.NoError(t, os.WriteFile(recent, make([]byte, 10), 0644))
+	require.NoError(t, os.Chtimes(old, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+
+	cache.maxDirSize = 10
+	cache.pruneDir()
+	assert.NoFileExists(t, old)
+	assert.FileExists(t, recent)
+}
+
+func TestCompilationCache_InstancePooling(t *testing.T) {
+	ctx := context.Background()
+	cache := newCompilationCache(1<<20, "", 0)
+	code := testWASMCode("a")
+
+	t.Setenv("SUBSTREAMS_WASM_INSTANCE_POOLING_ENABLED", "false")
+	registry := wasm.NewRegistry(nil, 0)
+	t.Setenv("SUBSTREAMS_WASM_INSTANCE_POOLING_ENABLED", "true")
+	poolingRegistry := wasm.NewRegistry(nil, 0)
+
+	compiled, err := cache.acquire(ctx, code, registry)
+	require.NoError(t, err)
+	defer cache.release(ctx, compiled)
+	assert.False(t, compiled.poolable, "code is only rewritten when pooling is enabled")
+
+	pooled, err := cache.acquire(ctx, code, poolingRegistry)
+	require.NoError(t, err)
+	defer cache.release(ctx, pooled)
+	assert.NotSame(t, compiled, pooled, "pooling compiles again")
+	assert.True(t, pooled.poolable)
+}
@@ -2,13 +2,25 @@ package wazero
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/tetratelabs/wazero/api"
+
+	"github.com/streamingfast/substreams/wasm"
 )
 
 type instance struct {
 	api.Module
 	allocations []allocation
+	snapshot    *snapshot
+}
+
+// A snapshot is the state of the memory and mutable globals of an instance
+// right after its instantiation.
+type snapshot struct {
+	memory      []byte
+	globalNames []string
+	globals     []uint64
 }
 
 type allocation struct {
@@ -25,9 +37,40 @@ func (i *instance) Close(ctx context.Context) error {
 	return i.Module.Close(ctx)
 }
 
+// Restore restores the snapshot of the instance, if any, and if its memory
+// didn't grow, wazero not being able to shrink it.
+func (i *instance) Restore(ctx context.Context) (bool, error) {
+	if i.snapshot == nil {
+		return false, nil
+	}
+	memory := i.Memory()
+	if memory.Size() != uint32(len(i.snapshot.memory)) {
+		return false, nil
+	}
+	if !memory.Write(0, i.snapshot.memory) {
+		return false, fmt.Errorf("writing memory snapshot")
+	}
+	for j, name := range i.snapshot.globalNames {
+		global, ok := i.ExportedGlobal(name).(api.MutableGlobal)
+		if !ok {
+			return false, fmt.Errorf("global %q is not mutable", name)
+		}
+		global.Set(i.snapshot.globals[j])
+	}
+	i.allocations = nil
+	return true, nil
+}
+
 func instanceFromContext(ctx context.Context) *instance {
 	return ctx.Value("instance").(*instance)
 }
 func withInstanceContext(ctx context.Context, inst *instance) context.Context {
 	return context.WithValue(ctx, "instance", inst)
 }
+
+func registryFromContext(ctx context.Context) *wasm.Registry {
+	return ctx.Value("registry").(*wasm.Registry)
+}
+func withRegistryContext(ctx context.Context, registry *wasm.Registry) context.Context {
+	return context.WithValue(ctx, "registry", registry)
+}
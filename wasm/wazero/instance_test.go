@@ -0,0 +1,76 @@
+package wazero
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// testMemoryWASMCode is a module exporting a memory of one page, `memory`, and
+// a mutable i32 global, `g`.
+var testMemoryWASMCode = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	0x06, 0x06, 0x01, 0x7f, 0x01, 0x41, 0x00, 0x0b,
+	0x07, 0x0e, 0x02,
+	0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+	0x01, 'g', 0x03, 0x00,
+}
+
+func TestInstance_Restore(t *testing.T) {
+	tests := []struct {
+		name          string
+		call          func(mod api.Module)
+		expectRestore bool
+	}{
+		{
+			name: "memory and globals changed",
+			call: func(mod api.Module) {
+				mod.Memory().WriteByte(42, 0xff)
+				mod.ExportedGlobal("g").(api.MutableGlobal).Set(7)
+			},
+			expectRestore: true,
+		},
+		{
+			name: "memory grown",
+			call: func(mod api.Module) {
+				mod.Memory().WriteByte(42, 0xff)
+				_, ok := mod.Memory().Grow(1)
+				require.True(t, ok)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			runtime := wazero.NewRuntime(ctx)
+			defer runtime.Close(ctx)
+
+			mod, err := runtime.Instantiate(ctx, testMemoryWASMCode)
+			require.NoError(t, err)
+			memory, ok := mod.Memory().Read(0, mod.Memory().Size())
+			require.True(t, ok)
+			inst := &instance{
+				Module:   mod,
+				snapshot: &snapshot{memory: append([]byte(nil), memory...), globalNames: []string{"g"}, globals: []uint64{0}},
+			}
+
+			test.call(mod)
+			restored, err := inst.Restore(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectRestore, restored)
+			if !test.expectRestore {
+				return
+			}
+
+			value, _ := mod.Memory().ReadByte(42)
+			assert.Zero(t, value)
+			assert.Zero(t, mod.ExportedGlobal("g").Get())
+		})
+	}
+}
@@ -26,8 +26,8 @@ var loggerFuncs = []funcs{
 				return
 			}
 
-			if length > wasm.MaxLogByteCount {
-				panic(fmt.Errorf("message to log is too big, max size is %s", humanize.IBytes(uint64(length))))
+			if uint64(length) > call.MaxLogByteCount() {
+				panic(fmt.Errorf("message to log is too big, size is %s, max is %s", humanize.IBytes(uint64(length)), humanize.IBytes(call.MaxLogByteCount())))
 			}
 
 			if tracer.Enabled() {
@@ -38,4 +38,36 @@ var loggerFuncs = []funcs{
 			return
 		}),
 	},
+	leveledLoggerFunc("debug", wasm.LogLevelDebug),
+	leveledLoggerFunc("info", wasm.LogLevelInfo),
+	leveledLoggerFunc("warn", wasm.LogLevelWarn),
+	leveledLoggerFunc("error", wasm.LogLevelError),
+}
+
+// leveledLoggerFunc returns the host function logging messages of `level`, with
+// the fields encoded as expected by wasm.DecodeLogFields.
+func leveledLoggerFunc(name string, level wasm.LogLevel) funcs {
+	return funcs{
+		name,
+		[]parm{i32, i32, i32, i32}, // message ptr, len, fields ptr, len
+		[]parm{},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			call := wasm.FromContext(ctx)
+			if call.ReachedLogsMaxByteCount() {
+				return
+			}
+
+			message := readStringFromStack(mod, stack[0:])
+			fields, err := wasm.DecodeLogFields(readBytesFromStack(mod, stack[2:]))
+			if err != nil {
+				panic(fmt.Errorf("logging %s message: %w", name, err))
+			}
+
+			if tracer.Enabled() {
+				zlog.Debug(message, zap.String("module_name", call.ModuleName), zap.Stringer("level", level))
+			}
+
+			call.AppendLeveledLog(level, message, fields)
+		}),
+	}
 }
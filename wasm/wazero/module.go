@@ -12,14 +12,13 @@ import (
 	"github.com/streamingfast/substreams/wasm"
 )
 
-// A Module represents a wazero.Runtime that clears and is destroyed upon completion of a request.
-// It has the pre-compiled `env` host module, as well as pre-compiled WASM code provided by the user
+// A Module is the handle of a request on the runtime compiled for its WASM code,
+// shared through the compilation cache with the other requests running the same code.
 type Module struct {
-	sync.Mutex
-	wazRuntime      wazero.Runtime
-	wazModuleConfig wazero.ModuleConfig
-	hostModules     []wazero.CompiledModule
-	userModule      wazero.CompiledModule
+	*compiledModule
+	cache     *compilationCache
+	registry  *wasm.Registry
+	closeOnce sync.Once
 }
 
 func init() {
@@ -27,83 +26,44 @@ func init() {
 }
 
 func newModule(ctx context.Context, wasmCode []byte, registry *wasm.Registry) (wasm.Module, error) {
-	// What's the effect of `ctx` here? Will it kill all the WASM if it cancels?
-	// TODO: try with: wazero.NewRuntimeConfigCompiler()
-	// TODO: try config := wazero.NewRuntimeConfig().WithCompilationCache(cache)
-	runtimeConfig := wazero.NewRuntimeConfigCompiler()
-	// TODO: can we use some caching in the RuntimeConfig so perhaps we reuse
-	// things across runtimes creations?
-	runtime := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
-	hostModules, err := addExtensionFunctions(ctx, runtime, registry)
+	cache := getCompilationCache()
+	compiled, err := cache.acquire(ctx, wasmCode, registry)
 	if err != nil {
 		return nil, err
 	}
-	envModule, err := addHostFunctions(ctx, runtime, "env", envFuncs)
-	if err != nil {
-		return nil, err
-	}
-	stateModule, err := addHostFunctions(ctx, runtime, "state", stateFuncs)
-	if err != nil {
-		return nil, err
-	}
-	loggerModule, err := addHostFunctions(ctx, runtime, "logger", loggerFuncs)
-	if err != nil {
-		return nil, err
-	}
-	hostModules = append(hostModules, envModule, stateModule, loggerModule)
-
-	// TODO: where to `Close()` the `runtime` here?
-	// One runtime per request?
-	mod, err := runtime.CompileModule(ctx, wasmCode)
-	if err != nil {
-		return nil, fmt.Errorf("creating new module: %w", err)
-	}
-
-	funcs := mod.ExportedFunctions()
-	if funcs["alloc"] == nil {
-		return nil, fmt.Errorf("missing required functions: alloc")
-	}
-	if funcs["dealloc"] == nil {
-		return nil, fmt.Errorf("missing required functions: dealloc")
-	}
 
 	return &Module{
-		wazModuleConfig: wazero.NewModuleConfig(),
-		wazRuntime:      runtime,
-		userModule:      mod,
-		hostModules:     hostModules,
+		compiledModule: compiled,
+		cache:          cache,
+		registry:       registry,
 	}, nil
 }
 
+// Close releases the compiled module, which the compilation cache closes once
+// evicted and unused.
 func (m *Module) Close(ctx context.Context) error {
-	closeFuncs := []func(context.Context) error{
-		m.wazRuntime.Close,
-		m.userModule.Close,
-	}
-	for _, hostMod := range m.hostModules {
-		closeFuncs = append(closeFuncs, hostMod.Close)
-	}
-	for _, f := range closeFuncs {
-		if err := f(ctx); err != nil {
-			return err
-		}
-	}
+	m.closeOnce.Do(func() {
+		m.cache.release(ctx, m.compiledModule)
+	})
 	return nil
 }
 
 func (m *Module) ExecuteNewCall(ctx context.Context, call *wasm.Call, cachedInstance wasm.Instance, arguments []wasm.Argument) (out wasm.Instance, err error) {
-	var mod api.Module
+	var inst *instance
 	if cachedInstance != nil {
-		mod = cachedInstance.(api.Module)
+		inst = cachedInstance.(*instance)
 	} else {
-		mod, err = m.instantiateModule(ctx)
+		mod, err := m.instantiateModule(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("could not instantiate wasm module: %w", err)
 		}
+		inst = &instance{Module: mod}
+		if m.registry.InstancePoolingEnabled() {
+			inst.snapshot = m.snapshotOf(mod)
+		}
 	}
-	inst := &instance{Module: mod}
 
-	f := mod.ExportedFunction(call.Entrypoint)
+	f := inst.ExportedFunction(call.Entrypoint)
 	if f == nil {
 		return inst, fmt.Errorf("could not find entrypoint function %q ", call.Entrypoint)
 	}
@@ -129,15 +89,16 @@ func (m *Module) ExecuteNewCall(ctx context.Context, call *wasm.Call, cachedInst
 		}
 	}
 
-	_, err = f.Call(wasm.WithContext(withInstanceContext(ctx, inst), call), args...)
+	ctx = withRegistryContext(withInstanceContext(ctx, inst), m.registry)
+	_, err = f.Call(wasm.WithContext(ctx, call), args...)
 	if err != nil {
-		return inst, fmt.Errorf("call: %w", err)
+		return inst, fmt.Errorf("call: %w", toTrapError(err))
 	}
 
 	return inst, nil
 }
 
-func (m *Module) instantiateModule(ctx context.Context) (api.Module, error) {
+func (m *compiledModule) instantiateModule(ctx context.Context) (api.Module, error) {
 	m.Lock()
 	defer m.Unlock()
 
@@ -156,8 +117,10 @@ func (m *Module) instantiateModule(ctx context.Context) (api.Module, error) {
 
 func addExtensionFunctions(ctx context.Context, runtime wazero.Runtime, registry *wasm.Registry) (out []wazero.CompiledModule, err error) {
 	for namespace, imports := range registry.Extensions {
+		namespace := namespace
 		builder := runtime.NewHostModuleBuilder(namespace)
-		for importName, f := range imports {
+		for importName := range imports {
+			importName := importName
 			builder.NewFunctionBuilder().
 				WithGoFunction(api.GoFunc(func(ctx context.Context, stack []uint64) {
 					inst := instanceFromContext(ctx)
@@ -165,6 +128,9 @@ func addExtensionFunctions(ctx context.Context, runtime wazero.Runtime, registry
 					data := readBytes(inst, ptr, length)
 					call := wasm.FromContext(ctx)
 
+					// The runtime is shared by the registries running the same code, so the
+					// extension is the one of the registry of the request.
+					f := registryFromContext(ctx).Extensions[namespace][importName]
 					out, err := f(ctx, reqctx.Details(ctx).UniqueIDString(), call.Clock, data)
 					if err != nil {
 						panic(fmt.Errorf(`running wasm extension "%s::%s": %w`, namespace, importName, err))
@@ -6,6 +6,7 @@ import (
 
 	"github.com/tetratelabs/wazero/api"
 
+	"github.com/streamingfast/substreams/storage/store"
 	"github.com/streamingfast/substreams/wasm"
 )
 
@@ -75,6 +76,33 @@ var stateFuncs = []funcs{
 			call.DoDeletePrefix(ord, prefix)
 		}),
 	},
+	{
+		"delete_range",
+		[]parm{i64, i32, i32, i32, i32},
+		[]parm{},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			ord := stack[0]
+			lowKey := readStringFromStack(mod, stack[1:])
+			highKey := readStringFromStack(mod, stack[3:])
+			call := wasm.FromContext(ctx)
+
+			call.DoDeleteRange(ord, lowKey, highKey)
+		}),
+	},
+	{
+		"delete_range_pointers",
+		[]parm{i64, i32, i32, i32, i32, i32, i32},
+		[]parm{},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			ord := stack[0]
+			lowKey := readStringFromStack(mod, stack[1:])
+			highKey := readStringFromStack(mod, stack[3:])
+			separator := readStringFromStack(mod, stack[5:])
+			call := wasm.FromContext(ctx)
+
+			call.DoDeleteRangePointers(ord, lowKey, highKey, separator)
+		}),
+	},
 	{
 		"add_bigint",
 		[]parm{i64, i32, i32, i32, i32},
@@ -321,6 +349,104 @@ var stateFuncs = []funcs{
 			setStack0Bool(stack, found)
 		}),
 	},
+	{
+		"scan_range_first",
+		[]parm{i32, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			keyRange := store.KeyRange{Low: readStringFromStack(mod, stack[1:]), High: readStringFromStack(mod, stack[3:])}
+			limit := int(uint32(stack[5]))
+			outputPtr := uint32(stack[6])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, result := call.DoScanFirst(int(storeIndex), keyRange, limit)
+			setStackAndScanOutput(ctx, stack, call, inst, outputPtr, page, result)
+		}),
+	},
+	{
+		"scan_range_last",
+		[]parm{i32, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			keyRange := store.KeyRange{Low: readStringFromStack(mod, stack[1:]), High: readStringFromStack(mod, stack[3:])}
+			limit := int(uint32(stack[5]))
+			outputPtr := uint32(stack[6])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, result := call.DoScanLast(int(storeIndex), keyRange, limit)
+			setStackAndScanOutput(ctx, stack, call, inst, outputPtr, page, result)
+		}),
+	},
+	{
+		"scan_range_at",
+		[]parm{i32, i64, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			ord := stack[1]
+			keyRange := store.KeyRange{Low: readStringFromStack(mod, stack[2:]), High: readStringFromStack(mod, stack[4:])}
+			limit := int(uint32(stack[6]))
+			outputPtr := uint32(stack[7])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, result := call.DoScanAt(int(storeIndex), ord, keyRange, limit)
+			setStackAndScanOutput(ctx, stack, call, inst, outputPtr, page, result)
+		}),
+	},
+	{
+		"scan_prefix_first",
+		[]parm{i32, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			keyRange := wasm.PrefixScanRange(readStringFromStack(mod, stack[1:]), readStringFromStack(mod, stack[3:]))
+			limit := int(uint32(stack[5]))
+			outputPtr := uint32(stack[6])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, result := call.DoScanFirst(int(storeIndex), keyRange, limit)
+			setStackAndScanOutput(ctx, stack, call, inst, outputPtr, page, result)
+		}),
+	},
+	{
+		"scan_prefix_last",
+		[]parm{i32, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			keyRange := wasm.PrefixScanRange(readStringFromStack(mod, stack[1:]), readStringFromStack(mod, stack[3:]))
+			limit := int(uint32(stack[5]))
+			outputPtr := uint32(stack[6])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, result := call.DoScanLast(int(storeIndex), keyRange, limit)
+			setStackAndScanOutput(ctx, stack, call, inst, outputPtr, page, result)
+		}),
+	},
+	{
+		"scan_prefix_at",
+		[]parm{i32, i64, i32, i32, i32, i32, i32, i32},
+		[]parm{i32},
+		api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			storeIndex := uint32(stack[0])
+			ord := stack[1]
+			keyRange := wasm.PrefixScanRange(readStringFromStack(mod, stack[2:]), readStringFromStack(mod, stack[4:]))
+			limit := int(uint32(stack[6]))
+			outputPtr := uint32(stack[7])
+			call := wasm.FromContext(ctx)
+			inst := instanceFromContext(ctx)
+
+			page, result := call.DoScanAt(int(storeIndex), ord, keyRange, limit)
+			setStackAndScanOutput(ctx, stack, call, inst, outputPtr, page, result)
+		}),
+	},
 }
 
 func setStackAndOutput(ctx context.Context, stack []uint64, call *wasm.Call, found bool, inst *instance, outputPtr uint32, value []byte) {
@@ -334,6 +460,15 @@ func setStackAndOutput(ctx context.Context, stack []uint64, call *wasm.Call, fou
 	}
 }
 
+func setStackAndScanOutput(ctx context.Context, stack []uint64, call *wasm.Call, inst *instance, outputPtr uint32, page []byte, result int32) {
+	if result != wasm.ScanEmpty {
+		if err := writeOutputToHeap(ctx, inst, outputPtr, page); err != nil {
+			call.ReturnError(fmt.Errorf("writing output to heap: %w", err))
+		}
+	}
+	stack[0] = uint64(result)
+}
+
 func setStack0Bool(stack []uint64, value bool) {
 	if value {
 		stack[0] = 1
@@ -0,0 +1,56 @@
+package wazero
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/streamingfast/substreams/wasm"
+)
+
+const (
+	trapPrefix       = "wasm error: "
+	stackTraceHeader = "wasm stack trace:"
+)
+
+// signatureRegex matches the signature wazero appends to the function names of
+// its stack traces, like `(i32,i32) i64`.
+var signatureRegex = regexp.MustCompile(`\([a-z0-9,]*\)( [a-z0-9]+| \([a-z0-9,]*\))?$`)
+
+// toTrapError turns the error of a call trapping into a wasm.TrapError, wazero
+// only giving the stack trace, symbolicated from the `name` section and the
+// DWARF debug information of the code, in the error message. Other errors, like
+// the panics of host functions, are returned as is.
+func toTrapError(err error) error {
+	message := err.Error()
+	if !strings.HasPrefix(message, trapPrefix) {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimPrefix(message, trapPrefix), "\n")
+	trap := &wasm.TrapError{Message: lines[0]}
+	inStackTrace := false
+	for _, line := range lines[1:] {
+		switch {
+		case line == stackTraceHeader:
+			inStackTrace = true
+		case !inStackTrace:
+		case strings.HasPrefix(line, "\t\t"):
+			// the source of the previous frame, like `0x1f: /src/lib.rs:12:5`, followed
+			// by the inlined calls leading to it
+			if len(trap.Frames) == 0 || trap.Frames[len(trap.Frames)-1].Location != "" {
+				continue
+			}
+			location := strings.TrimSpace(line)
+			if _, after, found := strings.Cut(location, ": "); found {
+				location = after
+			}
+			trap.Frames[len(trap.Frames)-1].Location = location
+		case strings.HasPrefix(line, "\t"):
+			function := signatureRegex.ReplaceAllString(strings.TrimPrefix(line, "\t"), "")
+			// the user module being instantiated without a name, its functions are `.<name>`
+			function = strings.TrimPrefix(function, ".")
+			trap.Frames = append(trap.Frames, wasm.StackFrame{Function: function})
+		}
+	}
+	return trap
+}
@@ -0,0 +1,39 @@
+package wazero
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+
+	"github.com/streamingfast/substreams/wasm"
+)
+
+func TestToTrapError(t *testing.T) {
+	ctx := context.Background()
+	code, err := os.ReadFile("../testdata/trap.wasm")
+	require.NoError(t, err)
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfigCompiler())
+	defer runtime.Close(ctx)
+	mod, err := runtime.Instantiate(ctx, code)
+	require.NoError(t, err)
+
+	_, err = mod.ExportedFunction("run").Call(ctx)
+	require.Error(t, err)
+
+	var trap *wasm.TrapError
+	require.True(t, errors.As(toTrapError(err), &trap), err.Error())
+	assert.Equal(t, "unreachable", trap.Message)
+	assert.Equal(t, []wasm.StackFrame{
+		{Function: "boom", Location: "src/lib.rs:14:5"},
+		{Function: "run", Location: "src/lib.rs:20:5"},
+	}, trap.Frames)
+
+	hostErr := errors.New("running wasm extension \"eth::call\": timeout (recovered by wazero)\nwasm stack trace:\n\t.run()")
+	assert.Same(t, hostErr, toTrapError(hostErr))
+}